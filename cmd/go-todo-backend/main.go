@@ -1,23 +1,40 @@
 package main
 
 import (
+	"database/sql"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+	_ "time/tzdata" // embed the IANA time zones for the tz parameter
 
 	"github.com/irenicaa/go-http-utils/middlewares"
 	"github.com/irenicaa/go-todo-backend/v2/gateways/db"
 	"github.com/irenicaa/go-todo-backend/v2/gateways/handlers"
+	"github.com/irenicaa/go-todo-backend/v2/gateways/memory"
+	"github.com/irenicaa/go-todo-backend/v2/gateways/migrator"
+	"github.com/irenicaa/go-todo-backend/v2/gateways/sqlite"
+	sqlitemigrations "github.com/irenicaa/go-todo-backend/v2/gateways/sqlite/migrations"
+	"github.com/irenicaa/go-todo-backend/v2/migrations"
+	"github.com/irenicaa/go-todo-backend/v2/models"
 	usecases "github.com/irenicaa/go-todo-backend/v2/use-cases"
 )
 
+const sqliteDSNPrefix = "sqlite://"
+
 func main() {
 	port, ok := os.LookupEnv("PORT")
 	if !ok {
 		port = "8080"
 	}
+	storageKind, ok := os.LookupEnv("STORAGE")
+	if !ok {
+		storageKind = "db"
+	}
 	dbDSN, ok := os.LookupEnv("DB_DSN")
 	if !ok {
 		dbDSN = db.DefaultDataSourceName
@@ -25,20 +42,117 @@ func main() {
 	flag.Parse()
 
 	logger := log.New(os.Stderr, "", log.Ldate|log.Ltime|log.Lmicroseconds)
-	dbPool, err := db.OpenDB(dbDSN)
+	limits, err := getValidationLimits()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	trashRetention, err := getDurationVariable("TRASH_RETENTION", 30*24*time.Hour)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	trashPurgeInterval, err :=
+		getDurationVariable("TRASH_PURGE_INTERVAL", time.Hour)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	defaultSort, err := getDefaultSort()
 	if err != nil {
 		logger.Fatal(err)
 	}
 
+	if flag.NArg() != 0 {
+		if flag.Arg(0) != "migrate" {
+			logger.Fatalf("unknown command: %q", flag.Arg(0))
+		}
+		if storageKind != "db" {
+			logger.Fatal("migrations are supported only by the db storage")
+		}
+
+		dbPool, dbMigrator, err := openDB(dbDSN)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		defer dbPool.Close()
+
+		if err := runMigrateCommand(dbMigrator, flag.Args()[1:]); err != nil {
+			logger.Fatal(err)
+		}
+
+		return
+	}
+
+	var storage usecases.TodoRecordStorage
+	var listStorage usecases.ListStorage
+	var tagStorage usecases.TagStorage
+	switch storageKind {
+	case "db":
+		dbPool, dbMigrator, err := openDB(dbDSN)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		appliedMigrations, err := dbMigrator.Up()
+		if err != nil {
+			logger.Fatal(err)
+		}
+		for _, migration := range appliedMigrations {
+			logger.Printf(
+				"migration %06d_%s is applied",
+				migration.Version,
+				migration.Name,
+			)
+		}
+
+		if strings.HasPrefix(dbDSN, sqliteDSNPrefix) {
+			storage = sqlite.NewTodoRecord(dbPool)
+			listStorage = sqlite.NewList(dbPool)
+			tagStorage = sqlite.NewTag(dbPool)
+		} else {
+			storage = db.NewTodoRecord(dbPool)
+			listStorage = db.NewList(dbPool)
+			tagStorage = db.NewTag(dbPool)
+		}
+	case "memory":
+		memoryDB := memory.NewDB()
+		storage = memory.NewTodoRecord(memoryDB)
+		listStorage = memory.NewList(memoryDB)
+		tagStorage = memory.NewTag(memoryDB)
+	default:
+		logger.Fatalf("unknown storage kind: %q", storageKind)
+	}
+
+	todoRecordUseCase := usecases.TodoRecord{
+		Storage:     storage,
+		Limits:      limits,
+		DefaultSort: defaultSort,
+	}
+	// the zero interval disables the background purge
+	if trashPurgeInterval != 0 {
+		go purgeTrash(todoRecordUseCase, trashRetention, trashPurgeInterval, logger)
+	}
+
+	listUseCase := usecases.List{
+		Storage:    listStorage,
+		TodoRecord: todoRecordUseCase,
+		Limits:     limits,
+	}
 	handler := middlewares.LoggingMiddleware(
 		middlewares.CORSMiddleware(handlers.Router{
 			BaseURL: "/api/v1",
 			TodoRecord: handlers.TodoRecord{
+				URLScheme:   "http",
+				UseCase:     todoRecordUseCase,
+				ListUseCase: listUseCase,
+				Logger:      logger,
+			},
+			List: handlers.List{
 				URLScheme: "http",
-				UseCase: usecases.TodoRecord{
-					Storage: db.NewTodoRecord(dbPool),
-				},
-				Logger: logger,
+				UseCase:   listUseCase,
+				Logger:    logger,
+			},
+			Tag: handlers.Tag{
+				UseCase: usecases.Tag{Storage: tagStorage},
+				Logger:  logger,
 			},
 			Logger: logger,
 		}),
@@ -49,3 +163,116 @@ func main() {
 		logger.Fatal(err)
 	}
 }
+
+func getValidationLimits() (models.ValidationLimits, error) {
+	limits := models.DefaultValidationLimits
+	for _, limit := range []struct {
+		name  string
+		value *int
+	}{
+		{name: "MAXIMAL_TITLE_LENGTH", value: &limits.MaximalTitleLength},
+		{name: "MAXIMAL_NOTES_LENGTH", value: &limits.MaximalNotesLength},
+		{name: "MINIMAL_ORDER", value: &limits.MinimalOrder},
+		{name: "MAXIMAL_ORDER", value: &limits.MaximalOrder},
+		{name: "MAXIMAL_TAG_LENGTH", value: &limits.MaximalTagLength},
+		{name: "MAXIMAL_TAG_COUNT", value: &limits.MaximalTagCount},
+		{name: "MAXIMAL_BATCH_SIZE", value: &limits.MaximalBatchSize},
+	} {
+		rawValue, ok := os.LookupEnv(limit.name)
+		if !ok {
+			continue
+		}
+
+		value, err := strconv.Atoi(rawValue)
+		if err != nil {
+			return models.ValidationLimits{},
+				fmt.Errorf("unable to parse the %s variable: %w", limit.name, err)
+		}
+
+		*limit.value = value
+	}
+
+	return limits, nil
+}
+
+func getDurationVariable(name string, defaultValue time.Duration) (
+	time.Duration,
+	error,
+) {
+	rawValue, ok := os.LookupEnv(name)
+	if !ok {
+		return defaultValue, nil
+	}
+
+	value, err := time.ParseDuration(rawValue)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse the %s variable: %w", name, err)
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("the %s variable is negative", name)
+	}
+
+	return value, nil
+}
+
+func getDefaultSort() ([]models.SortField, error) {
+	rawValue, ok := os.LookupEnv("DEFAULT_SORT")
+	if !ok {
+		return models.DefaultSort, nil
+	}
+
+	defaultSort, err := models.ParseDefaultSort(rawValue)
+	if err != nil {
+		return nil,
+			fmt.Errorf("unable to parse the DEFAULT_SORT variable: %w", err)
+	}
+
+	return defaultSort, nil
+}
+
+func purgeTrash(
+	useCase usecases.TodoRecord,
+	retention time.Duration,
+	interval time.Duration,
+	logger *log.Logger,
+) {
+	for range time.Tick(interval) {
+		count, err := useCase.PurgeTrash(retention)
+		if err != nil {
+			logger.Print(err)
+			continue
+		}
+		if count != 0 {
+			logger.Printf("%d to-do records are purged from the trash", count)
+		}
+	}
+}
+
+func openDB(dataSourceName string) (*sql.DB, migrator.Migrator, error) {
+	var dbPool *sql.DB
+	var dbMigrations []migrator.Migration
+	var err error
+	if strings.HasPrefix(dataSourceName, sqliteDSNPrefix) {
+		dbPool, err = sqlite.OpenDB(
+			strings.TrimPrefix(dataSourceName, sqliteDSNPrefix),
+		)
+		if err != nil {
+			return nil, migrator.Migrator{}, err
+		}
+
+		dbMigrations, err = migrator.LoadMigrations(sqlitemigrations.FS)
+	} else {
+		dbPool, err = db.OpenDB(dataSourceName)
+		if err != nil {
+			return nil, migrator.Migrator{}, err
+		}
+
+		dbMigrations, err = migrator.LoadMigrations(migrations.FS)
+	}
+	if err != nil {
+		dbPool.Close()
+		return nil, migrator.Migrator{}, err
+	}
+
+	return dbPool, migrator.NewMigrator(dbPool, dbMigrations), nil
+}
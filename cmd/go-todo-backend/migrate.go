@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/irenicaa/go-todo-backend/v2/gateways/migrator"
+)
+
+func runMigrateCommand(dbMigrator migrator.Migrator, arguments []string) error {
+	if len(arguments) != 1 {
+		return errors.New("usage: migrate up | down | status")
+	}
+
+	switch arguments[0] {
+	case "up":
+		appliedMigrations, err := dbMigrator.Up()
+		for _, migration := range appliedMigrations {
+			fmt.Printf("%06d_%s: applied\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(appliedMigrations) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		revertedMigration, err := dbMigrator.Down()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf(
+			"%06d_%s: reverted\n",
+			revertedMigration.Version,
+			revertedMigration.Name,
+		)
+	case "status":
+		statuses, err := dbMigrator.Status()
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied"
+			}
+
+			fmt.Printf(
+				"%06d_%s: %s\n",
+				status.Migration.Version,
+				status.Migration.Name,
+				state,
+			)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command: %q", arguments[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/lib/pq"
+)
+
+func wrapError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrNotFound
+	}
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	switch pqErr.Code.Name() {
+	case "unique_violation", "foreign_key_violation", "exclusion_violation":
+		return fmt.Errorf("%w: %s", models.ErrConflict, pqErr.Message)
+	case "not_null_violation", "check_violation":
+		return fmt.Errorf("%w: %s", models.ErrInvalid, pqErr.Message)
+	}
+	if pqErr.Code.Class() == "22" { // data exception
+		return fmt.Errorf("%w: %s", models.ErrInvalid, pqErr.Message)
+	}
+
+	return err
+}
+
+func checkAffectedRows(result sql.Result) error {
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to get the number of affected rows: %v", err)
+	}
+	if affectedRows == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+// +build integration
+
+package db
+
+import (
+	"testing"
+
+	"github.com/irenicaa/go-todo-backend/v2/gateways/storagetest"
+)
+
+func TestList(t *testing.T) {
+	storagetest.RunListTests(t, newStorages)
+}
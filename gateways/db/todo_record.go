@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -9,11 +10,65 @@ import (
 
 	utilmodels "github.com/irenicaa/go-http-utils/models"
 	"github.com/irenicaa/go-todo-backend/v2/models"
+	usecases "github.com/irenicaa/go-todo-backend/v2/use-cases"
+	"github.com/lib/pq"
 )
 
+const (
+	todoRecordTagsSubquery = `ARRAY(
+		SELECT tags.name
+		FROM todo_record_tags JOIN tags ON tags.id = todo_record_tags.tag_id
+		WHERE todo_record_tags.todo_record_id = todo_records.id
+		ORDER BY tags.name
+	)`
+	// todoRecordSearchQuery refers to the first argument
+	// of the makeTodoRecordWhere() result
+	todoRecordSearchQuery = "websearch_to_tsquery('english', $1)"
+	// todoRecordChildrenSource selects the direct children of the to-do record
+	// except the trashed ones
+	todoRecordChildrenSource = `todo_records AS children
+		WHERE children.parent_id = todo_records.id AND children.deleted_at IS NULL`
+	// todoRecordBlockedCondition checks the open blockers of the to-do record
+	todoRecordBlockedCondition = `EXISTS (
+		SELECT 1
+		FROM todo_dependencies
+			JOIN todo_records AS blockers
+				ON blockers.id = todo_dependencies.blocker_id
+		WHERE todo_dependencies.todo_record_id = todo_records.id
+			AND NOT blockers.completed
+			AND blockers.deleted_at IS NULL
+	)`
+	todoRecordColumns = `id, title, notes, completed, "order", priority, "date",
+		due_at, recurrence, list_id, parent_id, version, created_at, updated_at,
+		completed_at, deleted_at,
+		(SELECT count(*) FROM ` + todoRecordChildrenSource + `),
+		(SELECT count(*) FROM ` + todoRecordChildrenSource + ` AND children.completed), ` +
+		todoRecordBlockedCondition + `, ARRAY(
+		SELECT blocker_id
+		FROM todo_dependencies
+		WHERE todo_record_id = todo_records.id
+		ORDER BY blocker_id
+	), ` + todoRecordTagsSubquery
+)
+
+var todoRecordSortColumns = map[string]string{
+	models.SortByID:        "id",
+	models.SortByDate:      `"date"`,
+	models.SortByTitle:     "title",
+	models.SortByCompleted: "completed",
+	models.SortByOrder:     `"order"`,
+	models.SortByPriority:  "priority",
+	// the relevance depends on the search vector,
+	// see the makeTodoRecordOrder() function
+	models.SortByCreatedAt:   "created_at",
+	models.SortByUpdatedAt:   "updated_at",
+	models.SortByCompletedAt: "completed_at",
+}
+
 // TodoRecord ...
 type TodoRecord struct {
 	pool *sql.DB
+	tx   *sql.Tx
 }
 
 // NewTodoRecord ...
@@ -23,29 +78,39 @@ func NewTodoRecord(pool *sql.DB) TodoRecord {
 
 // GetAll ...
 func (db TodoRecord) GetAll(query models.Query) ([]models.TodoRecord, error) {
-	sql := "SELECT * FROM todo_records"
-	var args []interface{}
+	where, args := makeTodoRecordWhere(query)
 
-	sql += " WHERE TRUE"
-	var argNumber int
-	if query.MinimalDate != (utilmodels.Date{}) {
-		argNumber++
-		sql += " AND date >= $" + strconv.Itoa(argNumber)
-		args = append(args, time.Time(query.MinimalDate))
+	columns := todoRecordColumns
+	isHighlighted := query.Highlight && query.Search != ""
+	if isHighlighted {
+		columns += ", " + makeTodoRecordHeadline("title")
+		if query.SearchNotes {
+			columns += ", " + makeTodoRecordHeadline("notes")
+		}
 	}
-	if query.MaximalDate != (utilmodels.Date{}) {
-		argNumber++
-		sql += " AND date <= $" + strconv.Itoa(argNumber)
-		args = append(args, time.Time(query.MaximalDate))
+
+	sql := "SELECT " + columns + " FROM todo_records" + where
+
+	if query.Pagination.IsCursorMode() &&
+		query.Pagination.Cursor != (models.Cursor{}) {
+		cursorCondition, cursorArgs := makeTodoRecordCursorCondition(
+			query.Sort,
+			query.Pagination.Cursor,
+			len(args),
+		)
+		sql += " AND " + cursorCondition
+		args = append(args, cursorArgs...)
 	}
-	if query.TitleFragment != "" {
-		argNumber++
-		sql += " AND lower(title) LIKE $" + strconv.Itoa(argNumber)
-		args = append(args, "%"+strings.ToLower(query.TitleFragment)+"%")
+
+	var searchVector string
+	if query.Search != "" {
+		searchVector = getTodoRecordSearchVector(query.SearchNotes)
 	}
 
-	sql += ` ORDER BY "date" DESC, "order", id`
-	if query.Pagination != (models.Pagination{}) {
+	sql += makeTodoRecordOrder(query.Sort, searchVector)
+	if query.Pagination.IsCursorMode() {
+		sql += fmt.Sprintf(" LIMIT %d", query.Pagination.PageSize)
+	} else if query.Pagination != (models.Pagination{}) {
 		sql += fmt.Sprintf(
 			" OFFSET %d LIMIT %d",
 			(query.Pagination.Page-1)*query.Pagination.PageSize,
@@ -53,25 +118,32 @@ func (db TodoRecord) GetAll(query models.Query) ([]models.TodoRecord, error) {
 		)
 	}
 
-	rows, err := db.pool.Query(sql, args...)
+	rows, err := db.getExecutor().Query(sql, args...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create a cursor: %v", err)
+		return nil, fmt.Errorf("unable to create a cursor: %w", wrapError(err))
 	}
 	defer rows.Close()
 
 	var todos []models.TodoRecord
 	for rows.Next() {
-		var todo models.TodoRecord
-		err := rows.Scan(
-			&todo.ID,
-			&todo.Title,
-			&todo.Completed,
-			&todo.Order,
-			&todo.Date,
-		)
+		var highlight, notesHighlight string
+		var extraDestinations []interface{}
+		if isHighlighted {
+			extraDestinations = append(extraDestinations, &highlight)
+			if query.SearchNotes {
+				extraDestinations = append(extraDestinations, &notesHighlight)
+			}
+		}
+
+		todo, err := scanTodoRecord(rows, extraDestinations...)
 		if err != nil {
 			return nil, fmt.Errorf("unable to unmarshal the row: %v", err)
 		}
+		todo.Highlight = highlight
+		// the headline of the unmatched notes doesn't contain the marks
+		if strings.Contains(notesHighlight, models.HighlightStart) {
+			todo.NotesHighlight = notesHighlight
+		}
 
 		todos = append(todos, todo)
 	}
@@ -79,54 +151,756 @@ func (db TodoRecord) GetAll(query models.Query) ([]models.TodoRecord, error) {
 	return todos, nil
 }
 
+// Count ...
+func (db TodoRecord) Count(query models.Query) (int, error) {
+	where, args := makeTodoRecordWhere(query)
+
+	var count int
+	err := db.getExecutor().
+		QueryRow("SELECT count(*) FROM todo_records"+where, args...).
+		Scan(&count)
+	if err != nil {
+		return 0,
+			fmt.Errorf("unable to count the to-do records: %w", wrapError(err))
+	}
+
+	return count, nil
+}
+
 // GetSingle ...
 func (db TodoRecord) GetSingle(id int) (models.TodoRecord, error) {
-	var todo models.TodoRecord
-	err := db.pool.
-		QueryRow("SELECT * FROM todo_records WHERE id = $1", id).
-		Scan(&todo.ID, &todo.Title, &todo.Completed, &todo.Order, &todo.Date)
-	return todo, err
+	return getSingle(db.getExecutor(), id)
 }
 
 // Create ...
-func (db TodoRecord) Create(todo models.TodoRecord) (id int, err error) {
-	err = db.pool.
-		QueryRow(
-			`INSERT INTO todo_records (title, completed, "order", "date")
-			VALUES ($1, $2, $3, $4)
-			RETURNING id`,
-			todo.Title,
-			todo.Completed,
-			todo.Order,
-			todo.Date,
-		).
-		Scan(&id)
-	return id, err
+func (db TodoRecord) Create(todo models.TodoRecord) (
+	models.TodoRecord,
+	error,
+) {
+	var createdTodo models.TodoRecord
+	err := db.inTransaction(func(tx *sql.Tx) error {
+		var id int
+		err := tx.
+			QueryRow(
+				`INSERT INTO todo_records
+					(
+						title,
+						completed,
+						"order",
+						"date",
+						list_id,
+						completed_at,
+						due_at,
+						recurrence,
+						parent_id,
+						notes,
+						priority
+					)
+				VALUES (
+					$1,
+					$2,
+					$3,
+					$4,
+					NULLIF($5, 0),
+					CASE WHEN $2 THEN now() END,
+					$6,
+					$7,
+					NULLIF($8, 0),
+					$9,
+					$10
+				)
+				RETURNING id`,
+				todo.Title,
+				todo.Completed,
+				todo.Order,
+				todo.Date,
+				todo.ListID,
+				todo.DueAt,
+				todo.Recurrence,
+				todo.ParentID,
+				todo.Notes,
+				todo.Priority,
+			).
+			Scan(&id)
+		if err != nil {
+			return wrapError(err)
+		}
+
+		if err := setTags(tx, id, todo.Tags); err != nil {
+			return err
+		}
+
+		createdTodo, err = getSingle(tx, id)
+		return err
+	})
+	if err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	return createdTodo, nil
 }
 
 // Update ...
-func (db TodoRecord) Update(id int, todo models.TodoRecord) error {
-	_, err := db.pool.Exec(
+func (db TodoRecord) Update(
+	id int,
+	todo models.TodoRecord,
+	version int,
+) (models.TodoRecord, error) {
+	var updatedTodo models.TodoRecord
+	err := db.inTransaction(func(tx *sql.Tx) error {
+		err := tx.
+			QueryRow(
+				`UPDATE todo_records
+				SET
+					title = $1,
+					notes = $11,
+					completed = $2,
+					"order" = $3,
+					priority = $12,
+					"date" = $4,
+					due_at = $8,
+					recurrence = $9,
+					list_id = NULLIF($7, 0),
+					parent_id = NULLIF($10, 0),
+					version = version + 1,
+					updated_at = now(),
+					completed_at = CASE
+						WHEN NOT $2 THEN NULL
+						WHEN completed THEN completed_at
+						ELSE now()
+					END
+				WHERE id = $5 AND ($6 = 0 OR version = $6) AND deleted_at IS NULL
+				RETURNING id`,
+				todo.Title,
+				todo.Completed,
+				todo.Order,
+				todo.Date,
+				id,
+				version,
+				todo.ListID,
+				todo.DueAt,
+				todo.Recurrence,
+				todo.ParentID,
+				todo.Notes,
+				todo.Priority,
+			).
+			Scan(&id)
+		if err != nil {
+			return wrapError(err)
+		}
+
+		if err := setTags(tx, id, todo.Tags); err != nil {
+			return err
+		}
+
+		updatedTodo, err = getSingle(tx, id)
+		return err
+	})
+	if err != nil {
+		return models.TodoRecord{}, db.wrapVersionError(id, err)
+	}
+
+	return updatedTodo, nil
+}
+
+// Patch ...
+func (db TodoRecord) Patch(
+	id int,
+	todoPatch models.TodoRecordPatch,
+	version int,
+) (models.TodoRecord, error) {
+	var date *time.Time
+	if todoPatch.Date != nil {
+		date = (*time.Time)(todoPatch.Date)
+	}
+
+	var patchedTodo models.TodoRecord
+	err := db.inTransaction(func(tx *sql.Tx) error {
+		err := tx.
+			QueryRow(
+				`UPDATE todo_records
+				SET
+					title = COALESCE($1::text, title),
+					notes = COALESCE($12::text, notes),
+					completed = COALESCE($2::boolean, completed),
+					"order" = COALESCE($3::integer, "order"),
+					priority = COALESCE($13::integer, priority),
+					"date" = COALESCE($4::date, "date"),
+					due_at = CASE WHEN $8 THEN $9::timestamptz ELSE due_at END,
+					recurrence = COALESCE($10::text, recurrence),
+					list_id = CASE
+						WHEN $7::integer IS NULL THEN list_id
+						ELSE NULLIF($7::integer, 0)
+					END,
+					parent_id = CASE
+						WHEN $11::integer IS NULL THEN parent_id
+						ELSE NULLIF($11::integer, 0)
+					END,
+					version = version + 1,
+					updated_at = now(),
+					completed_at = CASE
+						WHEN NOT COALESCE($2::boolean, completed) THEN NULL
+						WHEN completed THEN completed_at
+						ELSE now()
+					END
+				WHERE id = $5 AND ($6 = 0 OR version = $6) AND deleted_at IS NULL
+				RETURNING id`,
+				todoPatch.Title,
+				todoPatch.Completed,
+				todoPatch.Order,
+				date,
+				id,
+				version,
+				todoPatch.ListID,
+				todoPatch.DueAt.IsSet,
+				todoPatch.DueAt.Value,
+				todoPatch.Recurrence,
+				todoPatch.ParentID,
+				todoPatch.Notes,
+				todoPatch.Priority,
+			).
+			Scan(&id)
+		if err != nil {
+			return wrapError(err)
+		}
+
+		if todoPatch.Tags != nil {
+			if err := setTags(tx, id, *todoPatch.Tags); err != nil {
+				return err
+			}
+		}
+
+		patchedTodo, err = getSingle(tx, id)
+		return err
+	})
+	if err != nil {
+		return models.TodoRecord{}, db.wrapVersionError(id, err)
+	}
+
+	return patchedTodo, nil
+}
+
+// DeleteAll moves the to-do records to the trash;
+// it ignores the pagination and sorting of the query.
+func (db TodoRecord) DeleteAll(query models.Query) (int, error) {
+	query.Deleted = false
+	where, args := makeTodoRecordWhere(query)
+	result, err := db.getExecutor().
+		Exec("UPDATE todo_records SET deleted_at = now()"+where, args...)
+	if err != nil {
+		return 0, wrapError(err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0,
+			fmt.Errorf("unable to get the number of affected rows: %v", err)
+	}
+
+	return int(count), nil
+}
+
+// DeleteSingle moves the to-do record to the trash.
+func (db TodoRecord) DeleteSingle(id int, version int) error {
+	result, err := db.getExecutor().Exec(
 		`UPDATE todo_records
-		SET title = $1, completed = $2, "order" = $3, "date" = $4
-		WHERE id = $5`,
-		todo.Title,
-		todo.Completed,
-		todo.Order,
-		todo.Date,
+		SET deleted_at = now()
+		WHERE id = $1 AND ($2 = 0 OR version = $2) AND deleted_at IS NULL`,
 		id,
+		version,
 	)
-	return err
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return db.wrapVersionError(id, checkAffectedRows(result))
+}
+
+// Restore moves the to-do record back from the trash.
+func (db TodoRecord) Restore(id int) (models.TodoRecord, error) {
+	var restoredTodo models.TodoRecord
+	err := db.inTransaction(func(tx *sql.Tx) error {
+		err := tx.
+			QueryRow(
+				`UPDATE todo_records
+				SET deleted_at = NULL
+				WHERE id = $1 AND deleted_at IS NOT NULL
+				RETURNING id`,
+				id,
+			).
+			Scan(&id)
+		if err != nil {
+			return wrapError(err)
+		}
+
+		restoredTodo, err = getSingle(tx, id)
+		return err
+	})
+	if err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	return restoredTodo, nil
 }
 
-// DeleteAll ...
-func (db TodoRecord) DeleteAll() error {
-	_, err := db.pool.Exec("DELETE FROM todo_records")
-	return err
+// Purge deletes permanently the to-do records trashed
+// at least the retention ago.
+func (db TodoRecord) Purge(retention time.Duration) (int, error) {
+	result, err := db.getExecutor().Exec(
+		`DELETE FROM todo_records
+		WHERE deleted_at <= now() - make_interval(secs => $1)`,
+		retention.Seconds(),
+	)
+	if err != nil {
+		return 0, wrapError(err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0,
+			fmt.Errorf("unable to get the number of affected rows: %v", err)
+	}
+
+	return int(count), nil
 }
 
-// DeleteSingle ...
-func (db TodoRecord) DeleteSingle(id int) error {
-	_, err := db.pool.Exec("DELETE FROM todo_records WHERE id = $1", id)
-	return err
+// AddDependency ignores the existing dependency.
+func (db TodoRecord) AddDependency(id int, blockerID int) error {
+	_, err := db.getExecutor().Exec(
+		`INSERT INTO todo_dependencies (todo_record_id, blocker_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`,
+		id,
+		blockerID,
+	)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return nil
+}
+
+// DeleteDependency ...
+func (db TodoRecord) DeleteDependency(id int, blockerID int) error {
+	result, err := db.getExecutor().Exec(
+		`DELETE FROM todo_dependencies
+		WHERE todo_record_id = $1 AND blocker_id = $2`,
+		id,
+		blockerID,
+	)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return checkAffectedRows(result)
+}
+
+// GetBlockerIDs includes the trashed blockers.
+func (db TodoRecord) GetBlockerIDs(id int) ([]int, error) {
+	rows, err := db.getExecutor().Query(
+		`SELECT blocker_id
+		FROM todo_dependencies
+		WHERE todo_record_id = $1
+		ORDER BY blocker_id`,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a cursor: %w", wrapError(err))
+	}
+	defer rows.Close()
+
+	var blockerIDs []int
+	for rows.Next() {
+		var blockerID int
+		if err := rows.Scan(&blockerID); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal the row: %v", err)
+		}
+
+		blockerIDs = append(blockerIDs, blockerID)
+	}
+
+	return blockerIDs, nil
+}
+
+// InTransaction ...
+//
+// The nested calls are isolated by savepoints,
+// so the handler can skip a failed one.
+func (db TodoRecord) InTransaction(
+	handler func(storage usecases.TodoRecordStorage) error,
+) error {
+	return db.inTransaction(func(tx *sql.Tx) error {
+		return handler(TodoRecord{pool: db.pool, tx: tx})
+	})
+}
+
+func (db TodoRecord) inTransaction(handler func(tx *sql.Tx) error) error {
+	return inNestedTransaction(db.pool, db.tx, handler)
+}
+
+func (db TodoRecord) getExecutor() executor {
+	if db.tx != nil {
+		return db.tx
+	}
+
+	return db.pool
+}
+
+// wrapVersionError distinguishes a missing to-do record
+// from the one with another version.
+func (db TodoRecord) wrapVersionError(id int, err error) error {
+	if !errors.Is(err, models.ErrNotFound) {
+		return err
+	}
+
+	var exists bool
+	err = db.getExecutor().
+		QueryRow(
+			`SELECT EXISTS (
+				SELECT 1 FROM todo_records WHERE id = $1 AND deleted_at IS NULL
+			)`,
+			id,
+		).
+		Scan(&exists)
+	if err != nil {
+		return wrapError(err)
+	}
+	if !exists {
+		return models.ErrNotFound
+	}
+
+	return models.ErrVersionMismatch
+}
+
+func getSingle(executor executor, id int) (models.TodoRecord, error) {
+	row := executor.QueryRow(
+		"SELECT "+todoRecordColumns+
+			" FROM todo_records WHERE id = $1 AND deleted_at IS NULL",
+		id,
+	)
+	todo, err := scanTodoRecord(row)
+	if err != nil {
+		return models.TodoRecord{}, wrapError(err)
+	}
+
+	return todo, nil
+}
+
+func setTags(executor executor, todoID int, tags []string) error {
+	_, err := executor.Exec(
+		"DELETE FROM todo_record_tags WHERE todo_record_id = $1",
+		todoID,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to delete the tags: %w", wrapError(err))
+	}
+
+	for _, tag := range tags {
+		_, err := executor.Exec(
+			"INSERT INTO tags (name) VALUES ($1) ON CONFLICT (name) DO NOTHING",
+			tag,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to create the tag: %w", wrapError(err))
+		}
+
+		_, err = executor.Exec(
+			`INSERT INTO todo_record_tags (todo_record_id, tag_id)
+			SELECT $1, id FROM tags WHERE name = $2
+			ON CONFLICT DO NOTHING`,
+			todoID,
+			tag,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to add the tag: %w", wrapError(err))
+		}
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTodoRecord(row scanner, extraDestinations ...interface{}) (
+	models.TodoRecord,
+	error,
+) {
+	var todo models.TodoRecord
+	var listID, parentID sql.NullInt64
+	var dueAt, completedAt, deletedAt sql.NullTime
+	var blockerIDs pq.Int64Array
+	destinations := []interface{}{
+		&todo.ID,
+		&todo.Title,
+		&todo.Notes,
+		&todo.Completed,
+		&todo.Order,
+		&todo.Priority,
+		&todo.Date,
+		&dueAt,
+		&todo.Recurrence,
+		&listID,
+		&parentID,
+		&todo.Version,
+		&todo.CreatedAt,
+		&todo.UpdatedAt,
+		&completedAt,
+		&deletedAt,
+		&todo.ChildCount,
+		&todo.CompletedChildCount,
+		&todo.Blocked,
+		&blockerIDs,
+		pq.Array(&todo.Tags),
+	}
+	err := row.Scan(append(destinations, extraDestinations...)...)
+	if err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	// the zero list ID means the inbox, which is stored as NULL
+	todo.ListID = int(listID.Int64)
+	todo.ParentID = int(parentID.Int64)
+	if dueAt.Valid {
+		dueAtInUTC := dueAt.Time.UTC()
+		todo.DueAt = &dueAtInUTC
+	}
+	todo.CreatedAt = todo.CreatedAt.UTC()
+	todo.UpdatedAt = todo.UpdatedAt.UTC()
+	if completedAt.Valid {
+		completedAtInUTC := completedAt.Time.UTC()
+		todo.CompletedAt = &completedAtInUTC
+	}
+	if deletedAt.Valid {
+		deletedAtInUTC := deletedAt.Time.UTC()
+		todo.DeletedAt = &deletedAtInUTC
+	}
+	for _, blockerID := range blockerIDs {
+		todo.BlockerIDs = append(todo.BlockerIDs, int(blockerID))
+	}
+	if len(todo.Tags) == 0 {
+		todo.Tags = nil
+	}
+
+	return todo, nil
+}
+
+// makeTodoRecordWhere ignores the pagination of the query.
+func makeTodoRecordWhere(query models.Query) (string, []interface{}) {
+	var args []interface{}
+
+	sql := " WHERE deleted_at IS NULL"
+	if query.Deleted {
+		sql = " WHERE deleted_at IS NOT NULL"
+	}
+
+	var argNumber int
+	// the search query is always the first argument,
+	// see the todoRecordSearchQuery constant
+	if query.Search != "" {
+		argNumber++
+		sql += " AND " + getTodoRecordSearchVector(query.SearchNotes) + " @@ " +
+			todoRecordSearchQuery
+		args = append(args, query.Search)
+	}
+	if query.MinimalDate != (utilmodels.Date{}) {
+		argNumber++
+		sql += " AND date >= $" + strconv.Itoa(argNumber)
+		args = append(args, time.Time(query.MinimalDate))
+	}
+	if query.MaximalDate != (utilmodels.Date{}) {
+		argNumber++
+		sql += " AND date <= $" + strconv.Itoa(argNumber)
+		args = append(args, time.Time(query.MaximalDate))
+	}
+	if query.Date != (utilmodels.Date{}) {
+		dateArg := "$" + strconv.Itoa(argNumber+1)
+		startArg := "$" + strconv.Itoa(argNumber+2)
+		endArg := "$" + strconv.Itoa(argNumber+3)
+		argNumber += 3
+
+		start, end := query.DateRange()
+		sql += ` AND ((due_at IS NULL AND "date" = ` + dateArg + `)` +
+			` OR (due_at >= ` + startArg + ` AND due_at < ` + endArg + `))`
+		args = append(args, time.Time(query.Date), start, end)
+	}
+	if query.TitleFragment != "" {
+		argNumber++
+		fragmentArg := "$" + strconv.Itoa(argNumber)
+		if query.SearchNotes {
+			sql += " AND (lower(title) LIKE " + fragmentArg +
+				" OR lower(notes) LIKE " + fragmentArg + ")"
+		} else {
+			sql += " AND lower(title) LIKE " + fragmentArg
+		}
+		args = append(args, "%"+strings.ToLower(query.TitleFragment)+"%")
+	}
+	if len(query.Tags) != 0 {
+		argNumber++
+		sql += " AND " + todoRecordTagsSubquery + " && $" + strconv.Itoa(argNumber)
+		args = append(args, pq.Array(query.Tags))
+	}
+	if len(query.AllTags) != 0 {
+		argNumber++
+		sql += " AND " + todoRecordTagsSubquery + " @> $" + strconv.Itoa(argNumber)
+		args = append(args, pq.Array(query.AllTags))
+	}
+	if query.ListID != 0 {
+		argNumber++
+		sql += " AND list_id = $" + strconv.Itoa(argNumber)
+		args = append(args, query.ListID)
+	}
+	if query.ParentID != 0 {
+		argNumber++
+		sql += " AND parent_id = $" + strconv.Itoa(argNumber)
+		args = append(args, query.ParentID)
+	}
+	if query.Blocked != nil {
+		if *query.Blocked {
+			sql += " AND " + todoRecordBlockedCondition
+		} else {
+			sql += " AND NOT " + todoRecordBlockedCondition
+		}
+	}
+	if query.Completed != nil {
+		argNumber++
+		sql += " AND completed = $" + strconv.Itoa(argNumber)
+		args = append(args, *query.Completed)
+	}
+	if query.MinimalOrder != nil {
+		argNumber++
+		sql += ` AND "order" >= $` + strconv.Itoa(argNumber)
+		args = append(args, *query.MinimalOrder)
+	}
+	if query.MaximalOrder != nil {
+		argNumber++
+		sql += ` AND "order" <= $` + strconv.Itoa(argNumber)
+		args = append(args, *query.MaximalOrder)
+	}
+	if query.MinimalPriority != nil {
+		argNumber++
+		sql += " AND priority >= $" + strconv.Itoa(argNumber)
+		args = append(args, *query.MinimalPriority)
+	}
+	if len(query.IDs) != 0 {
+		ids := make([]int64, 0, len(query.IDs))
+		for _, id := range query.IDs {
+			ids = append(ids, int64(id))
+		}
+
+		argNumber++
+		sql += " AND id = ANY($" + strconv.Itoa(argNumber) + ")"
+		args = append(args, pq.Array(ids))
+	}
+	timestampConditions := []struct {
+		condition string
+		timestamp time.Time
+	}{
+		{"created_at >= $", query.CreatedAfter},
+		{"created_at < $", query.CreatedBefore},
+		{"updated_at >= $", query.UpdatedAfter},
+		{"updated_at < $", query.UpdatedBefore},
+		{"completed_at >= $", query.CompletedAfter},
+		{"completed_at < $", query.CompletedBefore},
+		{"due_at >= $", query.DueAfter},
+		{"due_at < $", query.DueBefore},
+	}
+	for _, timestampCondition := range timestampConditions {
+		if !timestampCondition.timestamp.IsZero() {
+			argNumber++
+			sql += " AND " + timestampCondition.condition + strconv.Itoa(argNumber)
+			args = append(args, timestampCondition.timestamp)
+		}
+	}
+
+	return sql, args
+}
+
+// getTodoRecordSearchVector returns the column of the search vector
+// over the title and optionally the notes.
+func getTodoRecordSearchVector(searchNotes bool) string {
+	if searchNotes {
+		return "notes_search_vector"
+	}
+
+	return "search_vector"
+}
+
+// makeTodoRecordHeadline highlights all the matched words
+// in the text column, see the todoRecordSearchQuery constant.
+func makeTodoRecordHeadline(column string) string {
+	return "ts_headline('english', " + column + ", " + todoRecordSearchQuery +
+		", 'StartSel=" + models.HighlightStart +
+		", StopSel=" + models.HighlightStop + ", HighlightAll=true')"
+}
+
+// makeTodoRecordCursorCondition selects the records after the cursor
+// in the complete sort, which should contain only the cursor fields;
+// the argument numbers start after the given count of the previous ones.
+func makeTodoRecordCursorCondition(
+	sort []models.SortField,
+	cursor models.Cursor,
+	argCount int,
+) (string, []interface{}) {
+	var args []interface{}
+	completeSort := models.CompleteSort(sort, models.DefaultSort)
+	for _, field := range completeSort {
+		switch field.Name {
+		case models.SortByDate:
+			args = append(args, time.Time(cursor.Date))
+		case models.SortByOrder:
+			args = append(args, cursor.Order)
+		case models.SortByPriority:
+			args = append(args, cursor.Priority)
+		default:
+			args = append(args, cursor.ID)
+		}
+	}
+
+	var condition string
+	for index := len(completeSort) - 1; index >= 0; index-- {
+		field := completeSort[index]
+		column := todoRecordSortColumns[field.Name]
+		arg := "$" + strconv.Itoa(argCount+index+1)
+		operator := ">"
+		if field.Descending {
+			operator = "<"
+		}
+
+		if condition == "" {
+			condition = column + " " + operator + " " + arg
+			continue
+		}
+
+		condition = "(" + column + " " + operator + " " + arg + " OR (" +
+			column + " = " + arg + " AND " + condition + "))"
+	}
+
+	return condition, args
+}
+
+// makeTodoRecordOrder ignores the relevance sort without the search vector.
+func makeTodoRecordOrder(sort []models.SortField, searchVector string) string {
+	var items []string
+	for _, field := range models.CompleteSort(sort, models.DefaultSort) {
+		item := todoRecordSortColumns[field.Name]
+		if field.Name == models.SortByRelevance {
+			if searchVector == "" {
+				continue
+			}
+
+			item = "ts_rank(" + searchVector + ", " + todoRecordSearchQuery + ")"
+		}
+		if field.Descending {
+			item += " DESC"
+		}
+		if field.Name == models.SortByCompletedAt {
+			item += " NULLS LAST"
+		}
+
+		items = append(items, item)
+	}
+
+	return " ORDER BY " + strings.Join(items, ", ")
 }
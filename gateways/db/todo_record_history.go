@@ -0,0 +1,175 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+)
+
+const historyRecordColumns = `revision, todo_record_id, action,
+	old_value, new_value, actor, changed_at`
+
+// AddHistoryRecord ignores the revision and the change time of the record.
+func (db TodoRecord) AddHistoryRecord(record models.HistoryRecord) error {
+	oldValue, err := marshalHistoryValue(record.OldValue)
+	if err != nil {
+		return err
+	}
+
+	newValue, err := marshalHistoryValue(record.NewValue)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.getExecutor().Exec(
+		`INSERT INTO todo_record_history
+			(todo_record_id, action, old_value, new_value, actor, changed_at)
+		VALUES ($1, $2, $3, $4, $5, now())`,
+		record.TodoRecordID,
+		record.Action,
+		oldValue,
+		newValue,
+		record.Actor,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to add the history record: %w", wrapError(err))
+	}
+
+	return nil
+}
+
+// GetHistory returns the history records in the chronological order.
+func (db TodoRecord) GetHistory(query models.HistoryQuery) (
+	[]models.HistoryRecord,
+	error,
+) {
+	var conditions []string
+	var args []interface{}
+	if query.TodoRecordID != 0 {
+		args = append(args, query.TodoRecordID)
+		conditions = append(
+			conditions,
+			"todo_record_id = $"+strconv.Itoa(len(args)),
+		)
+	}
+	if !query.Since.IsZero() {
+		args = append(args, query.Since)
+		conditions = append(
+			conditions,
+			"changed_at >= $"+strconv.Itoa(len(args)),
+		)
+	}
+	if query.Cursor.Revision != 0 {
+		args = append(args, query.Cursor.Revision)
+		conditions = append(conditions, "revision > $"+strconv.Itoa(len(args)))
+	}
+
+	sql := "SELECT " + historyRecordColumns + " FROM todo_record_history"
+	if len(conditions) != 0 {
+		sql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sql += " ORDER BY revision"
+	if query.PageSize != 0 {
+		sql += fmt.Sprintf(" LIMIT %d", query.PageSize)
+	}
+
+	rows, err := db.getExecutor().Query(sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a cursor: %w", wrapError(err))
+	}
+	defer rows.Close()
+
+	var records []models.HistoryRecord
+	for rows.Next() {
+		record, err := scanHistoryRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal the row: %v", err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// GetHistoryRecord ...
+func (db TodoRecord) GetHistoryRecord(revision int) (
+	models.HistoryRecord,
+	error,
+) {
+	row := db.getExecutor().QueryRow(
+		"SELECT "+historyRecordColumns+
+			" FROM todo_record_history WHERE revision = $1",
+		revision,
+	)
+	record, err := scanHistoryRecord(row)
+	if err != nil {
+		return models.HistoryRecord{}, wrapError(err)
+	}
+
+	return record, nil
+}
+
+func marshalHistoryValue(todo *models.TodoRecord) (*string, error) {
+	if todo == nil {
+		return nil, nil
+	}
+
+	value, err := json.Marshal(models.NewHistoryValue(*todo))
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal the history value: %v", err)
+	}
+
+	formattedValue := string(value)
+	return &formattedValue, nil
+}
+
+func unmarshalHistoryValue(value sql.NullString) (
+	*models.TodoRecord,
+	*models.TodoRecordPatch,
+	error,
+) {
+	if !value.Valid {
+		return nil, nil, nil
+	}
+
+	todo, patch, err := models.ParseHistoryValue([]byte(value.String))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &todo, &patch, nil
+}
+
+func scanHistoryRecord(row scanner) (models.HistoryRecord, error) {
+	var record models.HistoryRecord
+	var oldValue, newValue sql.NullString
+	err := row.Scan(
+		&record.Revision,
+		&record.TodoRecordID,
+		&record.Action,
+		&oldValue,
+		&newValue,
+		&record.Actor,
+		&record.ChangedAt,
+	)
+	if err != nil {
+		return models.HistoryRecord{}, err
+	}
+
+	record.ChangedAt = record.ChangedAt.UTC()
+	record.OldValue, _, err = unmarshalHistoryValue(oldValue)
+	if err != nil {
+		return models.HistoryRecord{}, err
+	}
+	record.NewValue, record.NewValuePatch, err = unmarshalHistoryValue(newValue)
+	if err != nil {
+		return models.HistoryRecord{}, err
+	}
+
+	return record, nil
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	httputils "github.com/irenicaa/go-http-utils"
+	"github.com/irenicaa/go-todo-backend/v2/models"
+)
+
+func handleError(
+	writer http.ResponseWriter,
+	request *http.Request,
+	logger httputils.Logger,
+	problem models.Problem,
+) {
+	logger.Print(problem.Detail)
+
+	problem.Type = "about:blank"
+	problem.Title = http.StatusText(problem.Status)
+	problem.Instance = request.URL.RequestURI()
+
+	problemBytes, err := json.Marshal(problem)
+	if err != nil {
+		status, message :=
+			http.StatusInternalServerError, "unable to marshal the problem: %s"
+		httputils.HandleError(writer, logger, status, message, err)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/problem+json")
+	writer.WriteHeader(problem.Status)
+	writer.Write(problemBytes)
+}
+
+func newParameterProblem(
+	code string,
+	parameter string,
+	format string,
+	arguments ...interface{},
+) models.Problem {
+	return models.Problem{
+		Status:    http.StatusBadRequest,
+		Detail:    fmt.Sprintf(format, arguments...),
+		Code:      code,
+		Parameter: parameter,
+	}
+}
+
+func newUseCaseProblem(err error) models.Problem {
+	problem := models.Problem{Detail: err.Error()}
+
+	var validationErr models.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		problem.Status, problem.Code =
+			http.StatusUnprocessableEntity, "validation_failed"
+		problem.InvalidParams = validationErr.Fields
+	case errors.Is(err, models.ErrNotFound):
+		problem.Status, problem.Code = http.StatusNotFound, "record_not_found"
+	case errors.Is(err, models.ErrBlocked):
+		problem.Status, problem.Code = http.StatusConflict, "record_blocked"
+	case errors.Is(err, models.ErrConflict):
+		problem.Status, problem.Code = http.StatusConflict, "record_conflict"
+	case errors.Is(err, models.ErrVersionMismatch):
+		problem.Status, problem.Code =
+			http.StatusPreconditionFailed, "version_mismatch"
+	case errors.Is(err, models.ErrInvalid):
+		problem.Status, problem.Code =
+			http.StatusUnprocessableEntity, "invalid_record"
+	case errors.Is(err, models.ErrRolledBack):
+		problem.Status, problem.Code =
+			http.StatusFailedDependency, "batch_rolled_back"
+	default:
+		problem.Status, problem.Code =
+			http.StatusInternalServerError, "internal_error"
+	}
+
+	return problem
+}
+
+func newPresentationBatchResult(
+	request *http.Request,
+	result models.BatchResult,
+) models.PresentationBatchResult {
+	if result.Err != nil {
+		problem := newUseCaseProblem(result.Err)
+		problem.Type = "about:blank"
+		problem.Title = http.StatusText(problem.Status)
+		problem.Instance = request.URL.RequestURI()
+
+		return models.PresentationBatchResult{
+			Status:  problem.Status,
+			Problem: &problem,
+		}
+	}
+	if result.Todo == nil {
+		return models.PresentationBatchResult{Status: http.StatusNoContent}
+	}
+
+	return models.PresentationBatchResult{
+		Status: http.StatusOK,
+		Todo:   result.Todo,
+	}
+}
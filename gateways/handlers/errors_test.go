@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"testing/iotest"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newUseCaseProblem(t *testing.T) {
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want models.Problem
+	}{
+		{
+			name: "validation",
+			args: args{
+				err: fmt.Errorf("unable to validate: %w", models.ValidationError{
+					Fields: []models.FieldError{{Name: "title", Reason: "is required"}},
+				}),
+			},
+			want: models.Problem{
+				Status: http.StatusUnprocessableEntity,
+				Detail: "unable to validate: invalid fields: title: is required",
+				Code:   "validation_failed",
+				InvalidParams: []models.FieldError{
+					{Name: "title", Reason: "is required"},
+				},
+			},
+		},
+		{
+			name: "not found",
+			args: args{err: fmt.Errorf("unable to get: %w", models.ErrNotFound)},
+			want: models.Problem{
+				Status: http.StatusNotFound,
+				Detail: "unable to get: not found",
+				Code:   "record_not_found",
+			},
+		},
+		{
+			name: "conflict",
+			args: args{err: fmt.Errorf("unable to create: %w", models.ErrConflict)},
+			want: models.Problem{
+				Status: http.StatusConflict,
+				Detail: "unable to create: conflict",
+				Code:   "record_conflict",
+			},
+		},
+		{
+			name: "blocked",
+			args: args{err: fmt.Errorf("unable to patch: %w", models.ErrBlocked)},
+			want: models.Problem{
+				Status: http.StatusConflict,
+				Detail: "unable to patch: blocked",
+				Code:   "record_blocked",
+			},
+		},
+		{
+			name: "version mismatch",
+			args: args{
+				err: fmt.Errorf("unable to update: %w", models.ErrVersionMismatch),
+			},
+			want: models.Problem{
+				Status: http.StatusPreconditionFailed,
+				Detail: "unable to update: version mismatch",
+				Code:   "version_mismatch",
+			},
+		},
+		{
+			name: "invalid",
+			args: args{err: fmt.Errorf("unable to update: %w", models.ErrInvalid)},
+			want: models.Problem{
+				Status: http.StatusUnprocessableEntity,
+				Detail: "unable to update: invalid",
+				Code:   "invalid_record",
+			},
+		},
+		{
+			name: "rolled back",
+			args: args{err: models.ErrRolledBack},
+			want: models.Problem{
+				Status: http.StatusFailedDependency,
+				Detail: "rolled back",
+				Code:   "batch_rolled_back",
+			},
+		},
+		{
+			name: "unknown",
+			args: args{err: iotest.ErrTimeout},
+			want: models.Problem{
+				Status: http.StatusInternalServerError,
+				Detail: "timeout",
+				Code:   "internal_error",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newUseCaseProblem(tt.args.err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
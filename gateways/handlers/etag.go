@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+)
+
+// unmatchedVersion is the version of no to-do record,
+// so the use cases fail with the version mismatch error on it.
+const unmatchedVersion = -1
+
+// computedState contains the fields of the to-do record that depend
+// on the other ones, so they change without the version increment.
+type computedState struct {
+	ChildCount         int
+	ChildrenCompletion *float64
+	BlockedBy          []int
+	Blocked            bool
+	ChildrenETags      []string
+}
+
+// formatETag adds the hash of the computed state to the version, if any;
+// the entity tags of the children are included in it recursively.
+func formatETag(todo models.PresentationTodoRecord) string {
+	state := computedState{
+		ChildCount:         todo.ChildCount,
+		ChildrenCompletion: todo.ChildrenCompletion,
+		BlockedBy:          todo.BlockedBy,
+		Blocked:            todo.Blocked,
+	}
+	for _, child := range todo.Children {
+		state.ChildrenETags = append(state.ChildrenETags, formatETag(child))
+	}
+	if state.ChildCount == 0 && state.ChildrenCompletion == nil &&
+		len(state.BlockedBy) == 0 && !state.Blocked &&
+		len(state.ChildrenETags) == 0 {
+		return fmt.Sprintf(`"%d"`, todo.Version)
+	}
+
+	// the marshalling of this structure can't fail
+	data, _ := json.Marshal(state)
+
+	hash := fnv.New32a()
+	hash.Write(data)
+
+	return fmt.Sprintf(`"%d-%08x"`, todo.Version, hash.Sum32())
+}
+
+// getIfMatchETags returns nil if the If-Match header is missed or equals
+// to "*". The weak entity tags are skipped, because they never match
+// in the strong comparison required by RFC 7232, so the result is empty
+// (but not nil) if none of them can match.
+func getIfMatchETags(request *http.Request) ([]string, error) {
+	header := strings.TrimSpace(request.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	etags := []string{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		isWeak := strings.HasPrefix(tag, "W/")
+		tag = strings.TrimPrefix(tag, "W/")
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			return nil, errors.New("entity tag isn't quoted")
+		}
+		if strings.Contains(tag[1:len(tag)-1], `"`) {
+			return nil, errors.New("entity tag contains a quote")
+		}
+		if isWeak {
+			continue
+		}
+
+		etags = append(etags, tag)
+	}
+
+	return etags, nil
+}
+
+// matchIfNoneMatch uses the weak comparison as required by RFC 7232.
+func matchIfNoneMatch(request *http.Request, etag string) bool {
+	header := request.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
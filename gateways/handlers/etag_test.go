@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_formatETag(t *testing.T) {
+	completion := 0.5
+
+	tests := []struct {
+		name string
+		todo models.PresentationTodoRecord
+		want string
+	}{
+		{
+			name: "without a computed state",
+			todo: models.PresentationTodoRecord{Title: "test", Version: 23},
+			want: `"23"`,
+		},
+		{
+			name: "with a child count",
+			todo: models.PresentationTodoRecord{
+				Version:            23,
+				ChildCount:         2,
+				ChildrenCompletion: &completion,
+			},
+			want: `"23-ad732ac5"`,
+		},
+		{
+			name: "with blockers",
+			todo: models.PresentationTodoRecord{
+				Version:   23,
+				BlockedBy: []int{5},
+				Blocked:   true,
+			},
+			want: `"23-5b173100"`,
+		},
+		{
+			name: "with children",
+			todo: models.PresentationTodoRecord{
+				Version:  23,
+				Children: []models.PresentationTodoRecord{{Version: 5}},
+			},
+			want: `"23-e6552839"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatETag(tt.todo)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_getIfMatchETags(t *testing.T) {
+	type args struct {
+		header string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success without the header",
+			args:    args{header: ""},
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with an asterisk",
+			args:    args{header: "*"},
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with an entity tag",
+			args:    args{header: ` "23" `},
+			want:    []string{`"23"`},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with multiple entity tags",
+			args:    args{header: `"23", "42"`},
+			want:    []string{`"23"`, `"42"`},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with a weak entity tag",
+			args:    args{header: `W/"23"`},
+			want:    []string{},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with a computed state",
+			args:    args{header: `"23-0a1b2c3d"`},
+			want:    []string{`"23-0a1b2c3d"`},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error with an unquoted entity tag",
+			args:    args{header: `"23", 42`},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error with an empty entity tag",
+			args:    args{header: `"23",`},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error with a quote inside an entity tag",
+			args:    args{header: `"2"3"`},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPut, "http://example.com/", nil)
+			if tt.args.header != "" {
+				request.Header.Set("If-Match", tt.args.header)
+			}
+
+			got, err := getIfMatchETags(request)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func Test_matchIfNoneMatch(t *testing.T) {
+	type args struct {
+		header string
+		etag   string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "without the header",
+			args: args{header: "", etag: `"23"`},
+			want: false,
+		},
+		{
+			name: "with an asterisk",
+			args: args{header: "*", etag: `"23"`},
+			want: true,
+		},
+		{
+			name: "with a matched entity tag",
+			args: args{header: `"42", "23"`, etag: `"23"`},
+			want: true,
+		},
+		{
+			name: "with a matched weak entity tag",
+			args: args{header: `W/"23"`, etag: `"23"`},
+			want: true,
+		},
+		{
+			name: "with an unmatched entity tag",
+			args: args{header: `"42"`, etag: `"23"`},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+			if tt.args.header != "" {
+				request.Header.Set("If-None-Match", tt.args.header)
+			}
+
+			got := matchIfNoneMatch(request, tt.args.etag)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	httputils "github.com/irenicaa/go-http-utils"
+	"github.com/irenicaa/go-todo-backend/v2/models"
+)
+
+// ListUseCase ...
+type ListUseCase interface {
+	GetAll(baseURL *url.URL) ([]models.PresentationList, error)
+	GetSingle(baseURL *url.URL, id int) (models.PresentationList, error)
+	Create(baseURL *url.URL, presentationList models.PresentationList) (
+		models.PresentationList,
+		error,
+	)
+	Update(
+		baseURL *url.URL,
+		id int,
+		presentationList models.PresentationList,
+	) (
+		models.PresentationList,
+		error,
+	)
+	DeleteSingle(id int, cascade bool, actor string) error
+}
+
+// List ...
+type List struct {
+	URLScheme string
+	UseCase   ListUseCase
+	Logger    httputils.Logger
+}
+
+// GetAll ...
+//   @router /lists [GET]
+//   @summary get all lists
+//   @produce json
+//   @success 200 {array} models.PresentationList
+//   @failure 500 {object} models.Problem
+func (handler List) GetAll(writer http.ResponseWriter, request *http.Request) {
+	baseURL := handler.getBaseURL(request)
+	presentationLists, err := handler.UseCase.GetAll(baseURL)
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	httputils.HandleJSON(writer, handler.Logger, presentationLists)
+}
+
+// GetSingle ...
+//   @router /lists/{id} [GET]
+//   @summary get the single list
+//   @param id path integer true "list ID"
+//   @produce json
+//   @success 200 {object} models.PresentationList
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler List) GetSingle(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	id, err := httputils.GetIDFromURL(request)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	baseURL := handler.getBaseURL(request)
+	presentationList, err := handler.UseCase.GetSingle(baseURL, id)
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	httputils.HandleJSON(writer, handler.Logger, presentationList)
+}
+
+// Create ...
+//   @router /lists [POST]
+//   @summary create a list
+//   @param body body models.PresentationList true "list data"
+//   @accept json
+//   @produce json
+//   @success 200 {object} models.PresentationList
+//   @failure 400 {object} models.Problem
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler List) Create(writer http.ResponseWriter, request *http.Request) {
+	var presentationList models.PresentationList
+	if err := httputils.ReadJSONData(request.Body, &presentationList); err != nil {
+		problem := newParameterProblem(
+			"invalid_request_body",
+			"body",
+			"unable to get the request body: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	baseURL := handler.getBaseURL(request)
+	presentationList, err := handler.UseCase.Create(baseURL, presentationList)
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	httputils.HandleJSON(writer, handler.Logger, presentationList)
+}
+
+// Update ...
+//   @router /lists/{id} [PUT]
+//   @summary update the list
+//   @param id path integer true "list ID"
+//   @param body body models.PresentationList true "list data"
+//   @accept json
+//   @produce json
+//   @success 200 {object} models.PresentationList
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler List) Update(writer http.ResponseWriter, request *http.Request) {
+	id, err := httputils.GetIDFromURL(request)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	var presentationList models.PresentationList
+	if err := httputils.ReadJSONData(request.Body, &presentationList); err != nil {
+		problem := newParameterProblem(
+			"invalid_request_body",
+			"body",
+			"unable to get the request body: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	baseURL := handler.getBaseURL(request)
+	presentationList, err = handler.UseCase.Update(baseURL, id, presentationList)
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	httputils.HandleJSON(writer, handler.Logger, presentationList)
+}
+
+// DeleteSingle ...
+//   @router /lists/{id} [DELETE]
+//   @summary delete the list
+//   @param id path integer true "list ID"
+//   @param cascade query boolean false "delete the to-do records of the list instead of moving them to the inbox"
+//   @param X-Actor header string false "actor of the changes of the to-do records for the history"
+//   @success 204 {string} string
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 409 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler List) DeleteSingle(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	id, err := httputils.GetIDFromURL(request)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	var cascade bool
+	if rawCascade := request.FormValue("cascade"); rawCascade != "" {
+		cascade, err = strconv.ParseBool(rawCascade)
+		if err != nil {
+			problem := newParameterProblem(
+				"invalid_cascade",
+				"cascade",
+				"unable to get the cascade parameter: %v",
+				err,
+			)
+			handleError(writer, request, handler.Logger, problem)
+
+			return
+		}
+	}
+
+	err = handler.UseCase.DeleteSingle(id, cascade, getActor(request))
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler List) getBaseURL(request *http.Request) *url.URL {
+	return &url.URL{Scheme: handler.URLScheme, Host: request.Host}
+}
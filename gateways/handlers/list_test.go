@@ -0,0 +1,800 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"testing/iotest"
+
+	httputils "github.com/irenicaa/go-http-utils"
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList_GetAll(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   ListUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() ListUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationLists := []models.PresentationList{
+						{
+							URL:      "http://example.com/api/v1/lists/5",
+							Title:    "test",
+							TodosURL: "http://example.com/api/v1/lists/5/todos",
+						},
+					}
+
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL).
+						Return(presentationLists, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/lists",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"url":"http://example.com/api/v1/lists/5",` +
+						`"title":"test",` +
+						`"todos_url":"http://example.com/api/v1/lists/5/todos"}]`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() ListUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL).
+						Return([]models.PresentationList(nil), iotest.ErrTimeout)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"timeout"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/lists",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/lists",` +
+						`"code":"internal_error"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := List{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.GetAll(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockListUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestList_GetSingle(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   ListUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() ListUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationList := models.PresentationList{
+						URL:      "http://example.com/api/v1/lists/12",
+						Title:    "test",
+						TodosURL: "http://example.com/api/v1/lists/12/todos",
+					}
+
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 12).
+						Return(presentationList, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/lists/12",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/lists/12",` +
+						`"title":"test",` +
+						`"todos_url":"http://example.com/api/v1/lists/12/todos"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with a not found list",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() ListUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					err := fmt.Errorf("unable to get the list: %w", models.ErrNotFound)
+
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 12).
+						Return(models.PresentationList{}, err)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					message := "unable to get the list: not found"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/lists/12",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Not Found",` +
+						`"status":404,` +
+						`"detail":"unable to get the list: not found",` +
+						`"instance":"/api/v1/lists/12",` +
+						`"code":"record_not_found"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := List{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.GetSingle(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockListUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestList_Create(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   ListUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() ListUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationListIn := models.PresentationList{Title: "test"}
+					presentationListOut := models.PresentationList{
+						URL:      "http://example.com/api/v1/lists/12",
+						Title:    "test",
+						TodosURL: "http://example.com/api/v1/lists/12/todos",
+					}
+
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.
+						On("Create", baseURL, presentationListIn).
+						Return(presentationListOut, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/lists",
+					bytes.NewReader([]byte(`{"title": "test"}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/lists/12",` +
+						`"title":"test",` +
+						`"todos_url":"http://example.com/api/v1/lists/12/todos"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the request body",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockListUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the request body: " +
+						"unable to unmarshal the JSON data: unexpected end of JSON input"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/lists",
+					bytes.NewReader(nil),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the request body: ` +
+						`unable to unmarshal the JSON data: unexpected end of JSON input",` +
+						`"instance":"/api/v1/lists",` +
+						`"code":"invalid_request_body",` +
+						`"parameter":"body"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with validation",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() ListUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					err := fmt.Errorf(
+						"unable to validate the list: %w",
+						models.ValidationError{
+							Fields: []models.FieldError{
+								{Name: "title", Reason: "is required"},
+							},
+						},
+					)
+
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.
+						On("Create", baseURL, models.PresentationList{}).
+						Return(models.PresentationList{}, err)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					message := "unable to validate the list: " +
+						"invalid fields: title: is required"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/lists",
+					bytes.NewReader([]byte(`{}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusUnprocessableEntity) + " " +
+					http.StatusText(http.StatusUnprocessableEntity),
+				StatusCode: http.StatusUnprocessableEntity,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Unprocessable Entity",` +
+						`"status":422,` +
+						`"detail":"unable to validate the list: ` +
+						`invalid fields: title: is required",` +
+						`"instance":"/api/v1/lists",` +
+						`"code":"validation_failed",` +
+						`"invalid_params":[{"name":"title","reason":"is required"}]}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := List{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.Create(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockListUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestList_Update(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   ListUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() ListUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationListIn := models.PresentationList{Title: "test"}
+					presentationListOut := models.PresentationList{
+						URL:      "http://example.com/api/v1/lists/12",
+						Title:    "test",
+						TodosURL: "http://example.com/api/v1/lists/12/todos",
+					}
+
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.
+						On("Update", baseURL, 12, presentationListIn).
+						Return(presentationListOut, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPut,
+					"http://example.com/api/v1/lists/12",
+					bytes.NewReader([]byte(`{"title": "test"}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/lists/12",` +
+						`"title":"test",` +
+						`"todos_url":"http://example.com/api/v1/lists/12/todos"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the ID",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockListUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get an ID: unable to find an ID"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPut,
+					"http://example.com/api/v1/lists/incorrect",
+					bytes.NewReader([]byte(`{"title": "test"}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get an ID: unable to find an ID",` +
+						`"instance":"/api/v1/lists/incorrect",` +
+						`"code":"invalid_id",` +
+						`"parameter":"id"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := List{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.Update(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockListUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestList_DeleteSingle(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   ListUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success with moving to the inbox",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() ListUseCase {
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.On("DeleteSingle", 12, false, "").Return(nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/lists/12",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNoContent) + " " +
+					http.StatusText(http.StatusNoContent),
+				StatusCode:    http.StatusNoContent,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the cascade",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() ListUseCase {
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.On("DeleteSingle", 12, true, "admin").Return(nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(
+						http.MethodDelete,
+						"http://example.com/api/v1/lists/12?cascade=true",
+						nil,
+					)
+					request.Header.Set("X-Actor", "admin")
+
+					return request
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNoContent) + " " +
+					http.StatusText(http.StatusNoContent),
+				StatusCode:    http.StatusNoContent,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the cascade",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockListUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the cascade parameter: " +
+						`strconv.ParseBool: parsing "incorrect": invalid syntax`
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/lists/12?cascade=incorrect",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the cascade parameter: ` +
+						`strconv.ParseBool: parsing \"incorrect\": invalid syntax",` +
+						`"instance":"/api/v1/lists/12?cascade=incorrect",` +
+						`"code":"invalid_cascade",` +
+						`"parameter":"cascade"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with a not found list",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() ListUseCase {
+					err := fmt.Errorf("unable to delete the list: %w", models.ErrNotFound)
+
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.On("DeleteSingle", 12, false, "").Return(err)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					message := "unable to delete the list: not found"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/lists/12",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Not Found",` +
+						`"status":404,` +
+						`"detail":"unable to delete the list: not found",` +
+						`"instance":"/api/v1/lists/12",` +
+						`"code":"record_not_found"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := List{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.DeleteSingle(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockListUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/url"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockListUseCase struct {
+	InnerMock mock.Mock
+}
+
+func (mock *MockListUseCase) GetAll(baseURL *url.URL) (
+	[]models.PresentationList,
+	error,
+) {
+	results := mock.InnerMock.Called(baseURL)
+	return results.Get(0).([]models.PresentationList), results.Error(1)
+}
+
+func (mock *MockListUseCase) GetSingle(baseURL *url.URL, id int) (
+	models.PresentationList,
+	error,
+) {
+	results := mock.InnerMock.Called(baseURL, id)
+	return results.Get(0).(models.PresentationList), results.Error(1)
+}
+
+func (mock *MockListUseCase) Create(
+	baseURL *url.URL,
+	presentationList models.PresentationList,
+) (
+	models.PresentationList,
+	error,
+) {
+	results := mock.InnerMock.Called(baseURL, presentationList)
+	return results.Get(0).(models.PresentationList), results.Error(1)
+}
+
+func (mock *MockListUseCase) Update(
+	baseURL *url.URL,
+	id int,
+	presentationList models.PresentationList,
+) (
+	models.PresentationList,
+	error,
+) {
+	results := mock.InnerMock.Called(baseURL, id, presentationList)
+	return results.Get(0).(models.PresentationList), results.Error(1)
+}
+
+func (mock *MockListUseCase) DeleteSingle(
+	id int,
+	cascade bool,
+	actor string,
+) error {
+	results := mock.InnerMock.Called(id, cascade, actor)
+	return results.Error(0)
+}
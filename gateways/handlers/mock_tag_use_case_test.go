@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagUseCase struct {
+	InnerMock mock.Mock
+}
+
+func (mock *MockTagUseCase) GetAll() ([]models.Tag, error) {
+	results := mock.InnerMock.Called()
+	return results.Get(0).([]models.Tag), results.Error(1)
+}
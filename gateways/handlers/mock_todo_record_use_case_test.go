@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/url"
+	"time"
 
 	"github.com/irenicaa/go-todo-backend/v2/models"
 	"github.com/stretchr/testify/mock"
@@ -14,9 +15,11 @@ type MockTodoRecordUseCase struct {
 func (mock *MockTodoRecordUseCase) GetAll(
 	baseURL *url.URL,
 	query models.Query,
-) ([]models.PresentationTodoRecord, error) {
+) ([]models.PresentationTodoRecord, models.PageInfo, error) {
 	results := mock.InnerMock.Called(baseURL, query)
-	return results.Get(0).([]models.PresentationTodoRecord), results.Error(1)
+	return results.Get(0).([]models.PresentationTodoRecord),
+		results.Get(1).(models.PageInfo),
+		results.Error(2)
 }
 
 func (mock *MockTodoRecordUseCase) GetSingle(
@@ -27,11 +30,20 @@ func (mock *MockTodoRecordUseCase) GetSingle(
 	return results.Get(0).(models.PresentationTodoRecord), results.Error(1)
 }
 
+func (mock *MockTodoRecordUseCase) GetTree(
+	baseURL *url.URL,
+	id int,
+) (models.PresentationTodoRecord, error) {
+	results := mock.InnerMock.Called(baseURL, id)
+	return results.Get(0).(models.PresentationTodoRecord), results.Error(1)
+}
+
 func (mock *MockTodoRecordUseCase) Create(
 	baseURL *url.URL,
 	presentationTodo models.PresentationTodoRecord,
+	actor string,
 ) (models.PresentationTodoRecord, error) {
-	results := mock.InnerMock.Called(baseURL, presentationTodo)
+	results := mock.InnerMock.Called(baseURL, presentationTodo, actor)
 	return results.Get(0).(models.PresentationTodoRecord), results.Error(1)
 }
 
@@ -39,8 +51,20 @@ func (mock *MockTodoRecordUseCase) Update(
 	baseURL *url.URL,
 	id int,
 	presentationTodo models.PresentationTodoRecord,
+	version int,
+	cascade bool,
+	force bool,
+	actor string,
 ) (models.PresentationTodoRecord, error) {
-	results := mock.InnerMock.Called(baseURL, id, presentationTodo)
+	results := mock.InnerMock.Called(
+		baseURL,
+		id,
+		presentationTodo,
+		version,
+		cascade,
+		force,
+		actor,
+	)
 	return results.Get(0).(models.PresentationTodoRecord), results.Error(1)
 }
 
@@ -48,17 +72,111 @@ func (mock *MockTodoRecordUseCase) Patch(
 	baseURL *url.URL,
 	id int,
 	todoPatch models.TodoRecordPatch,
+	version int,
+	cascade bool,
+	force bool,
+	actor string,
 ) (models.PresentationTodoRecord, error) {
-	results := mock.InnerMock.Called(baseURL, id, todoPatch)
+	results := mock.InnerMock.Called(
+		baseURL,
+		id,
+		todoPatch,
+		version,
+		cascade,
+		force,
+		actor,
+	)
 	return results.Get(0).(models.PresentationTodoRecord), results.Error(1)
 }
 
-func (mock *MockTodoRecordUseCase) DeleteAll() error {
-	results := mock.InnerMock.Called()
+func (mock *MockTodoRecordUseCase) DeleteAll(
+	query models.Query,
+	actor string,
+) (int, error) {
+	results := mock.InnerMock.Called(query, actor)
+	return results.Int(0), results.Error(1)
+}
+
+func (mock *MockTodoRecordUseCase) DeleteSingle(
+	id int,
+	version int,
+	cascade bool,
+	actor string,
+) error {
+	results := mock.InnerMock.Called(id, version, cascade, actor)
 	return results.Error(0)
 }
 
-func (mock *MockTodoRecordUseCase) DeleteSingle(id int) error {
-	results := mock.InnerMock.Called(id)
+func (mock *MockTodoRecordUseCase) Batch(
+	baseURL *url.URL,
+	batch models.Batch,
+	actor string,
+) ([]models.BatchResult, error) {
+	results := mock.InnerMock.Called(baseURL, batch, actor)
+	return results.Get(0).([]models.BatchResult), results.Error(1)
+}
+
+func (mock *MockTodoRecordUseCase) Restore(
+	baseURL *url.URL,
+	id int,
+	actor string,
+) (models.PresentationTodoRecord, error) {
+	results := mock.InnerMock.Called(baseURL, id, actor)
+	return results.Get(0).(models.PresentationTodoRecord), results.Error(1)
+}
+
+func (mock *MockTodoRecordUseCase) PurgeTrash(retention time.Duration) (
+	int,
+	error,
+) {
+	results := mock.InnerMock.Called(retention)
+	return results.Int(0), results.Error(1)
+}
+
+func (mock *MockTodoRecordUseCase) AddDependency(
+	baseURL *url.URL,
+	id int,
+	dependency models.Dependency,
+) (models.PresentationTodoRecord, error) {
+	results := mock.InnerMock.Called(baseURL, id, dependency)
+	return results.Get(0).(models.PresentationTodoRecord), results.Error(1)
+}
+
+func (mock *MockTodoRecordUseCase) DeleteDependency(
+	id int,
+	blockerID int,
+) error {
+	results := mock.InnerMock.Called(id, blockerID)
 	return results.Error(0)
 }
+
+func (mock *MockTodoRecordUseCase) GetHistory(
+	baseURL *url.URL,
+	query models.HistoryQuery,
+) ([]models.PresentationHistoryRecord, string, error) {
+	results := mock.InnerMock.Called(baseURL, query)
+	return results.Get(0).([]models.PresentationHistoryRecord),
+		results.String(1),
+		results.Error(2)
+}
+
+func (mock *MockTodoRecordUseCase) Revert(
+	baseURL *url.URL,
+	id int,
+	revision int,
+	version int,
+	actor string,
+) (models.PresentationTodoRecord, error) {
+	results := mock.InnerMock.Called(baseURL, id, revision, version, actor)
+	return results.Get(0).(models.PresentationTodoRecord), results.Error(1)
+}
+
+func (mock *MockTodoRecordUseCase) GetOccurrences(
+	baseURL *url.URL,
+	id int,
+	minimalDate time.Time,
+	maximalDate time.Time,
+) ([]models.PresentationTodoRecord, error) {
+	results := mock.InnerMock.Called(baseURL, id, minimalDate, maximalDate)
+	return results.Get(0).([]models.PresentationTodoRecord), results.Error(1)
+}
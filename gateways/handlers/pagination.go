@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+)
+
+// setPaginationHeaders sets the X-Total-Count header and the RFC 8288 Link
+// one; the links keep all the request parameters except the pagination ones.
+// There are no prev and last links in the cursor mode, because the cursors
+// can only move forward.
+func setPaginationHeaders(
+	header http.Header,
+	requestURL *url.URL,
+	pagination models.Pagination,
+	pageInfo models.PageInfo,
+) {
+	header.Set("X-Total-Count", strconv.Itoa(pageInfo.TotalCount))
+	if pageInfo.NextCursor != "" {
+		header.Set("X-Next-Cursor", pageInfo.NextCursor)
+	}
+	if pagination.PageSize == 0 {
+		return
+	}
+
+	var links []string
+	addLink := func(relation string, key string, value string) {
+		link := makePaginationLink(requestURL, relation, key, value)
+		links = append(links, link)
+	}
+
+	if pagination.IsCursorMode() {
+		addLink("first", "", "")
+		if pageInfo.NextCursor != "" {
+			addLink("next", "cursor", pageInfo.NextCursor)
+		}
+	} else {
+		lastPage := (pageInfo.TotalCount + pagination.PageSize - 1) /
+			pagination.PageSize
+		if lastPage == 0 {
+			lastPage = 1
+		}
+
+		addLink("first", "page", "1")
+		if pagination.Page > 1 {
+			previousPage := pagination.Page - 1
+			if previousPage > lastPage {
+				previousPage = lastPage
+			}
+
+			addLink("prev", "page", strconv.Itoa(previousPage))
+		}
+		if pagination.Page < lastPage {
+			addLink("next", "page", strconv.Itoa(pagination.Page+1))
+		}
+		addLink("last", "page", strconv.Itoa(lastPage))
+	}
+
+	header.Set("Link", strings.Join(links, ", "))
+}
+
+// setHistoryPaginationHeaders sets the X-Next-Cursor header and the RFC 8288
+// Link one with the first and next links, like in the cursor mode
+// of the to-do records; there is no total count of the history records.
+func setHistoryPaginationHeaders(
+	header http.Header,
+	requestURL *url.URL,
+	nextCursor string,
+) {
+	links := []string{makePaginationLink(requestURL, "first", "", "")}
+	if nextCursor != "" {
+		header.Set("X-Next-Cursor", nextCursor)
+
+		link := makePaginationLink(requestURL, "next", "cursor", nextCursor)
+		links = append(links, link)
+	}
+
+	header.Set("Link", strings.Join(links, ", "))
+}
+
+// makePaginationLink replaces the pagination parameters of the request
+// with the given one; the empty key means the first page.
+func makePaginationLink(
+	requestURL *url.URL,
+	relation string,
+	key string,
+	value string,
+) string {
+	parameters := requestURL.Query()
+	parameters.Del("page")
+	parameters.Del("cursor")
+	if key != "" {
+		parameters.Set(key, value)
+	}
+
+	linkURL := *requestURL
+	linkURL.RawQuery = parameters.Encode()
+	return fmt.Sprintf("<%s>; rel=%q", &linkURL, relation)
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_setPaginationHeaders(t *testing.T) {
+	type args struct {
+		requestURL string
+		pagination models.Pagination
+		pageInfo   models.PageInfo
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want http.Header
+	}{
+		{
+			name: "success without pagination",
+			args: args{
+				requestURL: "http://example.com/api/v1/todos?title_fragment=test",
+				pagination: models.Pagination{},
+				pageInfo:   models.PageInfo{TotalCount: 23},
+			},
+			want: http.Header{"X-Total-Count": {"23"}},
+		},
+		{
+			name: "success with the first page",
+			args: args{
+				requestURL: "http://example.com/api/v1/todos" +
+					"?title_fragment=test&tags=home,work&page_size=10&page=1",
+				pagination: models.Pagination{PageSize: 10, Page: 1},
+				pageInfo:   models.PageInfo{TotalCount: 23},
+			},
+			want: http.Header{
+				"X-Total-Count": {"23"},
+				"Link": {
+					"<http://example.com/api/v1/todos" +
+						"?page=1&page_size=10&tags=home%2Cwork&title_fragment=test>; " +
+						`rel="first", ` +
+						"<http://example.com/api/v1/todos" +
+						"?page=2&page_size=10&tags=home%2Cwork&title_fragment=test>; " +
+						`rel="next", ` +
+						"<http://example.com/api/v1/todos" +
+						"?page=3&page_size=10&tags=home%2Cwork&title_fragment=test>; " +
+						`rel="last"`,
+				},
+			},
+		},
+		{
+			name: "success with the middle page",
+			args: args{
+				requestURL: "http://example.com/api/v1/todos?page_size=10&page=2",
+				pagination: models.Pagination{PageSize: 10, Page: 2},
+				pageInfo:   models.PageInfo{TotalCount: 23},
+			},
+			want: http.Header{
+				"X-Total-Count": {"23"},
+				"Link": {
+					`<http://example.com/api/v1/todos?page=1&page_size=10>; rel="first", ` +
+						`<http://example.com/api/v1/todos?page=1&page_size=10>; rel="prev", ` +
+						`<http://example.com/api/v1/todos?page=3&page_size=10>; rel="next", ` +
+						`<http://example.com/api/v1/todos?page=3&page_size=10>; rel="last"`,
+				},
+			},
+		},
+		{
+			name: "success with a page after the last one",
+			args: args{
+				requestURL: "http://example.com/api/v1/todos?page_size=10&page=5",
+				pagination: models.Pagination{PageSize: 10, Page: 5},
+				pageInfo:   models.PageInfo{TotalCount: 0},
+			},
+			want: http.Header{
+				"X-Total-Count": {"0"},
+				"Link": {
+					`<http://example.com/api/v1/todos?page=1&page_size=10>; rel="first", ` +
+						`<http://example.com/api/v1/todos?page=1&page_size=10>; rel="prev", ` +
+						`<http://example.com/api/v1/todos?page=1&page_size=10>; rel="last"`,
+				},
+			},
+		},
+		{
+			name: "success in the cursor mode with the next page",
+			args: args{
+				requestURL: "http://example.com/api/v1/todos" +
+					"?title_fragment=test&page_size=10&cursor=current",
+				pagination: models.Pagination{
+					PageSize: 10,
+					Cursor:   models.Cursor{Order: 12, ID: 23},
+				},
+				pageInfo: models.PageInfo{TotalCount: 23, NextCursor: "next"},
+			},
+			want: http.Header{
+				"X-Total-Count": {"23"},
+				"X-Next-Cursor": {"next"},
+				"Link": {
+					"<http://example.com/api/v1/todos" +
+						"?page_size=10&title_fragment=test>; " +
+						`rel="first", ` +
+						"<http://example.com/api/v1/todos" +
+						"?cursor=next&page_size=10&title_fragment=test>; " +
+						`rel="next"`,
+				},
+			},
+		},
+		{
+			name: "success in the cursor mode without the next page",
+			args: args{
+				requestURL: "http://example.com/api/v1/todos" +
+					"?page_size=10&cursor=current",
+				pagination: models.Pagination{
+					PageSize: 10,
+					Cursor:   models.Cursor{Order: 12, ID: 23},
+				},
+				pageInfo: models.PageInfo{TotalCount: 23},
+			},
+			want: http.Header{
+				"X-Total-Count": {"23"},
+				"Link": {
+					`<http://example.com/api/v1/todos?page_size=10>; rel="first"`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestURL, err := url.Parse(tt.args.requestURL)
+			require.NoError(t, err)
+
+			header := http.Header{}
+			setPaginationHeaders(
+				header,
+				requestURL,
+				tt.args.pagination,
+				tt.args.pageInfo,
+			)
+
+			assert.Equal(t, tt.want, header)
+		})
+	}
+}
+
+func Test_setHistoryPaginationHeaders(t *testing.T) {
+	type args struct {
+		requestURL string
+		nextCursor string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want http.Header
+	}{
+		{
+			name: "success with the next page",
+			args: args{
+				requestURL: "http://example.com/api/v1/history" +
+					"?since=2006-01-02T15:04:05Z&page_size=10&cursor=current",
+				nextCursor: "next",
+			},
+			want: http.Header{
+				"X-Next-Cursor": {"next"},
+				"Link": {
+					"<http://example.com/api/v1/history" +
+						"?page_size=10&since=2006-01-02T15%3A04%3A05Z>; " +
+						`rel="first", ` +
+						"<http://example.com/api/v1/history" +
+						"?cursor=next&page_size=10&since=2006-01-02T15%3A04%3A05Z>; " +
+						`rel="next"`,
+				},
+			},
+		},
+		{
+			name: "success without the next page",
+			args: args{
+				requestURL: "http://example.com/api/v1/todos/23/history" +
+					"?page_size=10&cursor=current",
+				nextCursor: "",
+			},
+			want: http.Header{
+				"Link": {
+					"<http://example.com/api/v1/todos/23/history?page_size=10>; " +
+						`rel="first"`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestURL, err := url.Parse(tt.args.requestURL)
+			require.NoError(t, err)
+
+			header := http.Header{}
+			setHistoryPaginationHeaders(header, requestURL, tt.args.nextCursor)
+
+			assert.Equal(t, tt.want, header)
+		})
+	}
+}
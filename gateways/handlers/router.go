@@ -1,16 +1,20 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
 	httputils "github.com/irenicaa/go-http-utils"
+	"github.com/irenicaa/go-todo-backend/v2/models"
 )
 
 // Router ...
 type Router struct {
 	BaseURL    string
 	TodoRecord TodoRecord
+	List       List
+	Tag        Tag
 	Logger     httputils.Logger
 }
 
@@ -19,15 +23,86 @@ func (router Router) ServeHTTP(
 	writer http.ResponseWriter,
 	request *http.Request,
 ) {
+	if request.URL.Path == router.BaseURL+"/tags" &&
+		request.Method == http.MethodGet {
+		router.Tag.GetAll(writer, request)
+		return
+	}
+	if strings.HasPrefix(request.URL.Path, router.BaseURL+"/lists") {
+		switch request.Method {
+		case http.MethodPost:
+			router.List.Create(writer, request)
+			return
+		case http.MethodGet:
+			if request.URL.Path == router.BaseURL+"/lists" {
+				router.List.GetAll(writer, request)
+			} else if strings.HasSuffix(request.URL.Path, "/todos") {
+				router.TodoRecord.GetAllByList(writer, request)
+			} else {
+				router.List.GetSingle(writer, request)
+			}
+
+			return
+		case http.MethodPut:
+			router.List.Update(writer, request)
+			return
+		case http.MethodDelete:
+			router.List.DeleteSingle(writer, request)
+			return
+		}
+	}
+	if strings.HasPrefix(request.URL.Path, router.BaseURL+"/trash") {
+		switch request.Method {
+		case http.MethodGet:
+			if request.URL.Path == router.BaseURL+"/trash" {
+				router.TodoRecord.GetTrash(writer, request)
+				return
+			}
+		case http.MethodPost:
+			if strings.HasSuffix(request.URL.Path, "/restore") {
+				router.TodoRecord.Restore(writer, request)
+				return
+			}
+		case http.MethodDelete:
+			if request.URL.Path == router.BaseURL+"/trash" {
+				router.TodoRecord.PurgeTrash(writer, request)
+				return
+			}
+		}
+	}
+	if request.URL.Path == router.BaseURL+"/history" &&
+		request.Method == http.MethodGet {
+		router.TodoRecord.GetHistory(writer, request)
+		return
+	}
+	if request.URL.Path == router.BaseURL+"/todos/batch" &&
+		request.Method == http.MethodPost {
+		router.TodoRecord.Batch(writer, request)
+		return
+	}
 	if strings.HasPrefix(request.URL.Path, router.BaseURL+"/todos") {
 		switch request.Method {
 		case http.MethodPost:
-			router.TodoRecord.Create(writer, request)
+			if strings.HasSuffix(request.URL.Path, "/revert") {
+				router.TodoRecord.Revert(writer, request)
+			} else if strings.HasSuffix(request.URL.Path, "/blockers") {
+				router.TodoRecord.AddDependency(writer, request)
+			} else {
+				router.TodoRecord.Create(writer, request)
+			}
+
 			return
 		case http.MethodGet:
 			if request.URL.Path == router.BaseURL+"/todos" {
 				router.TodoRecord.GetAll(writer, request)
-			} else if httputils.DatePattern.MatchString(request.URL.Path) {
+			} else if strings.HasSuffix(request.URL.Path, "/history") {
+				router.TodoRecord.GetHistoryByID(writer, request)
+			} else if strings.HasSuffix(request.URL.Path, "/occurrences") {
+				router.TodoRecord.GetOccurrences(writer, request)
+			} else if strings.HasSuffix(request.URL.Path, "/children") {
+				router.TodoRecord.GetAllByParent(writer, request)
+			} else if httputils.DatePattern.MatchString(request.URL.Path) ||
+				request.URL.Path == router.BaseURL+"/todos/today" {
 				router.TodoRecord.GetAllByDate(writer, request)
 			} else {
 				router.TodoRecord.GetSingle(writer, request)
@@ -43,6 +118,8 @@ func (router Router) ServeHTTP(
 		case http.MethodDelete:
 			if request.URL.Path == router.BaseURL+"/todos" {
 				router.TodoRecord.DeleteAll(writer, request)
+			} else if strings.Contains(request.URL.Path, "/blockers/") {
+				router.TodoRecord.DeleteDependency(writer, request)
 			} else {
 				router.TodoRecord.DeleteSingle(writer, request)
 			}
@@ -51,6 +128,13 @@ func (router Router) ServeHTTP(
 		}
 	}
 
-	status, message := http.StatusNotFound, http.StatusText(http.StatusNotFound)
-	httputils.HandleError(writer, router.Logger, status, message)
+	handleError(writer, request, router.Logger, models.Problem{
+		Status: http.StatusNotFound,
+		Detail: fmt.Sprintf(
+			"unable to find the route %s %s",
+			request.Method,
+			request.URL.Path,
+		),
+		Code: "route_not_found",
+	})
 }
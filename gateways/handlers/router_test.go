@@ -18,10 +18,12 @@ import (
 
 func TestRouter_ServeHTTP(t *testing.T) {
 	type fields struct {
-		BaseURL   string
-		URLScheme string
-		UseCase   TodoRecordUseCase
-		Logger    httputils.Logger
+		BaseURL     string
+		URLScheme   string
+		UseCase     TodoRecordUseCase
+		ListUseCase ListUseCase
+		TagUseCase  TagUseCase
+		Logger      httputils.Logger
 	}
 	type args struct {
 		request *http.Request
@@ -51,6 +53,7 @@ func TestRouter_ServeHTTP(t *testing.T) {
 							Title:     "test",
 							Completed: true,
 							Order:     12,
+							Tags:      []string{},
 						},
 						{
 							URL: "http://example.com/api/v1/todos/23",
@@ -62,17 +65,20 @@ func TestRouter_ServeHTTP(t *testing.T) {
 							Title:     "test2",
 							Completed: false,
 							Order:     42,
+							Tags:      []string{},
 						},
 					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
 						On("GetAll", baseURL, models.Query{}).
-						Return(presentationTodos, nil)
+						Return(presentationTodos, models.PageInfo{TotalCount: 2}, nil)
 
 					return useCase
 				}(),
-				Logger: &MockLogger{},
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
@@ -88,18 +94,23 @@ func TestRouter_ServeHTTP(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"2"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`[{"url":"http://example.com/api/v1/todos/5",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":12},` +
+						`"order":12,` +
+						`"tags":[]},` +
 						`{"url":"http://example.com/api/v1/todos/23",` +
 						`"date":"2006-01-03",` +
 						`"title":"test2",` +
 						`"completed":false,` +
-						`"order":42}]`,
+						`"order":42,` +
+						`"tags":[]}]`,
 				))),
 				ContentLength: -1,
 			},
@@ -122,6 +133,7 @@ func TestRouter_ServeHTTP(t *testing.T) {
 							Title:     "test",
 							Completed: true,
 							Order:     12,
+							Tags:      []string{},
 						},
 						{
 							URL: "http://example.com/api/v1/todos/23",
@@ -133,28 +145,27 @@ func TestRouter_ServeHTTP(t *testing.T) {
 							Title:     "test2",
 							Completed: false,
 							Order:     42,
+							Tags:      []string{},
 						},
 					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
 						On("GetAll", baseURL, models.Query{
-							MinimalDate: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
-							MaximalDate: utilmodels.Date(time.Date(
+							Date: utilmodels.Date(time.Date(
 								2006, time.January, 2,
 								0, 0, 0, 0,
 								time.UTC,
 							)),
+							TimeZone: time.UTC,
 						}).
-						Return(presentationTodos, nil)
+						Return(presentationTodos, models.PageInfo{TotalCount: 2}, nil)
 
 					return useCase
 				}(),
-				Logger: &MockLogger{},
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
@@ -170,22 +181,75 @@ func TestRouter_ServeHTTP(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"2"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`[{"url":"http://example.com/api/v1/todos/5",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":12},` +
+						`"order":12,` +
+						`"tags":[]},` +
 						`{"url":"http://example.com/api/v1/todos/23",` +
 						`"date":"2006-01-02",` +
 						`"title":"test2",` +
 						`"completed":false,` +
-						`"order":42}]`,
+						`"order":42,` +
+						`"tags":[]}]`,
 				))),
 				ContentLength: -1,
 			},
 		},
+		{
+			name: "success with getting of today records",
+			fields: fields{
+				BaseURL:   "/api/v1",
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{}
+
+					year, month, day := time.Now().UTC().Date()
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							Date: utilmodels.Date(
+								time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+							),
+							TimeZone: time.UTC,
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 0}, nil)
+
+					return useCase
+				}(),
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/today",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"0"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
+				ContentLength: -1,
+			},
+		},
 		{
 			name: "success with getting of a single record",
 			fields: fields{
@@ -203,6 +267,8 @@ func TestRouter_ServeHTTP(t *testing.T) {
 						Title:     "test",
 						Completed: true,
 						Order:     23,
+						Tags:      []string{},
+						Version:   5,
 					}
 
 					useCase := &MockTodoRecordUseCase{}
@@ -212,7 +278,9 @@ func TestRouter_ServeHTTP(t *testing.T) {
 
 					return useCase
 				}(),
-				Logger: &MockLogger{},
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
@@ -228,13 +296,17 @@ func TestRouter_ServeHTTP(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5"`},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`{"url":"http://example.com/api/v1/todos/12",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":23}`,
+						`"order":23,` +
+						`"tags":[]}`,
 				))),
 				ContentLength: -1,
 			},
@@ -266,16 +338,20 @@ func TestRouter_ServeHTTP(t *testing.T) {
 						Title:     "test",
 						Completed: true,
 						Order:     23,
+						Tags:      []string{},
+						Version:   5,
 					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
-						On("Create", baseURL, presentationTodoIn).
+						On("Create", baseURL, presentationTodoIn, "").
 						Return(presentationTodoOut, nil)
 
 					return useCase
 				}(),
-				Logger: &MockLogger{},
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
@@ -296,13 +372,17 @@ func TestRouter_ServeHTTP(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5"`},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`{"url":"http://example.com/api/v1/todos/12",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":23}`,
+						`"order":23,` +
+						`"tags":[]}`,
 				))),
 				ContentLength: -1,
 			},
@@ -334,16 +414,20 @@ func TestRouter_ServeHTTP(t *testing.T) {
 						Title:     "test",
 						Completed: true,
 						Order:     23,
+						Tags:      []string{},
+						Version:   5,
 					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
-						On("Update", baseURL, 12, presentationTodoIn).
+						On("Update", baseURL, 12, presentationTodoIn, 0, false, false, "").
 						Return(presentationTodoOut, nil)
 
 					return useCase
 				}(),
-				Logger: &MockLogger{},
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
@@ -364,13 +448,17 @@ func TestRouter_ServeHTTP(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5"`},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`{"url":"http://example.com/api/v1/todos/12",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":23}`,
+						`"order":23,` +
+						`"tags":[]}`,
 				))),
 				ContentLength: -1,
 			},
@@ -394,16 +482,20 @@ func TestRouter_ServeHTTP(t *testing.T) {
 						Title:     "test",
 						Completed: true,
 						Order:     23,
+						Tags:      []string{},
+						Version:   5,
 					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
-						On("Patch", baseURL, 12, todoPatch).
+						On("Patch", baseURL, 12, todoPatch, 0, false, false, "").
 						Return(presentationTodo, nil)
 
 					return useCase
 				}(),
-				Logger: &MockLogger{},
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
@@ -419,13 +511,17 @@ func TestRouter_ServeHTTP(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5"`},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`{"url":"http://example.com/api/v1/todos/12",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":23}`,
+						`"order":23,` +
+						`"tags":[]}`,
 				))),
 				ContentLength: -1,
 			},
@@ -437,28 +533,34 @@ func TestRouter_ServeHTTP(t *testing.T) {
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
 					useCase := &MockTodoRecordUseCase{}
-					useCase.InnerMock.On("DeleteAll").Return(nil)
+					useCase.InnerMock.On("DeleteAll", models.Query{}, "").Return(2, nil)
 
 					return useCase
 				}(),
-				Logger: &MockLogger{},
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodDelete,
-					"http://example.com/api/v1/todos",
+					"http://example.com/api/v1/todos?confirm=all",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusNoContent) + " " +
-					http.StatusText(http.StatusNoContent),
-				StatusCode:    http.StatusNoContent,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{},
-				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"deleted_count":2}`,
+				))),
 				ContentLength: -1,
 			},
 		},
@@ -469,11 +571,13 @@ func TestRouter_ServeHTTP(t *testing.T) {
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
 					useCase := &MockTodoRecordUseCase{}
-					useCase.InnerMock.On("DeleteSingle", 12).Return(nil)
+					useCase.InnerMock.On("DeleteSingle", 12, 0, false, "").Return(nil)
 
 					return useCase
 				}(),
-				Logger: &MockLogger{},
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
@@ -495,95 +599,764 @@ func TestRouter_ServeHTTP(t *testing.T) {
 			},
 		},
 		{
-			name: "error with an unknown HTTP method",
+			name: "success with executing of a batch",
 			fields: fields{
 				BaseURL:   "/api/v1",
 				URLScheme: "http",
-				UseCase:   &MockTodoRecordUseCase{},
-				Logger: func() httputils.Logger {
-					logger := &MockLogger{}
-					logger.InnerMock.
-						On("Print", []interface{}{http.StatusText(http.StatusNotFound)}).
-						Return().
-						Times(1)
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					batch := models.Batch{
+						Operations: []models.BatchOperation{
+							{Action: models.BatchActionDelete, ID: 12},
+						},
+					}
 
-					return logger
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Batch", baseURL, batch, "").
+						Return([]models.BatchResult{{}}, nil)
+
+					return useCase
 				}(),
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodHead,
-					"http://example.com/api/v1/todos",
-					nil,
+					http.MethodPost,
+					"http://example.com/api/v1/todos/batch",
+					bytes.NewReader([]byte(`{
+						"operations": [{"action": "delete", "id": 12}]
+					}`)),
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusNotFound) + " " +
-					http.StatusText(http.StatusNotFound),
-				StatusCode: http.StatusNotFound,
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					http.StatusText(http.StatusNotFound),
+					`{"committed":true,"results":[{"status":204}]}`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error with an unknown route",
+			name: "success with getting of all trashed records",
 			fields: fields{
 				BaseURL:   "/api/v1",
 				URLScheme: "http",
-				UseCase:   &MockTodoRecordUseCase{},
-				Logger: func() httputils.Logger {
-					logger := &MockLogger{}
-					logger.InnerMock.
-						On("Print", []interface{}{http.StatusText(http.StatusNotFound)}).
-						Return().
-						Times(1)
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{}
 
-					return logger
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{Deleted: true}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 0}, nil)
+
+					return useCase
 				}(),
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/incorrect",
+					"http://example.com/api/v1/trash",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusNotFound) + " " +
-					http.StatusText(http.StatusNotFound),
-				StatusCode: http.StatusNotFound,
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"0"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with restoring of a record",
+			fields: fields{
+				BaseURL:   "/api/v1",
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodo := models.PresentationTodoRecord{
+						URL: "http://example.com/api/v1/todos/12",
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:   "test",
+						Tags:    []string{},
+						Version: 5,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Restore", baseURL, 12, "").
+						Return(presentationTodo, nil)
+
+					return useCase
+				}(),
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/trash/12/restore",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5"`},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					http.StatusText(http.StatusNotFound),
+					`{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":false,` +
+						`"order":0,` +
+						`"tags":[]}`,
 				))),
 				ContentLength: -1,
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			responseRecorder := httptest.NewRecorder()
-			router := Router{
-				BaseURL: tt.fields.BaseURL,
-				TodoRecord: TodoRecord{
-					URLScheme: tt.fields.URLScheme,
-					UseCase:   tt.fields.UseCase,
-					Logger:    tt.fields.Logger,
+		{
+			name: "success with purging of the trash",
+			fields: fields{
+				BaseURL:   "/api/v1",
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("PurgeTrash", time.Duration(0)).
+						Return(2, nil)
+
+					return useCase
+				}(),
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/trash",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"deleted_count":2}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with getting of the history",
+			fields: fields{
+				BaseURL:   "/api/v1",
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationRecords := []models.PresentationHistoryRecord{}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetHistory", baseURL, models.HistoryQuery{}).
+						Return(presentationRecords, "", nil)
+
+					return useCase
+				}(),
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/history",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with getting of the history of a record",
+			fields: fields{
+				BaseURL:   "/api/v1",
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationRecords := []models.PresentationHistoryRecord{}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetHistory", baseURL, models.HistoryQuery{TodoRecordID: 12}).
+						Return(presentationRecords, "", nil)
+
+					return useCase
+				}(),
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12/history",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with getting of the occurrences of a record",
+			fields: fields{
+				BaseURL:   "/api/v1",
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On(
+							"GetOccurrences",
+							baseURL,
+							12,
+							time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+							time.Date(2006, time.January, 14, 0, 0, 0, 0, time.UTC),
+						).
+						Return(presentationTodos, nil)
+
+					return useCase
+				}(),
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12/occurrences"+
+						"?minimal_date=2006-01-01&maximal_date=2006-01-14",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with getting of the children of a record",
+			fields: fields{
+				BaseURL:   "/api/v1",
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{ParentID: 12}).
+						Return([]models.PresentationTodoRecord{}, models.PageInfo{}, nil)
+
+					return useCase
+				}(),
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12/children",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"0"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with deleting of a dependency",
+			fields: fields{
+				BaseURL:   "/api/v1",
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.On("DeleteDependency", 12, 5).Return(nil)
+
+					return useCase
+				}(),
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos/12/blockers/5",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNoContent) + " " +
+					http.StatusText(http.StatusNoContent),
+				StatusCode:    http.StatusNoContent,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with reverting of a record",
+			fields: fields{
+				BaseURL:   "/api/v1",
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodo := models.PresentationTodoRecord{
+						URL: "http://example.com/api/v1/todos/12",
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:   "test",
+						Tags:    []string{},
+						Version: 5,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Revert", baseURL, 12, 23, 0, "").
+						Return(presentationTodo, nil)
+
+					return useCase
+				}(),
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger:      &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/todos/12/revert?revision=23",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5"`},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":false,` +
+						`"order":0,` +
+						`"tags":[]}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with getting of all lists",
+			fields: fields{
+				BaseURL:   "/api/v1",
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				ListUseCase: func() ListUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationLists := []models.PresentationList{
+						{
+							URL:      "http://example.com/api/v1/lists/5",
+							Title:    "test",
+							TodosURL: "http://example.com/api/v1/lists/5/todos",
+						},
+					}
+
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL).
+						Return(presentationLists, nil)
+
+					return useCase
+				}(),
+				TagUseCase: &MockTagUseCase{},
+				Logger:     &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/lists",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"url":"http://example.com/api/v1/lists/5",` +
+						`"title":"test",` +
+						`"todos_url":"http://example.com/api/v1/lists/5/todos"}]`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with getting of all records of a list",
+			fields: fields{
+				BaseURL:   "/api/v1",
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{ListID: 5}).
+						Return([]models.PresentationTodoRecord{}, models.PageInfo{}, nil)
+
+					return useCase
+				}(),
+				ListUseCase: func() ListUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationList := models.PresentationList{
+						URL:      "http://example.com/api/v1/lists/5",
+						Title:    "test",
+						TodosURL: "http://example.com/api/v1/lists/5/todos",
+					}
+
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 5).
+						Return(presentationList, nil)
+
+					return useCase
+				}(),
+				TagUseCase: &MockTagUseCase{},
+				Logger:     &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/lists/5/todos",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"0"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with deleting of a list",
+			fields: fields{
+				BaseURL:   "/api/v1",
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				ListUseCase: func() ListUseCase {
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.On("DeleteSingle", 5, true, "").Return(nil)
+
+					return useCase
+				}(),
+				TagUseCase: &MockTagUseCase{},
+				Logger:     &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/lists/5?cascade=1",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNoContent) + " " +
+					http.StatusText(http.StatusNoContent),
+				StatusCode:    http.StatusNoContent,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with getting of all tags",
+			fields: fields{
+				BaseURL:     "/api/v1",
+				URLScheme:   "http",
+				UseCase:     &MockTodoRecordUseCase{},
+				ListUseCase: &MockListUseCase{},
+				TagUseCase: func() TagUseCase {
+					tags := []models.Tag{
+						{Name: "one", Count: 2},
+						{Name: "two", Count: 1},
+					}
+
+					useCase := &MockTagUseCase{}
+					useCase.InnerMock.On("GetAll").Return(tags, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/tags",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"name":"one","count":2},{"name":"two","count":1}]`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with an unknown HTTP method",
+			fields: fields{
+				BaseURL:     "/api/v1",
+				URLScheme:   "http",
+				UseCase:     &MockTodoRecordUseCase{},
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to find the route HEAD /api/v1/todos"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodHead,
+					"http://example.com/api/v1/todos",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Not Found",` +
+						`"status":404,` +
+						`"detail":"unable to find the route HEAD /api/v1/todos",` +
+						`"instance":"/api/v1/todos",` +
+						`"code":"route_not_found"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with an unknown route",
+			fields: fields{
+				BaseURL:     "/api/v1",
+				URLScheme:   "http",
+				UseCase:     &MockTodoRecordUseCase{},
+				ListUseCase: &MockListUseCase{},
+				TagUseCase:  &MockTagUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to find the route GET /api/v1/incorrect"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/incorrect",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Not Found",` +
+						`"status":404,` +
+						`"detail":"unable to find the route GET /api/v1/incorrect",` +
+						`"instance":"/api/v1/incorrect",` +
+						`"code":"route_not_found"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			router := Router{
+				BaseURL: tt.fields.BaseURL,
+				TodoRecord: TodoRecord{
+					URLScheme:   tt.fields.URLScheme,
+					UseCase:     tt.fields.UseCase,
+					ListUseCase: tt.fields.ListUseCase,
+					Logger:      tt.fields.Logger,
+				},
+				List: List{
+					URLScheme: tt.fields.URLScheme,
+					UseCase:   tt.fields.ListUseCase,
+					Logger:    tt.fields.Logger,
+				},
+				Tag: Tag{
+					UseCase: tt.fields.TagUseCase,
+					Logger:  tt.fields.Logger,
 				},
 				Logger: tt.fields.Logger,
 			}
 			router.ServeHTTP(responseRecorder, tt.args.request)
 
 			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.ListUseCase.(*MockListUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.TagUseCase.(*MockTagUseCase).InnerMock.AssertExpectations(t)
 			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
 			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
 		})
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	httputils "github.com/irenicaa/go-http-utils"
+	"github.com/irenicaa/go-todo-backend/v2/models"
+)
+
+// TagUseCase ...
+type TagUseCase interface {
+	GetAll() ([]models.Tag, error)
+}
+
+// Tag ...
+type Tag struct {
+	UseCase TagUseCase
+	Logger  httputils.Logger
+}
+
+// GetAll ...
+//   @router /tags [GET]
+//   @summary get all tags with their usage counts
+//   @produce json
+//   @success 200 {array} models.Tag
+//   @failure 500 {object} models.Problem
+func (handler Tag) GetAll(writer http.ResponseWriter, request *http.Request) {
+	tags, err := handler.UseCase.GetAll()
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	httputils.HandleJSON(writer, handler.Logger, tags)
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"testing/iotest"
+
+	httputils "github.com/irenicaa/go-http-utils"
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTag_GetAll(t *testing.T) {
+	type fields struct {
+		UseCase TagUseCase
+		Logger  httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				UseCase: func() TagUseCase {
+					tags := []models.Tag{
+						{Name: "one", Count: 2},
+						{Name: "two", Count: 1},
+					}
+
+					useCase := &MockTagUseCase{}
+					useCase.InnerMock.On("GetAll").Return(tags, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/tags",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"name":"one","count":2},{"name":"two","count":1}]`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error",
+			fields: fields{
+				UseCase: func() TagUseCase {
+					useCase := &MockTagUseCase{}
+					useCase.InnerMock.
+						On("GetAll").
+						Return([]models.Tag(nil), iotest.ErrTimeout)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"timeout"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/tags",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/tags",` +
+						`"code":"internal_error"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := Tag{
+				UseCase: tt.fields.UseCase,
+				Logger:  tt.fields.Logger,
+			}
+			handler.GetAll(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTagUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
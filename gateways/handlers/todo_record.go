@@ -4,8 +4,13 @@ import (
 	"math"
 	"net/http"
 	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 
 	httputils "github.com/irenicaa/go-http-utils"
+	utilmodels "github.com/irenicaa/go-http-utils/models"
 	"github.com/irenicaa/go-todo-backend/v2/models"
 )
 
@@ -13,10 +18,16 @@ import (
 type TodoRecordUseCase interface {
 	GetAll(baseURL *url.URL, query models.Query) (
 		[]models.PresentationTodoRecord,
+		models.PageInfo,
 		error,
 	)
 	GetSingle(baseURL *url.URL, id int) (models.PresentationTodoRecord, error)
-	Create(baseURL *url.URL, presentationTodo models.PresentationTodoRecord) (
+	GetTree(baseURL *url.URL, id int) (models.PresentationTodoRecord, error)
+	Create(
+		baseURL *url.URL,
+		presentationTodo models.PresentationTodoRecord,
+		actor string,
+	) (
 		models.PresentationTodoRecord,
 		error,
 	)
@@ -24,23 +35,81 @@ type TodoRecordUseCase interface {
 		baseURL *url.URL,
 		id int,
 		presentationTodo models.PresentationTodoRecord,
+		version int,
+		cascade bool,
+		force bool,
+		actor string,
+	) (
+		models.PresentationTodoRecord,
+		error,
+	)
+	Patch(
+		baseURL *url.URL,
+		id int,
+		todoPatch models.TodoRecordPatch,
+		version int,
+		cascade bool,
+		force bool,
+		actor string,
+	) (
+		models.PresentationTodoRecord,
+		error,
+	)
+	DeleteAll(query models.Query, actor string) (int, error)
+	DeleteSingle(id int, version int, cascade bool, actor string) error
+	Batch(baseURL *url.URL, batch models.Batch, actor string) (
+		[]models.BatchResult,
+		error,
+	)
+	Restore(baseURL *url.URL, id int, actor string) (
+		models.PresentationTodoRecord,
+		error,
+	)
+	PurgeTrash(retention time.Duration) (int, error)
+	AddDependency(
+		baseURL *url.URL,
+		id int,
+		dependency models.Dependency,
 	) (
 		models.PresentationTodoRecord,
 		error,
 	)
-	Patch(baseURL *url.URL, id int, todoPatch models.TodoRecordPatch) (
+	DeleteDependency(id int, blockerID int) error
+	GetHistory(baseURL *url.URL, query models.HistoryQuery) (
+		[]models.PresentationHistoryRecord,
+		string,
+		error,
+	)
+	Revert(
+		baseURL *url.URL,
+		id int,
+		revision int,
+		version int,
+		actor string,
+	) (
 		models.PresentationTodoRecord,
 		error,
 	)
-	DeleteAll() error
-	DeleteSingle(id int) error
+	GetOccurrences(
+		baseURL *url.URL,
+		id int,
+		minimalDate time.Time,
+		maximalDate time.Time,
+	) (
+		[]models.PresentationTodoRecord,
+		error,
+	)
 }
 
 // TodoRecord ...
+//
+// The list use case is used only to check the list existence
+// on the GetAllByList() method.
 type TodoRecord struct {
-	URLScheme string
-	UseCase   TodoRecordUseCase
-	Logger    httputils.Logger
+	URLScheme   string
+	UseCase     TodoRecordUseCase
+	ListUseCase ListUseCase
+	Logger      httputils.Logger
 }
 
 // GetAll ...
@@ -49,189 +118,384 @@ type TodoRecord struct {
 //   @param minimal_date query string false "filtration by the minimal date in the RFC 3339 format"
 //   @param maximal_date query string false "filtration by the maximal date in the RFC 3339 format"
 //   @param title_fragment query string false "search by the title fragment"
+//   @param q query string false "full-text search by the title (the web search syntax)"
+//   @param search_notes query boolean false "extend the title_fragment and q parameters to the notes"
+//   @param highlight query boolean false "return the title with the matched words in the highlight field and the matched notes in the notes_highlight one (requires q)"
+//   @param render query string false "return the notes rendered to the sanitized HTML in the notes_html field, the only allowed value is html"
+//   @param tags query string false "filtration by any of the comma-separated tags"
+//   @param all_tags query string false "filtration by all of the comma-separated tags"
+//   @param completed query boolean false "filtration by the completion status"
+//   @param blocked query boolean false "filtration by the presence of the open blockers"
+//   @param min_order query integer false "filtration by the minimal order"
+//   @param max_order query integer false "filtration by the maximal order"
+//   @param min_priority query integer false "filtration by the minimal priority (from 0 to 4)"
+//   @param ids query string false "filtration by the comma-separated IDs"
+//   @param created_after query string false "filtration by the creation time at or after in the RFC 3339 format"
+//   @param created_before query string false "filtration by the creation time before in the RFC 3339 format"
+//   @param updated_after query string false "filtration by the update time at or after in the RFC 3339 format"
+//   @param updated_before query string false "filtration by the update time before in the RFC 3339 format"
+//   @param completed_after query string false "filtration by the completion time at or after in the RFC 3339 format"
+//   @param completed_before query string false "filtration by the completion time before in the RFC 3339 format"
+//   @param due_after query string false "filtration by the due time at or after in the RFC 3339 format"
+//   @param due_before query string false "filtration by the due time before in the RFC 3339 format"
+//   @param sort query string false "comma-separated fields (id, date, title, completed, order, priority, relevance, created_at, updated_at, completed_at) with an optional minus prefix for the descending order; relevance requires q and is approximated by the title length in the SQLite and memory storages"
 //   @param page_size query integer false "specify the page size for pagination" minimum(1)
 //   @param page query integer false "specify the page for pagination" minimum(1)
+//   @param cursor query string false "specify the cursor for pagination (requires page_size and excludes page)"
 //   @produce json
-//   @success 200 {array} models.PresentationTodoRecord
-//   @failure 500 {string} string
+//   @success 200 {array} models.PresentationTodoRecord "to-do records (the models.PresentationTodoRecordPage object in the cursor mode)"
+//   @header 200 {string} X-Next-Cursor "cursor of the next page in the cursor mode (missed on the last page)"
+//   @header 200 {integer} X-Total-Count "count of the to-do records matching the filters"
+//   @header 200 {string} Link "RFC 8288 links to the first, previous, next and last pages"
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
 func (handler TodoRecord) GetAll(
 	writer http.ResponseWriter,
 	request *http.Request,
 ) {
-	minimalDate, err := httputils.GetDateFormValue(request, "minimal_date")
-	if err != nil && err != httputils.ErrKeyIsMissed {
-		status, message :=
-			http.StatusBadRequest, "unable to get the minimal_date parameter: %v"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
-
-		return
-	}
+	handler.getAll(writer, request, 0, 0)
+}
 
-	maximalDate, err := httputils.GetDateFormValue(request, "maximal_date")
-	if err != nil && err != httputils.ErrKeyIsMissed {
-		status, message :=
-			http.StatusBadRequest, "unable to get the maximal_date parameter: %v"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+// GetAllByList ...
+//   @router /lists/{id}/todos [GET]
+//   @summary get all to-do records of the list
+//   @param id path integer true "list ID"
+//   @param minimal_date query string false "filtration by the minimal date in the RFC 3339 format"
+//   @param maximal_date query string false "filtration by the maximal date in the RFC 3339 format"
+//   @param title_fragment query string false "search by the title fragment"
+//   @param q query string false "full-text search by the title (the web search syntax)"
+//   @param search_notes query boolean false "extend the title_fragment and q parameters to the notes"
+//   @param highlight query boolean false "return the title with the matched words in the highlight field and the matched notes in the notes_highlight one (requires q)"
+//   @param render query string false "return the notes rendered to the sanitized HTML in the notes_html field, the only allowed value is html"
+//   @param tags query string false "filtration by any of the comma-separated tags"
+//   @param all_tags query string false "filtration by all of the comma-separated tags"
+//   @param completed query boolean false "filtration by the completion status"
+//   @param blocked query boolean false "filtration by the presence of the open blockers"
+//   @param min_order query integer false "filtration by the minimal order"
+//   @param max_order query integer false "filtration by the maximal order"
+//   @param min_priority query integer false "filtration by the minimal priority (from 0 to 4)"
+//   @param ids query string false "filtration by the comma-separated IDs"
+//   @param created_after query string false "filtration by the creation time at or after in the RFC 3339 format"
+//   @param created_before query string false "filtration by the creation time before in the RFC 3339 format"
+//   @param updated_after query string false "filtration by the update time at or after in the RFC 3339 format"
+//   @param updated_before query string false "filtration by the update time before in the RFC 3339 format"
+//   @param completed_after query string false "filtration by the completion time at or after in the RFC 3339 format"
+//   @param completed_before query string false "filtration by the completion time before in the RFC 3339 format"
+//   @param due_after query string false "filtration by the due time at or after in the RFC 3339 format"
+//   @param due_before query string false "filtration by the due time before in the RFC 3339 format"
+//   @param sort query string false "comma-separated fields (id, date, title, completed, order, priority, relevance, created_at, updated_at, completed_at) with an optional minus prefix for the descending order; relevance requires q and is approximated by the title length in the SQLite and memory storages"
+//   @param page_size query integer false "specify the page size for pagination" minimum(1)
+//   @param page query integer false "specify the page for pagination" minimum(1)
+//   @param cursor query string false "specify the cursor for pagination (requires page_size and excludes page)"
+//   @produce json
+//   @success 200 {array} models.PresentationTodoRecord "to-do records (the models.PresentationTodoRecordPage object in the cursor mode)"
+//   @header 200 {string} X-Next-Cursor "cursor of the next page in the cursor mode (missed on the last page)"
+//   @header 200 {integer} X-Total-Count "count of the to-do records matching the filters"
+//   @header 200 {string} Link "RFC 8288 links to the first, previous, next and last pages"
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler TodoRecord) GetAllByList(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	listID, err := httputils.GetIDFromURL(request)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
 
 		return
 	}
 
-	pageSize, err :=
-		httputils.GetIntFormValue(request, "page_size", 1, math.MaxInt32)
-	if err != nil && err != httputils.ErrKeyIsMissed {
-		status, message :=
-			http.StatusBadRequest, "unable to get the page_size parameter: %v"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+	// distinguish the unknown list from the empty one
+	baseURL := handler.getBaseURL(request)
+	if _, err := handler.ListUseCase.GetSingle(baseURL, listID); err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
 
 		return
 	}
 
-	page, err := httputils.GetIntFormValue(request, "page", 1, math.MaxInt32)
-	if err != nil && err != httputils.ErrKeyIsMissed {
-		status, message :=
-			http.StatusBadRequest, "unable to get the page parameter: %v"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
-
-		return
-	}
+	handler.getAll(writer, request, listID, 0)
+}
 
-	baseURL := handler.getBaseURL(request)
-	presentationTodos, err := handler.UseCase.GetAll(baseURL, models.Query{
-		MinimalDate:   minimalDate,
-		MaximalDate:   maximalDate,
-		TitleFragment: request.FormValue("title_fragment"),
-		Pagination:    models.Pagination{PageSize: pageSize, Page: page},
-	})
+// GetAllByParent returns the direct children of the to-do record.
+//   @router /todos/{id}/children [GET]
+//   @summary get all child to-do records of the to-do record
+//   @param id path integer true "parent to-do record ID"
+//   @param minimal_date query string false "filtration by the minimal date in the RFC 3339 format"
+//   @param maximal_date query string false "filtration by the maximal date in the RFC 3339 format"
+//   @param title_fragment query string false "search by the title fragment"
+//   @param q query string false "full-text search by the title (the web search syntax)"
+//   @param search_notes query boolean false "extend the title_fragment and q parameters to the notes"
+//   @param highlight query boolean false "return the title with the matched words in the highlight field and the matched notes in the notes_highlight one (requires q)"
+//   @param render query string false "return the notes rendered to the sanitized HTML in the notes_html field, the only allowed value is html"
+//   @param tags query string false "filtration by any of the comma-separated tags"
+//   @param all_tags query string false "filtration by all of the comma-separated tags"
+//   @param completed query boolean false "filtration by the completion status"
+//   @param blocked query boolean false "filtration by the presence of the open blockers"
+//   @param min_order query integer false "filtration by the minimal order"
+//   @param max_order query integer false "filtration by the maximal order"
+//   @param min_priority query integer false "filtration by the minimal priority (from 0 to 4)"
+//   @param ids query string false "filtration by the comma-separated IDs"
+//   @param created_after query string false "filtration by the creation time at or after in the RFC 3339 format"
+//   @param created_before query string false "filtration by the creation time before in the RFC 3339 format"
+//   @param updated_after query string false "filtration by the update time at or after in the RFC 3339 format"
+//   @param updated_before query string false "filtration by the update time before in the RFC 3339 format"
+//   @param completed_after query string false "filtration by the completion time at or after in the RFC 3339 format"
+//   @param completed_before query string false "filtration by the completion time before in the RFC 3339 format"
+//   @param due_after query string false "filtration by the due time at or after in the RFC 3339 format"
+//   @param due_before query string false "filtration by the due time before in the RFC 3339 format"
+//   @param sort query string false "comma-separated fields (id, date, title, completed, order, priority, relevance, created_at, updated_at, completed_at) with an optional minus prefix for the descending order; relevance requires q and is approximated by the title length in the SQLite and memory storages"
+//   @param page_size query integer false "specify the page size for pagination" minimum(1)
+//   @param page query integer false "specify the page for pagination" minimum(1)
+//   @param cursor query string false "specify the cursor for pagination (requires page_size and excludes page)"
+//   @produce json
+//   @success 200 {array} models.PresentationTodoRecord "to-do records (the models.PresentationTodoRecordPage object in the cursor mode)"
+//   @header 200 {string} X-Next-Cursor "cursor of the next page in the cursor mode (missed on the last page)"
+//   @header 200 {integer} X-Total-Count "count of the to-do records matching the filters"
+//   @header 200 {string} Link "RFC 8288 links to the first, previous, next and last pages"
+//   @failure 400 {object} models.Problem
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler TodoRecord) GetAllByParent(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	parentID, err := httputils.GetIDFromURL(request)
 	if err != nil {
-		status, message := http.StatusInternalServerError, "%s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
 
 		return
 	}
 
-	httputils.HandleJSON(writer, handler.Logger, presentationTodos)
+	handler.getAll(writer, request, 0, parentID)
 }
 
 // GetAllByDate ...
+//
+// The to-do records with the due time are matched by it
+// in the time zone of the tz parameter, the rest ones by the date.
 //   @router /todos/{date} [GET]
 //   @summary get all to-do records
-//   @param date path string true "to-do record date in the RFC 3339 format"
+//   @param date path string true "to-do record date in the RFC 3339 format or today"
+//   @param tz query string false "IANA time zone of the date, e.g. Europe/Berlin (default: UTC)"
 //   @param title_fragment query string false "search by the title fragment"
+//   @param q query string false "full-text search by the title (the web search syntax)"
+//   @param search_notes query boolean false "extend the title_fragment and q parameters to the notes"
+//   @param highlight query boolean false "return the title with the matched words in the highlight field and the matched notes in the notes_highlight one (requires q)"
+//   @param render query string false "return the notes rendered to the sanitized HTML in the notes_html field, the only allowed value is html"
+//   @param tags query string false "filtration by any of the comma-separated tags"
+//   @param all_tags query string false "filtration by all of the comma-separated tags"
+//   @param completed query boolean false "filtration by the completion status"
+//   @param blocked query boolean false "filtration by the presence of the open blockers"
+//   @param min_order query integer false "filtration by the minimal order"
+//   @param max_order query integer false "filtration by the maximal order"
+//   @param min_priority query integer false "filtration by the minimal priority (from 0 to 4)"
+//   @param ids query string false "filtration by the comma-separated IDs"
+//   @param created_after query string false "filtration by the creation time at or after in the RFC 3339 format"
+//   @param created_before query string false "filtration by the creation time before in the RFC 3339 format"
+//   @param updated_after query string false "filtration by the update time at or after in the RFC 3339 format"
+//   @param updated_before query string false "filtration by the update time before in the RFC 3339 format"
+//   @param completed_after query string false "filtration by the completion time at or after in the RFC 3339 format"
+//   @param completed_before query string false "filtration by the completion time before in the RFC 3339 format"
+//   @param due_after query string false "filtration by the due time at or after in the RFC 3339 format"
+//   @param due_before query string false "filtration by the due time before in the RFC 3339 format"
+//   @param sort query string false "comma-separated fields (id, date, title, completed, order, priority, relevance, created_at, updated_at, completed_at) with an optional minus prefix for the descending order; relevance requires q and is approximated by the title length in the SQLite and memory storages"
 //   @param page_size query integer false "specify the page size for pagination" minimum(1)
 //   @param page query integer false "specify the page for pagination" minimum(1)
+//   @param cursor query string false "specify the cursor for pagination (requires page_size and excludes page)"
 //   @produce json
-//   @success 200 {array} models.PresentationTodoRecord
-//   @failure 500 {string} string
+//   @success 200 {array} models.PresentationTodoRecord "to-do records (the models.PresentationTodoRecordPage object in the cursor mode)"
+//   @header 200 {string} X-Next-Cursor "cursor of the next page in the cursor mode (missed on the last page)"
+//   @header 200 {integer} X-Total-Count "count of the to-do records matching the filters"
+//   @header 200 {string} Link "RFC 8288 links to the first, previous, next and last pages"
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
 func (handler TodoRecord) GetAllByDate(
 	writer http.ResponseWriter,
 	request *http.Request,
 ) {
-	date, err := httputils.GetDateFromURL(request)
+	timeZone, err := time.LoadLocation(request.FormValue("tz"))
 	if err != nil {
-		status, message := http.StatusBadRequest, "unable to get a date: %s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newParameterProblem(
+			"invalid_tz",
+			"tz",
+			"unable to get the tz parameter: %v",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
 
 		return
 	}
 
-	pageSize, err :=
-		httputils.GetIntFormValue(request, "page_size", 1, math.MaxInt32)
-	if err != nil && err != httputils.ErrKeyIsMissed {
-		status, message :=
-			http.StatusBadRequest, "unable to get the page_size parameter: %v"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
-
-		return
-	}
-
-	page, err := httputils.GetIntFormValue(request, "page", 1, math.MaxInt32)
-	if err != nil && err != httputils.ErrKeyIsMissed {
-		status, message :=
-			http.StatusBadRequest, "unable to get the page parameter: %v"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+	var date utilmodels.Date
+	if strings.HasSuffix(request.URL.Path, "/today") {
+		year, month, day := time.Now().In(timeZone).Date()
+		date = utilmodels.Date(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	} else {
+		date, err = httputils.GetDateFromURL(request)
+		if err != nil {
+			problem := newParameterProblem(
+				"invalid_date",
+				"date",
+				"unable to get a date: %s",
+				err,
+			)
+			handleError(writer, request, handler.Logger, problem)
 
-		return
+			return
+		}
 	}
 
-	baseURL := handler.getBaseURL(request)
-	presentationTodos, err := handler.UseCase.GetAll(baseURL, models.Query{
-		MinimalDate:   date,
-		MaximalDate:   date,
-		TitleFragment: request.FormValue("title_fragment"),
-		Pagination:    models.Pagination{PageSize: pageSize, Page: page},
-	})
-	if err != nil {
-		status, message := http.StatusInternalServerError, "%s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
-
+	query, ok := handler.getQuery(writer, request)
+	if !ok {
 		return
 	}
 
-	httputils.HandleJSON(writer, handler.Logger, presentationTodos)
+	query.Date, query.TimeZone = date, timeZone
+	handler.getAllByQuery(writer, request, query)
 }
 
 // GetSingle ...
+//
+// The entity tag of the tree hashes the entity tags of the children
+// recursively, see the formatETag() function.
 //   @router /todos/{id} [GET]
 //   @summary get the single to-do record
 //   @param id path integer true "to-do record ID"
+//   @param include query string false "return the descendants in the children field, the only allowed value is children"
+//   @param render query string false "return the notes rendered to the sanitized HTML in the notes_html field, the only allowed value is html"
+//   @param If-None-Match header string false "return 304 if the to-do record has one of these entity tags"
 //   @produce json
 //   @success 200 {object} models.PresentationTodoRecord
-//   @failure 400 {string} string
-//   @failure 500 {string} string
+//   @header 200 {string} ETag "to-do record version with the hash of the computed fields (they include the children for the tree)"
+//   @success 304 {string} string
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 500 {object} models.Problem
 func (handler TodoRecord) GetSingle(
 	writer http.ResponseWriter,
 	request *http.Request,
 ) {
 	id, err := httputils.GetIDFromURL(request)
 	if err != nil {
-		status, message := http.StatusBadRequest, "unable to get an ID: %s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	include := request.FormValue("include")
+	if include != "" && include != "children" {
+		problem := newParameterProblem(
+			"invalid_include",
+			"include",
+			"unable to get the include parameter: unknown value %q",
+			include,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
 
+	render, ok := handler.getRenderFormValue(writer, request)
+	if !ok {
 		return
 	}
 
 	baseURL := handler.getBaseURL(request)
+	if include == "children" {
+		presentationTodo, err := handler.UseCase.GetTree(baseURL, id)
+		if err != nil {
+			problem := newUseCaseProblem(err)
+			handleError(writer, request, handler.Logger, problem)
+
+			return
+		}
+		if render {
+			presentationTodo.RenderNotes()
+		}
+
+		handler.handleConditionalGet(writer, request, presentationTodo)
+		return
+	}
+
 	presentationTodo, err := handler.UseCase.GetSingle(baseURL, id)
 	if err != nil {
-		status, message := http.StatusInternalServerError, "%s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
 
 		return
 	}
+	if render {
+		presentationTodo.RenderNotes()
+	}
 
-	httputils.HandleJSON(writer, handler.Logger, presentationTodo)
+	handler.handleConditionalGet(writer, request, presentationTodo)
 }
 
 // Create ...
 //   @router /todos [POST]
 //   @summary create a to-do record
 //   @param body body models.PresentationTodoRecord true "to-do record data"
+//   @param X-Actor header string false "actor of the change for the history"
 //   @accept json
 //   @produce json
 //   @success 200 {object} models.PresentationTodoRecord
-//   @failure 400 {string} string
-//   @failure 500 {string} string
+//   @header 200 {string} ETag "to-do record version with the hash of the computed fields"
+//   @failure 400 {object} models.Problem
+//   @failure 409 {object} models.Problem
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
 func (handler TodoRecord) Create(
 	writer http.ResponseWriter,
 	request *http.Request,
 ) {
 	var presentationTodo models.PresentationTodoRecord
 	if err := httputils.ReadJSONData(request.Body, &presentationTodo); err != nil {
-		status, message := http.StatusBadRequest, "unable to get the request body: %s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newParameterProblem(
+			"invalid_request_body",
+			"body",
+			"unable to get the request body: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
 
 		return
 	}
 
 	baseURL := handler.getBaseURL(request)
-	presentationTodo, err := handler.UseCase.Create(baseURL, presentationTodo)
+	presentationTodo, err := handler.UseCase.Create(
+		baseURL,
+		presentationTodo,
+		getActor(request),
+	)
 	if err != nil {
-		status, message := http.StatusInternalServerError, "%s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
 
 		return
 	}
 
+	writer.Header().Set("ETag", formatETag(presentationTodo))
 	httputils.HandleJSON(writer, handler.Logger, presentationTodo)
 }
 
@@ -240,40 +504,83 @@ func (handler TodoRecord) Create(
 //   @summary update the to-do record
 //   @param id path integer true "to-do record ID"
 //   @param body body models.PresentationTodoRecord true "to-do record data"
+//   @param cascade query boolean false "complete the descendants along with the to-do record"
+//   @param force query boolean false "complete the to-do record even with the open blockers"
+//   @param If-Match header string false "update only if the to-do record has one of these entity tags"
+//   @param X-Actor header string false "actor of the change for the history"
 //   @accept json
 //   @produce json
 //   @success 200 {object} models.PresentationTodoRecord
-//   @failure 400 {string} string
-//   @failure 500 {string} string
+//   @header 200 {string} ETag "to-do record version with the hash of the computed fields"
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 409 {object} models.Problem
+//   @failure 412 {object} models.Problem
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
 func (handler TodoRecord) Update(
 	writer http.ResponseWriter,
 	request *http.Request,
 ) {
 	id, err := httputils.GetIDFromURL(request)
 	if err != nil {
-		status, message := http.StatusBadRequest, "unable to get an ID: %s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
 
+	version, ok := handler.getIfMatchVersion(writer, request, id)
+	if !ok {
+		return
+	}
+
+	cascade, ok := handler.getFlagFormValue(writer, request, "cascade")
+	if !ok {
+		return
+	}
+
+	force, ok := handler.getFlagFormValue(writer, request, "force")
+	if !ok {
 		return
 	}
 
 	var presentationTodo models.PresentationTodoRecord
 	if err := httputils.ReadJSONData(request.Body, &presentationTodo); err != nil {
-		status, message := http.StatusBadRequest, "unable to get the request body: %s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newParameterProblem(
+			"invalid_request_body",
+			"body",
+			"unable to get the request body: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
 
 		return
 	}
 
 	baseURL := handler.getBaseURL(request)
-	presentationTodo, err = handler.UseCase.Update(baseURL, id, presentationTodo)
+	presentationTodo, err = handler.UseCase.Update(
+		baseURL,
+		id,
+		presentationTodo,
+		version,
+		cascade,
+		force,
+		getActor(request),
+	)
 	if err != nil {
-		status, message := http.StatusInternalServerError, "%s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
 
 		return
 	}
 
+	writer.Header().Set("ETag", formatETag(presentationTodo))
 	httputils.HandleJSON(writer, handler.Logger, presentationTodo)
 }
 
@@ -282,84 +589,215 @@ func (handler TodoRecord) Update(
 //   @summary patch the to-do record
 //   @param id path integer true "to-do record ID"
 //   @param body body models.TodoRecordPatch true "to-do record patch"
+//   @param cascade query boolean false "complete the descendants along with the to-do record"
+//   @param force query boolean false "complete the to-do record even with the open blockers"
+//   @param If-Match header string false "update only if the to-do record has one of these entity tags"
+//   @param X-Actor header string false "actor of the change for the history"
 //   @accept json
 //   @produce json
 //   @success 200 {object} models.PresentationTodoRecord
-//   @failure 400 {string} string
-//   @failure 500 {string} string
+//   @header 200 {string} ETag "to-do record version with the hash of the computed fields"
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 409 {object} models.Problem
+//   @failure 412 {object} models.Problem
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
 func (handler TodoRecord) Patch(
 	writer http.ResponseWriter,
 	request *http.Request,
 ) {
 	id, err := httputils.GetIDFromURL(request)
 	if err != nil {
-		status, message := http.StatusBadRequest, "unable to get an ID: %s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	version, ok := handler.getIfMatchVersion(writer, request, id)
+	if !ok {
+		return
+	}
+
+	cascade, ok := handler.getFlagFormValue(writer, request, "cascade")
+	if !ok {
+		return
+	}
 
+	force, ok := handler.getFlagFormValue(writer, request, "force")
+	if !ok {
 		return
 	}
 
 	var todoPatch models.TodoRecordPatch
 	if err := httputils.ReadJSONData(request.Body, &todoPatch); err != nil {
-		status, message := http.StatusBadRequest, "unable to get the request body: %s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newParameterProblem(
+			"invalid_request_body",
+			"body",
+			"unable to get the request body: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
 
 		return
 	}
 
 	baseURL := handler.getBaseURL(request)
-	presentationTodo, err := handler.UseCase.Patch(baseURL, id, todoPatch)
+	presentationTodo, err := handler.UseCase.Patch(
+		baseURL,
+		id,
+		todoPatch,
+		version,
+		cascade,
+		force,
+		getActor(request),
+	)
 	if err != nil {
-		status, message := http.StatusInternalServerError, "%s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
 
 		return
 	}
 
+	writer.Header().Set("ETag", formatETag(presentationTodo))
 	httputils.HandleJSON(writer, handler.Logger, presentationTodo)
 }
 
 // DeleteAll ...
 //   @router /todos [DELETE]
-//   @summary delete the to-do records
-//   @success 204 {string} string
-//   @failure 500 {string} string
+//   @summary delete the to-do records matching the filters
+//   @param minimal_date query string false "filtration by the minimal date in the RFC 3339 format"
+//   @param maximal_date query string false "filtration by the maximal date in the RFC 3339 format"
+//   @param title_fragment query string false "search by the title fragment"
+//   @param q query string false "full-text search by the title (the web search syntax)"
+//   @param search_notes query boolean false "extend the title_fragment and q parameters to the notes"
+//   @param tags query string false "filtration by any of the comma-separated tags"
+//   @param all_tags query string false "filtration by all of the comma-separated tags"
+//   @param completed query boolean false "filtration by the completion status"
+//   @param blocked query boolean false "filtration by the presence of the open blockers"
+//   @param min_order query integer false "filtration by the minimal order"
+//   @param max_order query integer false "filtration by the maximal order"
+//   @param min_priority query integer false "filtration by the minimal priority (from 0 to 4)"
+//   @param ids query string false "filtration by the comma-separated IDs"
+//   @param created_after query string false "filtration by the creation time at or after in the RFC 3339 format"
+//   @param created_before query string false "filtration by the creation time before in the RFC 3339 format"
+//   @param updated_after query string false "filtration by the update time at or after in the RFC 3339 format"
+//   @param updated_before query string false "filtration by the update time before in the RFC 3339 format"
+//   @param completed_after query string false "filtration by the completion time at or after in the RFC 3339 format"
+//   @param completed_before query string false "filtration by the completion time before in the RFC 3339 format"
+//   @param due_after query string false "filtration by the due time at or after in the RFC 3339 format"
+//   @param due_before query string false "filtration by the due time before in the RFC 3339 format"
+//   @param confirm query string false "confirmation of the deletion without filters, the only allowed value is all"
+//   @param X-Actor header string false "actor of the change for the history"
+//   @produce json
+//   @success 200 {object} models.DeletionResult
+//   @failure 400 {object} models.Problem
+//   @failure 500 {object} models.Problem
 func (handler TodoRecord) DeleteAll(
 	writer http.ResponseWriter,
 	request *http.Request,
 ) {
-	if err := handler.UseCase.DeleteAll(); err != nil {
-		status, message := http.StatusInternalServerError, "%s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+	minimalDate, maximalDate, ok := handler.getDateRange(writer, request)
+	if !ok {
+		return
+	}
 
+	query, ok := handler.getQuery(writer, request)
+	if !ok {
 		return
 	}
 
-	writer.WriteHeader(http.StatusNoContent)
+	query.MinimalDate, query.MaximalDate = minimalDate, maximalDate
+	// the deletion doesn't depend on the highlighting, sorting and pagination
+	query.Highlight, query.Sort, query.Pagination =
+		false, nil, models.Pagination{}
+
+	confirm := request.FormValue("confirm")
+	if confirm != "" && confirm != "all" {
+		problem := newParameterProblem(
+			"invalid_confirm",
+			"confirm",
+			"unable to get the confirm parameter: unknown value %q",
+			confirm,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+	if !query.HasFilters() && confirm != "all" {
+		problem := newParameterProblem(
+			"confirmation_required",
+			"confirm",
+			"the deletion of all the to-do records requires "+
+				"the confirm=all parameter",
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	count, err := handler.UseCase.DeleteAll(query, getActor(request))
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	deletionResult := models.DeletionResult{DeletedCount: count}
+	httputils.HandleJSON(writer, handler.Logger, deletionResult)
 }
 
 // DeleteSingle ...
 //   @router /todos/{id} [DELETE]
 //   @summary delete the to-do record
 //   @param id path integer true "to-do record ID"
+//   @param cascade query boolean false "delete the descendants along with the to-do record"
+//   @param If-Match header string false "delete only if the to-do record has one of these entity tags"
+//   @param X-Actor header string false "actor of the change for the history"
 //   @success 204 {string} string
-//   @failure 400 {string} string
-//   @failure 500 {string} string
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 409 {object} models.Problem
+//   @failure 412 {object} models.Problem
+//   @failure 500 {object} models.Problem
 func (handler TodoRecord) DeleteSingle(
 	writer http.ResponseWriter,
 	request *http.Request,
 ) {
 	id, err := httputils.GetIDFromURL(request)
 	if err != nil {
-		status, message := http.StatusBadRequest, "unable to get an ID: %s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	version, ok := handler.getIfMatchVersion(writer, request, id)
+	if !ok {
+		return
+	}
 
+	cascade, ok := handler.getFlagFormValue(writer, request, "cascade")
+	if !ok {
 		return
 	}
 
-	if err := handler.UseCase.DeleteSingle(id); err != nil {
-		status, message := http.StatusInternalServerError, "%s"
-		httputils.HandleError(writer, handler.Logger, status, message, err)
+	err = handler.UseCase.DeleteSingle(id, version, cascade, getActor(request))
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
 
 		return
 	}
@@ -367,6 +805,1176 @@ func (handler TodoRecord) DeleteSingle(
 	writer.WriteHeader(http.StatusNoContent)
 }
 
-func (handler TodoRecord) getBaseURL(request *http.Request) *url.URL {
-	return &url.URL{Scheme: handler.URLScheme, Host: request.Host}
+// Batch ...
+//   @router /todos/batch [POST]
+//   @summary execute several operations with to-do records in a single transaction
+//   @param body body models.Batch true "batch operations"
+//   @param X-Actor header string false "actor of the change for the history"
+//   @accept json
+//   @produce json
+//   @success 200 {object} models.PresentationBatchResults
+//   @failure 400 {object} models.Problem
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler TodoRecord) Batch(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	var batch models.Batch
+	if err := httputils.ReadJSONData(request.Body, &batch); err != nil {
+		problem := newParameterProblem(
+			"invalid_request_body",
+			"body",
+			"unable to get the request body: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	baseURL := handler.getBaseURL(request)
+	results, err := handler.UseCase.Batch(baseURL, batch, getActor(request))
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	presentationResults := models.PresentationBatchResults{
+		Committed: true,
+		// force the empty array instead of the nil one
+		Results: []models.PresentationBatchResult{},
+	}
+	for _, result := range results {
+		if result.Err != nil && batch.Mode != models.BatchModeBestEffort {
+			presentationResults.Committed = false
+		}
+
+		presentationResults.Results = append(
+			presentationResults.Results,
+			newPresentationBatchResult(request, result),
+		)
+	}
+
+	httputils.HandleJSON(writer, handler.Logger, presentationResults)
+}
+
+// GetTrash ...
+//   @router /trash [GET]
+//   @summary get all trashed to-do records
+//   @param minimal_date query string false "filtration by the minimal date in the RFC 3339 format"
+//   @param maximal_date query string false "filtration by the maximal date in the RFC 3339 format"
+//   @param title_fragment query string false "search by the title fragment"
+//   @param q query string false "full-text search by the title (the web search syntax)"
+//   @param search_notes query boolean false "extend the title_fragment and q parameters to the notes"
+//   @param highlight query boolean false "return the title with the matched words in the highlight field and the matched notes in the notes_highlight one (requires q)"
+//   @param render query string false "return the notes rendered to the sanitized HTML in the notes_html field, the only allowed value is html"
+//   @param tags query string false "filtration by any of the comma-separated tags"
+//   @param all_tags query string false "filtration by all of the comma-separated tags"
+//   @param completed query boolean false "filtration by the completion status"
+//   @param blocked query boolean false "filtration by the presence of the open blockers"
+//   @param min_order query integer false "filtration by the minimal order"
+//   @param max_order query integer false "filtration by the maximal order"
+//   @param min_priority query integer false "filtration by the minimal priority (from 0 to 4)"
+//   @param ids query string false "filtration by the comma-separated IDs"
+//   @param created_after query string false "filtration by the creation time at or after in the RFC 3339 format"
+//   @param created_before query string false "filtration by the creation time before in the RFC 3339 format"
+//   @param updated_after query string false "filtration by the update time at or after in the RFC 3339 format"
+//   @param updated_before query string false "filtration by the update time before in the RFC 3339 format"
+//   @param completed_after query string false "filtration by the completion time at or after in the RFC 3339 format"
+//   @param completed_before query string false "filtration by the completion time before in the RFC 3339 format"
+//   @param due_after query string false "filtration by the due time at or after in the RFC 3339 format"
+//   @param due_before query string false "filtration by the due time before in the RFC 3339 format"
+//   @param sort query string false "comma-separated fields (id, date, title, completed, order, priority, relevance, created_at, updated_at, completed_at) with an optional minus prefix for the descending order; relevance requires q and is approximated by the title length in the SQLite and memory storages"
+//   @param page_size query integer false "specify the page size for pagination" minimum(1)
+//   @param page query integer false "specify the page for pagination" minimum(1)
+//   @param cursor query string false "specify the cursor for pagination (requires page_size and excludes page)"
+//   @produce json
+//   @success 200 {array} models.PresentationTodoRecord "to-do records (the models.PresentationTodoRecordPage object in the cursor mode)"
+//   @header 200 {string} X-Next-Cursor "cursor of the next page in the cursor mode (missed on the last page)"
+//   @header 200 {integer} X-Total-Count "count of the to-do records matching the filters"
+//   @header 200 {string} Link "RFC 8288 links to the first, previous, next and last pages"
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler TodoRecord) GetTrash(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	minimalDate, maximalDate, ok := handler.getDateRange(writer, request)
+	if !ok {
+		return
+	}
+
+	query, ok := handler.getQuery(writer, request)
+	if !ok {
+		return
+	}
+
+	query.MinimalDate, query.MaximalDate = minimalDate, maximalDate
+	query.Deleted = true
+	handler.getAllByQuery(writer, request, query)
+}
+
+// Restore ...
+//   @router /trash/{id}/restore [POST]
+//   @summary restore the trashed to-do record
+//   @param id path integer true "to-do record ID"
+//   @param X-Actor header string false "actor of the change for the history"
+//   @produce json
+//   @success 200 {object} models.PresentationTodoRecord
+//   @header 200 {string} ETag "to-do record version with the hash of the computed fields"
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler TodoRecord) Restore(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	id, err := httputils.GetIDFromURL(request)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	baseURL := handler.getBaseURL(request)
+	presentationTodo, err :=
+		handler.UseCase.Restore(baseURL, id, getActor(request))
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	writer.Header().Set("ETag", formatETag(presentationTodo))
+	httputils.HandleJSON(writer, handler.Logger, presentationTodo)
+}
+
+// PurgeTrash ...
+//   @router /trash [DELETE]
+//   @summary permanently delete all trashed to-do records
+//   @produce json
+//   @success 200 {object} models.DeletionResult
+//   @failure 500 {object} models.Problem
+func (handler TodoRecord) PurgeTrash(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	// the zero retention means all the trashed to-do records
+	count, err := handler.UseCase.PurgeTrash(0)
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	deletionResult := models.DeletionResult{DeletedCount: count}
+	httputils.HandleJSON(writer, handler.Logger, deletionResult)
+}
+
+// AddDependency ...
+//   @router /todos/{id}/blockers [POST]
+//   @summary make the to-do record blocked by another one
+//   @param id path integer true "to-do record ID"
+//   @param body body models.Dependency true "dependency data"
+//   @accept json
+//   @produce json
+//   @success 200 {object} models.PresentationTodoRecord
+//   @header 200 {string} ETag "to-do record version with the hash of the computed fields"
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 409 {object} models.Problem
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler TodoRecord) AddDependency(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	id, err := httputils.GetIDFromURL(request)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	var dependency models.Dependency
+	if err := httputils.ReadJSONData(request.Body, &dependency); err != nil {
+		problem := newParameterProblem(
+			"invalid_request_body",
+			"body",
+			"unable to get the request body: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	baseURL := handler.getBaseURL(request)
+	presentationTodo, err :=
+		handler.UseCase.AddDependency(baseURL, id, dependency)
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	writer.Header().Set("ETag", formatETag(presentationTodo))
+	httputils.HandleJSON(writer, handler.Logger, presentationTodo)
+}
+
+// DeleteDependency ...
+//   @router /todos/{id}/blockers/{blocker_id} [DELETE]
+//   @summary make the to-do record not blocked by another one
+//   @param id path integer true "to-do record ID"
+//   @param blocker_id path integer true "blocker to-do record ID"
+//   @success 204 {string} string
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler TodoRecord) DeleteDependency(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	id, err := httputils.GetIDFromURL(request)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	// the blocker ID is the last path segment, after the to-do record ID
+	blockerID, err := strconv.Atoi(path.Base(request.URL.Path))
+	if err != nil || blockerID < 1 {
+		problem := newParameterProblem(
+			"invalid_blocker_id",
+			"blocker_id",
+			"unable to get the blocker ID: incorrect ID %q",
+			path.Base(request.URL.Path),
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	if err := handler.UseCase.DeleteDependency(id, blockerID); err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// GetHistory ...
+//   @router /history [GET]
+//   @summary get the history of all to-do records
+//   @param since query string false "filtration by the minimal change time in the RFC 3339 format"
+//   @param page_size query integer false "specify the page size for pagination" minimum(1)
+//   @param cursor query string false "specify the cursor for pagination (requires page_size)"
+//   @produce json
+//   @success 200 {array} models.PresentationHistoryRecord "history records (the models.PresentationHistoryRecordPage object with page_size)"
+//   @header 200 {string} X-Next-Cursor "cursor of the next page with page_size (missed on the last page)"
+//   @header 200 {string} Link "RFC 8288 links to the first and next pages with page_size"
+//   @failure 400 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler TodoRecord) GetHistory(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	handler.getHistory(writer, request, 0)
+}
+
+// GetHistoryByID ...
+//   @router /todos/{id}/history [GET]
+//   @summary get the history of the to-do record
+//   @param id path integer true "to-do record ID"
+//   @param since query string false "filtration by the minimal change time in the RFC 3339 format"
+//   @param page_size query integer false "specify the page size for pagination" minimum(1)
+//   @param cursor query string false "specify the cursor for pagination (requires page_size)"
+//   @produce json
+//   @success 200 {array} models.PresentationHistoryRecord "history records (the models.PresentationHistoryRecordPage object with page_size)"
+//   @header 200 {string} X-Next-Cursor "cursor of the next page with page_size (missed on the last page)"
+//   @header 200 {string} Link "RFC 8288 links to the first and next pages with page_size"
+//   @failure 400 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler TodoRecord) GetHistoryByID(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	id, err := httputils.GetIDFromURL(request)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	handler.getHistory(writer, request, id)
+}
+
+// Revert ...
+//   @router /todos/{id}/revert [POST]
+//   @summary revert the to-do record to its state after the revision
+//   @param id path integer true "to-do record ID"
+//   @param revision query integer true "revision from the history of the to-do record" minimum(1)
+//   @param If-Match header string false "revert only if the to-do record has one of these entity tags"
+//   @param X-Actor header string false "actor of the change for the history"
+//   @produce json
+//   @success 200 {object} models.PresentationTodoRecord
+//   @header 200 {string} ETag "to-do record version with the hash of the computed fields"
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 409 {object} models.Problem
+//   @failure 412 {object} models.Problem
+//   @failure 422 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler TodoRecord) Revert(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	id, err := httputils.GetIDFromURL(request)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	revision, err :=
+		httputils.GetIntFormValue(request, "revision", 1, math.MaxInt32)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_revision",
+			"revision",
+			"unable to get the revision parameter: %v",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	version, ok := handler.getIfMatchVersion(writer, request, id)
+	if !ok {
+		return
+	}
+
+	baseURL := handler.getBaseURL(request)
+	presentationTodo, err := handler.UseCase.Revert(
+		baseURL,
+		id,
+		revision,
+		version,
+		getActor(request),
+	)
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	writer.Header().Set("ETag", formatETag(presentationTodo))
+	httputils.HandleJSON(writer, handler.Logger, presentationTodo)
+}
+
+// GetOccurrences ...
+//   @router /todos/{id}/occurrences [GET]
+//   @summary expand the occurrences of the recurring to-do record without saving them
+//   @param id path integer true "to-do record ID"
+//   @param minimal_date query string true "minimal date of the occurrences in the RFC 3339 format"
+//   @param maximal_date query string true "maximal date of the occurrences in the RFC 3339 format"
+//   @param render query string false "return the notes rendered to the sanitized HTML in the notes_html field, the only allowed value is html"
+//   @produce json
+//   @success 200 {array} models.PresentationTodoRecord
+//   @failure 400 {object} models.Problem
+//   @failure 404 {object} models.Problem
+//   @failure 500 {object} models.Problem
+func (handler TodoRecord) GetOccurrences(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	id, err := httputils.GetIDFromURL(request)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_id",
+			"id",
+			"unable to get an ID: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	minimalDate, maximalDate, ok := handler.getDateRange(writer, request)
+	if !ok {
+		return
+	}
+	for _, parameter := range []struct {
+		name  string
+		value utilmodels.Date
+	}{
+		{name: "minimal_date", value: minimalDate},
+		{name: "maximal_date", value: maximalDate},
+	} {
+		if parameter.value == (utilmodels.Date{}) {
+			problem := newParameterProblem(
+				"invalid_date",
+				parameter.name,
+				"unable to get the %s parameter: %v",
+				parameter.name,
+				httputils.ErrKeyIsMissed,
+			)
+			handleError(writer, request, handler.Logger, problem)
+
+			return
+		}
+	}
+
+	render, ok := handler.getRenderFormValue(writer, request)
+	if !ok {
+		return
+	}
+
+	baseURL := handler.getBaseURL(request)
+	presentationTodos, err := handler.UseCase.GetOccurrences(
+		baseURL,
+		id,
+		time.Time(minimalDate),
+		time.Time(maximalDate),
+	)
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+	if render {
+		renderNotes(presentationTodos)
+	}
+
+	httputils.HandleJSON(writer, handler.Logger, presentationTodos)
+}
+
+func (handler TodoRecord) getAll(
+	writer http.ResponseWriter,
+	request *http.Request,
+	listID int,
+	parentID int,
+) {
+	minimalDate, maximalDate, ok := handler.getDateRange(writer, request)
+	if !ok {
+		return
+	}
+
+	query, ok := handler.getQuery(writer, request)
+	if !ok {
+		return
+	}
+
+	query.MinimalDate, query.MaximalDate = minimalDate, maximalDate
+	query.ListID, query.ParentID = listID, parentID
+	handler.getAllByQuery(writer, request, query)
+}
+
+// getHistory returns the history of all the to-do records for the zero ID.
+//
+// The history has only the cursor pagination, because it's append-only.
+func (handler TodoRecord) getHistory(
+	writer http.ResponseWriter,
+	request *http.Request,
+	id int,
+) {
+	query := models.HistoryQuery{TodoRecordID: id}
+	if rawSince := request.FormValue("since"); rawSince != "" {
+		since, err := time.Parse(time.RFC3339, rawSince)
+		if err != nil {
+			problem := newParameterProblem(
+				"invalid_since",
+				"since",
+				"unable to get the since parameter: %v",
+				err,
+			)
+			handleError(writer, request, handler.Logger, problem)
+
+			return
+		}
+
+		query.Since = since
+	}
+
+	pageSize, err :=
+		httputils.GetIntFormValue(request, "page_size", 1, math.MaxInt32)
+	if err != nil && err != httputils.ErrKeyIsMissed {
+		problem := newParameterProblem(
+			"invalid_page_size",
+			"page_size",
+			"unable to get the page_size parameter: %v",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	query.PageSize = pageSize
+	if rawCursor := request.FormValue("cursor"); rawCursor != "" {
+		cursor, err := models.ParseHistoryCursor(rawCursor)
+		if err != nil {
+			problem := newParameterProblem(
+				"invalid_cursor",
+				"cursor",
+				"unable to get the cursor parameter: %v",
+				err,
+			)
+			handleError(writer, request, handler.Logger, problem)
+
+			return
+		}
+		if pageSize == 0 {
+			problem := newParameterProblem(
+				"invalid_cursor",
+				"cursor",
+				"the cursor parameter requires the page_size one",
+			)
+			handleError(writer, request, handler.Logger, problem)
+
+			return
+		}
+
+		query.Cursor = cursor
+	}
+
+	baseURL := handler.getBaseURL(request)
+	presentationRecords, nextCursor, err :=
+		handler.UseCase.GetHistory(baseURL, query)
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+
+	// the array is kept without the page size for the backward compatibility
+	if query.PageSize != 0 {
+		requestURL := handler.getBaseURL(request)
+		requestURL.Path, requestURL.RawQuery =
+			request.URL.Path, request.URL.RawQuery
+		setHistoryPaginationHeaders(writer.Header(), requestURL, nextCursor)
+
+		page := models.PresentationHistoryRecordPage{
+			History:    presentationRecords,
+			NextCursor: nextCursor,
+		}
+		httputils.HandleJSON(writer, handler.Logger, page)
+
+		return
+	}
+
+	httputils.HandleJSON(writer, handler.Logger, presentationRecords)
+}
+
+// getDateRange returns the zero dates for the missed parameters.
+func (handler TodoRecord) getDateRange(
+	writer http.ResponseWriter,
+	request *http.Request,
+) (utilmodels.Date, utilmodels.Date, bool) {
+	minimalDate, err := httputils.GetDateFormValue(request, "minimal_date")
+	if err != nil && err != httputils.ErrKeyIsMissed {
+		problem := newParameterProblem(
+			"invalid_date",
+			"minimal_date",
+			"unable to get the minimal_date parameter: %v",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return utilmodels.Date{}, utilmodels.Date{}, false
+	}
+
+	maximalDate, err := httputils.GetDateFormValue(request, "maximal_date")
+	if err != nil && err != httputils.ErrKeyIsMissed {
+		problem := newParameterProblem(
+			"invalid_date",
+			"maximal_date",
+			"unable to get the maximal_date parameter: %v",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return utilmodels.Date{}, utilmodels.Date{}, false
+	}
+
+	return minimalDate, maximalDate, true
+}
+
+// getQuery parses the parameters common for all the collection endpoints.
+func (handler TodoRecord) getQuery(
+	writer http.ResponseWriter,
+	request *http.Request,
+) (models.Query, bool) {
+	var completed *bool
+	if rawCompleted := request.FormValue("completed"); rawCompleted != "" {
+		value, err := strconv.ParseBool(rawCompleted)
+		if err != nil {
+			problem := newParameterProblem(
+				"invalid_completed",
+				"completed",
+				"unable to get the completed parameter: %v",
+				err,
+			)
+			handleError(writer, request, handler.Logger, problem)
+
+			return models.Query{}, false
+		}
+
+		completed = &value
+	}
+
+	var blocked *bool
+	if rawBlocked := request.FormValue("blocked"); rawBlocked != "" {
+		value, err := strconv.ParseBool(rawBlocked)
+		if err != nil {
+			problem := newParameterProblem(
+				"invalid_blocked",
+				"blocked",
+				"unable to get the blocked parameter: %v",
+				err,
+			)
+			handleError(writer, request, handler.Logger, problem)
+
+			return models.Query{}, false
+		}
+
+		blocked = &value
+	}
+
+	minimalOrder, ok := handler.getOrderFormValue(writer, request, "min_order")
+	if !ok {
+		return models.Query{}, false
+	}
+
+	maximalOrder, ok := handler.getOrderFormValue(writer, request, "max_order")
+	if !ok {
+		return models.Query{}, false
+	}
+
+	var minimalPriority *int
+	priority, err := httputils.GetIntFormValue(
+		request,
+		"min_priority",
+		models.PriorityNone,
+		models.PriorityUrgent,
+	)
+	if err != httputils.ErrKeyIsMissed {
+		if err != nil {
+			problem := newParameterProblem(
+				"invalid_priority",
+				"min_priority",
+				"unable to get the min_priority parameter: %v",
+				err,
+			)
+			handleError(writer, request, handler.Logger, problem)
+
+			return models.Query{}, false
+		}
+
+		minimalPriority = &priority
+	}
+
+	var ids []int
+	for _, rawID := range getListFormValue(request, "ids") {
+		id, err := strconv.Atoi(rawID)
+		if err != nil || id < 1 {
+			problem := newParameterProblem(
+				"invalid_ids",
+				"ids",
+				"unable to get the ids parameter: incorrect ID %q",
+				rawID,
+			)
+			handleError(writer, request, handler.Logger, problem)
+
+			return models.Query{}, false
+		}
+
+		ids = append(ids, id)
+	}
+
+	var createdAfter, createdBefore time.Time
+	var updatedAfter, updatedBefore time.Time
+	var completedAfter, completedBefore time.Time
+	var dueAfter, dueBefore time.Time
+	timestampParameters := []struct {
+		key       string
+		timestamp *time.Time
+	}{
+		{"created_after", &createdAfter},
+		{"created_before", &createdBefore},
+		{"updated_after", &updatedAfter},
+		{"updated_before", &updatedBefore},
+		{"completed_after", &completedAfter},
+		{"completed_before", &completedBefore},
+		{"due_after", &dueAfter},
+		{"due_before", &dueBefore},
+	}
+	for _, parameter := range timestampParameters {
+		timestamp, ok :=
+			handler.getTimestampFormValue(writer, request, parameter.key)
+		if !ok {
+			return models.Query{}, false
+		}
+
+		*parameter.timestamp = timestamp
+	}
+
+	var highlight bool
+	if rawHighlight := request.FormValue("highlight"); rawHighlight != "" {
+		var err error
+		highlight, err = strconv.ParseBool(rawHighlight)
+		if err != nil {
+			problem := newParameterProblem(
+				"invalid_highlight",
+				"highlight",
+				"unable to get the highlight parameter: %v",
+				err,
+			)
+			handleError(writer, request, handler.Logger, problem)
+
+			return models.Query{}, false
+		}
+	}
+
+	searchNotes, ok := handler.getFlagFormValue(writer, request, "search_notes")
+	if !ok {
+		return models.Query{}, false
+	}
+
+	pagination, ok := handler.getPagination(writer, request)
+	if !ok {
+		return models.Query{}, false
+	}
+
+	search := strings.TrimSpace(request.FormValue("q"))
+	sort, ok := handler.getSort(writer, request, pagination, search)
+	if !ok {
+		return models.Query{}, false
+	}
+
+	query := models.Query{
+		TitleFragment:   request.FormValue("title_fragment"),
+		Search:          search,
+		SearchNotes:     searchNotes,
+		Highlight:       highlight,
+		Tags:            getListFormValue(request, "tags"),
+		AllTags:         getListFormValue(request, "all_tags"),
+		Completed:       completed,
+		Blocked:         blocked,
+		MinimalOrder:    minimalOrder,
+		MaximalOrder:    maximalOrder,
+		MinimalPriority: minimalPriority,
+		IDs:             ids,
+		CreatedAfter:    createdAfter,
+		CreatedBefore:   createdBefore,
+		UpdatedAfter:    updatedAfter,
+		UpdatedBefore:   updatedBefore,
+		CompletedAfter:  completedAfter,
+		CompletedBefore: completedBefore,
+		DueAfter:        dueAfter,
+		DueBefore:       dueBefore,
+		Sort:            sort,
+		Pagination:      pagination,
+	}
+
+	return query, true
+}
+
+// getTimestampFormValue returns the zero time if the parameter is missed.
+func (handler TodoRecord) getTimestampFormValue(
+	writer http.ResponseWriter,
+	request *http.Request,
+	key string,
+) (time.Time, bool) {
+	rawTimestamp := request.FormValue(key)
+	if rawTimestamp == "" {
+		return time.Time{}, true
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, rawTimestamp)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_timestamp",
+			key,
+			"unable to get the %s parameter: %v",
+			key,
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return time.Time{}, false
+	}
+
+	return timestamp, true
+}
+
+// getOrderFormValue returns nil if the parameter is missed.
+func (handler TodoRecord) getOrderFormValue(
+	writer http.ResponseWriter,
+	request *http.Request,
+	key string,
+) (*int, bool) {
+	order, err :=
+		httputils.GetIntFormValue(request, key, math.MinInt32, math.MaxInt32)
+	if err == httputils.ErrKeyIsMissed {
+		return nil, true
+	}
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_order",
+			key,
+			"unable to get the %s parameter: %v",
+			key,
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return nil, false
+	}
+
+	return &order, true
+}
+
+func (handler TodoRecord) handleConditionalGet(
+	writer http.ResponseWriter,
+	request *http.Request,
+	presentationTodo models.PresentationTodoRecord,
+) {
+	etag := formatETag(presentationTodo)
+	writer.Header().Set("ETag", etag)
+	if matchIfNoneMatch(request, etag) {
+		writer.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	httputils.HandleJSON(writer, handler.Logger, presentationTodo)
+}
+
+// getIfMatchVersion returns the zero version if the If-Match header
+// is missed or equals to "*", and the unmatched one if none of its entity tags
+// match the current one of the to-do record; otherwise, it returns the current
+// version, so the use cases still check that nothing has changed since then.
+func (handler TodoRecord) getIfMatchVersion(
+	writer http.ResponseWriter,
+	request *http.Request,
+	id int,
+) (int, bool) {
+	etags, err := getIfMatchETags(request)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_if_match",
+			"If-Match",
+			"unable to get the If-Match header: %s",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return 0, false
+	}
+	if etags == nil {
+		return 0, true
+	}
+	if len(etags) == 0 {
+		return unmatchedVersion, true
+	}
+
+	presentationTodo, err :=
+		handler.UseCase.GetSingle(handler.getBaseURL(request), id)
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return 0, false
+	}
+
+	currentETag := formatETag(presentationTodo)
+	for _, etag := range etags {
+		if etag == currentETag {
+			return presentationTodo.Version, true
+		}
+	}
+
+	return unmatchedVersion, true
+}
+
+// getFlagFormValue returns false if the parameter is missed.
+func (handler TodoRecord) getFlagFormValue(
+	writer http.ResponseWriter,
+	request *http.Request,
+	key string,
+) (bool, bool) {
+	rawFlag := request.FormValue(key)
+	if rawFlag == "" {
+		return false, true
+	}
+
+	flag, err := strconv.ParseBool(rawFlag)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_"+key,
+			key,
+			"unable to get the %s parameter: %v",
+			key,
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return false, false
+	}
+
+	return flag, true
+}
+
+// getRenderFormValue returns false if the parameter is missed.
+func (handler TodoRecord) getRenderFormValue(
+	writer http.ResponseWriter,
+	request *http.Request,
+) (bool, bool) {
+	render := request.FormValue("render")
+	if render != "" && render != "html" {
+		problem := newParameterProblem(
+			"invalid_render",
+			"render",
+			"unable to get the render parameter: unknown value %q",
+			render,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return false, false
+	}
+
+	return render == "html", true
+}
+
+func (handler TodoRecord) getAllByQuery(
+	writer http.ResponseWriter,
+	request *http.Request,
+	query models.Query,
+) {
+	render, ok := handler.getRenderFormValue(writer, request)
+	if !ok {
+		return
+	}
+
+	baseURL := handler.getBaseURL(request)
+	presentationTodos, pageInfo, err := handler.UseCase.GetAll(baseURL, query)
+	if err != nil {
+		problem := newUseCaseProblem(err)
+		handleError(writer, request, handler.Logger, problem)
+
+		return
+	}
+	if render {
+		renderNotes(presentationTodos)
+	}
+
+	requestURL := handler.getBaseURL(request)
+	requestURL.Path, requestURL.RawQuery =
+		request.URL.Path, request.URL.RawQuery
+	setPaginationHeaders(writer.Header(), requestURL, query.Pagination, pageInfo)
+
+	// the array is kept in the other modes for the backward compatibility
+	if query.Pagination.IsCursorMode() {
+		page := models.PresentationTodoRecordPage{
+			Todos:      presentationTodos,
+			NextCursor: pageInfo.NextCursor,
+		}
+		httputils.HandleJSON(writer, handler.Logger, page)
+
+		return
+	}
+
+	httputils.HandleJSON(writer, handler.Logger, presentationTodos)
+}
+
+func (handler TodoRecord) getPagination(
+	writer http.ResponseWriter,
+	request *http.Request,
+) (models.Pagination, bool) {
+	pageSize, err :=
+		httputils.GetIntFormValue(request, "page_size", 1, math.MaxInt32)
+	if err != nil && err != httputils.ErrKeyIsMissed {
+		problem := newParameterProblem(
+			"invalid_page_size",
+			"page_size",
+			"unable to get the page_size parameter: %v",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return models.Pagination{}, false
+	}
+
+	page, err := httputils.GetIntFormValue(request, "page", 1, math.MaxInt32)
+	if err != nil && err != httputils.ErrKeyIsMissed {
+		problem := newParameterProblem(
+			"invalid_page",
+			"page",
+			"unable to get the page parameter: %v",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return models.Pagination{}, false
+	}
+
+	rawCursor := request.FormValue("cursor")
+	if rawCursor == "" {
+		return models.Pagination{PageSize: pageSize, Page: page}, true
+	}
+
+	cursor, err := models.ParseCursor(rawCursor)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_cursor",
+			"cursor",
+			"unable to get the cursor parameter: %v",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return models.Pagination{}, false
+	}
+	if page != 0 {
+		problem := newParameterProblem(
+			"invalid_cursor",
+			"cursor",
+			"the cursor parameter can't be used with the page one",
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return models.Pagination{}, false
+	}
+	if pageSize == 0 {
+		problem := newParameterProblem(
+			"invalid_cursor",
+			"cursor",
+			"the cursor parameter requires the page_size one",
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return models.Pagination{}, false
+	}
+
+	return models.Pagination{PageSize: pageSize, Cursor: cursor}, true
+}
+
+func (handler TodoRecord) getSort(
+	writer http.ResponseWriter,
+	request *http.Request,
+	pagination models.Pagination,
+	search string,
+) ([]models.SortField, bool) {
+	rawSort := request.FormValue("sort")
+	if rawSort == "" {
+		return nil, true
+	}
+
+	sort, err := models.ParseSort(rawSort)
+	if err != nil {
+		problem := newParameterProblem(
+			"invalid_sort",
+			"sort",
+			"unable to get the sort parameter: %v",
+			err,
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return nil, false
+	}
+	if pagination.IsCursorMode() {
+		problem := newParameterProblem(
+			"invalid_sort",
+			"sort",
+			"the sort parameter can't be used in the cursor mode",
+		)
+		handleError(writer, request, handler.Logger, problem)
+
+		return nil, false
+	}
+	for _, field := range sort {
+		if field.Name == models.SortByRelevance && search == "" {
+			problem := newParameterProblem(
+				"invalid_sort",
+				"sort",
+				"the relevance sort requires the q parameter",
+			)
+			handleError(writer, request, handler.Logger, problem)
+
+			return nil, false
+		}
+	}
+
+	return sort, true
+}
+
+func renderNotes(presentationTodos []models.PresentationTodoRecord) {
+	for index := range presentationTodos {
+		presentationTodos[index].RenderNotes()
+	}
+}
+
+func getListFormValue(request *http.Request, key string) []string {
+	var values []string
+	for _, value := range strings.Split(request.FormValue(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func (handler TodoRecord) getBaseURL(request *http.Request) *url.URL {
+	return &url.URL{Scheme: handler.URLScheme, Host: request.Host}
+}
+
+// getActor returns the empty string for the missed header,
+// because the authentication is out of the scope of this service.
+func getActor(request *http.Request) string {
+	return request.Header.Get("X-Actor")
 }
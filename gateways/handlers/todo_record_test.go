@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -15,6 +16,7 @@ import (
 	utilmodels "github.com/irenicaa/go-http-utils/models"
 	"github.com/irenicaa/go-todo-backend/v2/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTodoRecord_GetAll(t *testing.T) {
@@ -44,7 +46,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
 						On("GetAll", baseURL, models.Query{}).
-						Return(presentationTodos, nil)
+						Return(presentationTodos, models.PageInfo{TotalCount: 0}, nil)
 
 					return useCase
 				}(),
@@ -60,11 +62,14 @@ func TestTodoRecord_GetAll(t *testing.T) {
 			wantResponse: &http.Response{
 				Status: strconv.Itoa(http.StatusOK) + " " +
 					http.StatusText(http.StatusOK),
-				StatusCode:    http.StatusOK,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{"Content-Type": {"application/json"}},
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"0"},
+				},
 				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
 				ContentLength: -1,
 			},
@@ -86,6 +91,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 							Title:     "test",
 							Completed: true,
 							Order:     12,
+							Tags:      []string{},
 						},
 						{
 							URL: "http://example.com/api/v1/todos/23",
@@ -97,13 +103,14 @@ func TestTodoRecord_GetAll(t *testing.T) {
 							Title:     "test2",
 							Completed: false,
 							Order:     42,
+							Tags:      []string{},
 						},
 					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
 						On("GetAll", baseURL, models.Query{}).
-						Return(presentationTodos, nil)
+						Return(presentationTodos, models.PageInfo{TotalCount: 2}, nil)
 
 					return useCase
 				}(),
@@ -123,18 +130,23 @@ func TestTodoRecord_GetAll(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"2"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`[{"url":"http://example.com/api/v1/todos/5",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":12},` +
+						`"order":12,` +
+						`"tags":[]},` +
 						`{"url":"http://example.com/api/v1/todos/23",` +
 						`"date":"2006-01-03",` +
 						`"title":"test2",` +
 						`"completed":false,` +
-						`"order":42}]`,
+						`"order":42,` +
+						`"tags":[]}]`,
 				))),
 				ContentLength: -1,
 			},
@@ -156,6 +168,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 							Title:     "test",
 							Completed: true,
 							Order:     12,
+							Tags:      []string{},
 						},
 						{
 							URL: "http://example.com/api/v1/todos/23",
@@ -167,6 +180,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 							Title:     "test2",
 							Completed: false,
 							Order:     42,
+							Tags:      []string{},
 						},
 					}
 
@@ -179,7 +193,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 								time.UTC,
 							)),
 						}).
-						Return(presentationTodos, nil)
+						Return(presentationTodos, models.PageInfo{TotalCount: 2}, nil)
 
 					return useCase
 				}(),
@@ -199,18 +213,23 @@ func TestTodoRecord_GetAll(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"2"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`[{"url":"http://example.com/api/v1/todos/5",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":12},` +
+						`"order":12,` +
+						`"tags":[]},` +
 						`{"url":"http://example.com/api/v1/todos/23",` +
 						`"date":"2006-01-03",` +
 						`"title":"test2",` +
 						`"completed":false,` +
-						`"order":42}]`,
+						`"order":42,` +
+						`"tags":[]}]`,
 				))),
 				ContentLength: -1,
 			},
@@ -232,6 +251,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 							Title:     "test",
 							Completed: true,
 							Order:     12,
+							Tags:      []string{},
 						},
 						{
 							URL: "http://example.com/api/v1/todos/23",
@@ -243,6 +263,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 							Title:     "test2",
 							Completed: false,
 							Order:     42,
+							Tags:      []string{},
 						},
 					}
 
@@ -255,7 +276,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 								time.UTC,
 							)),
 						}).
-						Return(presentationTodos, nil)
+						Return(presentationTodos, models.PageInfo{TotalCount: 2}, nil)
 
 					return useCase
 				}(),
@@ -275,18 +296,23 @@ func TestTodoRecord_GetAll(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"2"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`[{"url":"http://example.com/api/v1/todos/5",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":12},` +
+						`"order":12,` +
+						`"tags":[]},` +
 						`{"url":"http://example.com/api/v1/todos/23",` +
 						`"date":"2006-01-03",` +
 						`"title":"test2",` +
 						`"completed":false,` +
-						`"order":42}]`,
+						`"order":42,` +
+						`"tags":[]}]`,
 				))),
 				ContentLength: -1,
 			},
@@ -308,6 +334,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 							Title:     "test",
 							Completed: true,
 							Order:     12,
+							Tags:      []string{},
 						},
 						{
 							URL: "http://example.com/api/v1/todos/23",
@@ -319,13 +346,14 @@ func TestTodoRecord_GetAll(t *testing.T) {
 							Title:     "test2",
 							Completed: false,
 							Order:     42,
+							Tags:      []string{},
 						},
 					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
 						On("GetAll", baseURL, models.Query{TitleFragment: "test"}).
-						Return(presentationTodos, nil)
+						Return(presentationTodos, models.PageInfo{TotalCount: 2}, nil)
 
 					return useCase
 				}(),
@@ -345,24 +373,29 @@ func TestTodoRecord_GetAll(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"2"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`[{"url":"http://example.com/api/v1/todos/5",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":12},` +
+						`"order":12,` +
+						`"tags":[]},` +
 						`{"url":"http://example.com/api/v1/todos/23",` +
 						`"date":"2006-01-03",` +
 						`"title":"test2",` +
 						`"completed":false,` +
-						`"order":42}]`,
+						`"order":42,` +
+						`"tags":[]}]`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "success with the pagination",
+			name: "success with the tags",
 			fields: fields{
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
@@ -378,6 +411,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 							Title:     "test",
 							Completed: true,
 							Order:     12,
+							Tags:      []string{},
 						},
 						{
 							URL: "http://example.com/api/v1/todos/23",
@@ -389,15 +423,19 @@ func TestTodoRecord_GetAll(t *testing.T) {
 							Title:     "test2",
 							Completed: false,
 							Order:     42,
+							Tags:      []string{},
 						},
 					}
 
+					query := models.Query{
+						Tags:    []string{"one", "two"},
+						AllTags: []string{"three"},
+					}
+
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
-						On("GetAll", baseURL, models.Query{
-							Pagination: models.Pagination{PageSize: 23, Page: 42},
-						}).
-						Return(presentationTodos, nil)
+						On("GetAll", baseURL, query).
+						Return(presentationTodos, models.PageInfo{TotalCount: 2}, nil)
 
 					return useCase
 				}(),
@@ -406,7 +444,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos?page_size=23&page=42",
+					"http://example.com/api/v1/todos?tags=one,+two,&all_tags=three",
 					nil,
 				),
 			},
@@ -417,269 +455,307 @@ func TestTodoRecord_GetAll(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"2"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`[{"url":"http://example.com/api/v1/todos/5",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":12},` +
+						`"order":12,` +
+						`"tags":[]},` +
 						`{"url":"http://example.com/api/v1/todos/23",` +
 						`"date":"2006-01-03",` +
 						`"title":"test2",` +
 						`"completed":false,` +
-						`"order":42}]`,
+						`"order":42,` +
+						`"tags":[]}]`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error with the minimal_date parameter",
+			name: "success with the completion status, the order range and IDs",
 			fields: fields{
 				URLScheme: "http",
-				UseCase:   &MockTodoRecordUseCase{},
-				Logger: func() httputils.Logger {
-					message := "unable to get the minimal_date parameter: " +
-						"unable to parse the date: " +
-						"parsing time \"incorrect\" as \"2006-01-02\": " +
-						"cannot parse \"incorrect\" as \"2006\""
-					logger := &MockLogger{}
-					logger.InnerMock.
-						On("Print", []interface{}{message}).
-						Return().
-						Times(1)
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{}
 
-					return logger
+					completed, minimalOrder, maximalOrder := false, -5, 23
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							Completed:    &completed,
+							MinimalOrder: &minimalOrder,
+							MaximalOrder: &maximalOrder,
+							IDs:          []int{5, 12, 42},
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 0}, nil)
+
+					return useCase
 				}(),
+				Logger: &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos?minimal_date=incorrect",
+					"http://example.com/api/v1/todos"+
+						"?completed=false&min_order=-5&max_order=23&ids=5,12,42",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusBadRequest) + " " +
-					http.StatusText(http.StatusBadRequest),
-				StatusCode: http.StatusBadRequest,
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
-				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get the minimal_date parameter: " +
-						"unable to parse the date: " +
-						"parsing time \"incorrect\" as \"2006-01-02\": " +
-						"cannot parse \"incorrect\" as \"2006\"",
-				))),
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"0"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error with the maximal_date parameter",
+			name: "success with the minimal priority and the sort",
 			fields: fields{
 				URLScheme: "http",
-				UseCase:   &MockTodoRecordUseCase{},
-				Logger: func() httputils.Logger {
-					message := "unable to get the maximal_date parameter: " +
-						"unable to parse the date: " +
-						"parsing time \"incorrect\" as \"2006-01-02\": " +
-						"cannot parse \"incorrect\" as \"2006\""
-					logger := &MockLogger{}
-					logger.InnerMock.
-						On("Print", []interface{}{message}).
-						Return().
-						Times(1)
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{}
 
-					return logger
+					minimalPriority := models.PriorityHigh
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							MinimalPriority: &minimalPriority,
+							Sort: []models.SortField{
+								{Name: models.SortByPriority, Descending: true},
+								{Name: models.SortByTitle},
+							},
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 0}, nil)
+
+					return useCase
 				}(),
+				Logger: &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos?maximal_date=incorrect",
+					"http://example.com/api/v1/todos"+
+						"?min_priority=3&sort=-priority,title",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusBadRequest) + " " +
-					http.StatusText(http.StatusBadRequest),
-				StatusCode: http.StatusBadRequest,
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
-				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get the maximal_date parameter: " +
-						"unable to parse the date: " +
-						"parsing time \"incorrect\" as \"2006-01-02\": " +
-						"cannot parse \"incorrect\" as \"2006\"",
-				))),
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"0"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error with the page_size parameter",
+			name: "success with the timestamps",
 			fields: fields{
 				URLScheme: "http",
-				UseCase:   &MockTodoRecordUseCase{},
-				Logger: func() httputils.Logger {
-					message := "unable to get the page_size parameter: " +
-						"value is incorrect: " +
-						"strconv.Atoi: parsing \"incorrect\": invalid syntax"
-					logger := &MockLogger{}
-					logger.InnerMock.
-						On("Print", []interface{}{message}).
-						Return().
-						Times(1)
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{}
 
-					return logger
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							CreatedAfter: time.Date(
+								2006, time.January, 2,
+								15, 4, 5, 0,
+								time.UTC,
+							),
+							CompletedBefore: time.Date(
+								2006, time.January, 3,
+								15, 4, 5, 0,
+								time.UTC,
+							),
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 0}, nil)
+
+					return useCase
 				}(),
+				Logger: &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos?page_size=incorrect",
+					"http://example.com/api/v1/todos"+
+						"?created_after=2006-01-02T15:04:05Z"+
+						"&completed_before=2006-01-03T15:04:05Z",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusBadRequest) + " " +
-					http.StatusText(http.StatusBadRequest),
-				StatusCode: http.StatusBadRequest,
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
-				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get the page_size parameter: " +
-						"value is incorrect: " +
-						"strconv.Atoi: parsing \"incorrect\": invalid syntax",
-				))),
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"0"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error with the page parameter",
+			name: "success with the search",
 			fields: fields{
 				URLScheme: "http",
-				UseCase:   &MockTodoRecordUseCase{},
-				Logger: func() httputils.Logger {
-					message := "unable to get the page parameter: " +
-						"value is incorrect: " +
-						"strconv.Atoi: parsing \"incorrect\": invalid syntax"
-					logger := &MockLogger{}
-					logger.InnerMock.
-						On("Print", []interface{}{message}).
-						Return().
-						Times(1)
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{
+						{
+							URL: "http://example.com/api/v1/todos/5",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title:     "buy milk",
+							Order:     12,
+							Tags:      []string{},
+							Highlight: "buy <mark>milk</mark>",
+						},
+					}
 
-					return logger
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							Search:    "milk",
+							Highlight: true,
+							Sort: []models.SortField{
+								{Name: models.SortByRelevance, Descending: true},
+							},
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 1}, nil)
+
+					return useCase
 				}(),
+				Logger: &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos?page=incorrect",
+					"http://example.com/api/v1/todos"+
+						"?q=milk&highlight=true&sort=-relevance",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusBadRequest) + " " +
-					http.StatusText(http.StatusBadRequest),
-				StatusCode: http.StatusBadRequest,
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"1"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get the page parameter: " +
-						"value is incorrect: " +
-						"strconv.Atoi: parsing \"incorrect\": invalid syntax",
+					`[{"url":"http://example.com/api/v1/todos/5",` +
+						`"date":"2006-01-02",` +
+						`"title":"buy milk",` +
+						`"completed":false,` +
+						`"order":12,` +
+						`"tags":[],` +
+						`"highlight":"buy \u003cmark\u003emilk\u003c/mark\u003e"}]`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error with the use case",
+			name: "success with the notes search and rendering",
 			fields: fields{
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
 					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{
+						{
+							URL: "http://example.com/api/v1/todos/5",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title: "go shopping",
+							Notes: "buy **milk**",
+							Order: 12,
+							Tags:  []string{},
+						},
+					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
-						On("GetAll", baseURL, models.Query{}).
-						Return([]models.PresentationTodoRecord(nil), iotest.ErrTimeout)
+						On("GetAll", baseURL, models.Query{
+							Search:      "milk",
+							SearchNotes: true,
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 1}, nil)
 
 					return useCase
 				}(),
-				Logger: func() httputils.Logger {
-					logger := &MockLogger{}
-					logger.InnerMock.
-						On("Print", []interface{}{"timeout"}).
-						Return().
-						Times(1)
-
-					return logger
-				}(),
+				Logger: &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos",
+					"http://example.com/api/v1/todos"+
+						"?q=milk&search_notes=true&render=html",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
-					http.StatusText(http.StatusInternalServerError),
-				StatusCode:    http.StatusInternalServerError,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{},
-				Body:          ioutil.NopCloser(bytes.NewReader([]byte("timeout"))),
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"1"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"url":"http://example.com/api/v1/todos/5",` +
+						`"date":"2006-01-02",` +
+						`"title":"go shopping",` +
+						`"notes":"buy **milk**",` +
+						`"completed":false,` +
+						`"order":12,` +
+						`"tags":[],` +
+						`"notes_html":"\u003cp\u003ebuy ` +
+						`\u003cstrong\u003emilk\u003c/strong\u003e\u003c/p\u003e\n"}]`,
+				))),
 				ContentLength: -1,
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			responseRecorder := httptest.NewRecorder()
-			handler := TodoRecord{
-				URLScheme: tt.fields.URLScheme,
-				UseCase:   tt.fields.UseCase,
-				Logger:    tt.fields.Logger,
-			}
-			handler.GetAll(responseRecorder, tt.args.request)
-
-			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
-			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
-			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
-		})
-	}
-}
-
-func TestTodoRecord_GetAllByDate(t *testing.T) {
-	type fields struct {
-		URLScheme string
-		UseCase   TodoRecordUseCase
-		Logger    httputils.Logger
-	}
-	type args struct {
-		request *http.Request
-	}
-
-	tests := []struct {
-		name         string
-		fields       fields
-		args         args
-		wantResponse *http.Response
-	}{
 		{
-			name: "success without to-do records",
+			name: "success with the sort",
 			fields: fields{
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
@@ -689,18 +765,12 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
 						On("GetAll", baseURL, models.Query{
-							MinimalDate: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
-							MaximalDate: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
+							Sort: []models.SortField{
+								{Name: models.SortByCompleted},
+								{Name: models.SortByDate, Descending: true},
+							},
 						}).
-						Return(presentationTodos, nil)
+						Return(presentationTodos, models.PageInfo{TotalCount: 0}, nil)
 
 					return useCase
 				}(),
@@ -709,24 +779,27 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos/2006-01-02",
+					"http://example.com/api/v1/todos?sort=completed,-date",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
 				Status: strconv.Itoa(http.StatusOK) + " " +
 					http.StatusText(http.StatusOK),
-				StatusCode:    http.StatusOK,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{"Content-Type": {"application/json"}},
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"0"},
+				},
 				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "success with to-do records",
+			name: "success with the pagination",
 			fields: fields{
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
@@ -742,35 +815,28 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 							Title:     "test",
 							Completed: true,
 							Order:     12,
+							Tags:      []string{},
 						},
 						{
 							URL: "http://example.com/api/v1/todos/23",
 							Date: utilmodels.Date(time.Date(
-								2006, time.January, 2,
+								2006, time.January, 3,
 								0, 0, 0, 0,
 								time.UTC,
 							)),
 							Title:     "test2",
 							Completed: false,
 							Order:     42,
+							Tags:      []string{},
 						},
 					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
 						On("GetAll", baseURL, models.Query{
-							MinimalDate: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
-							MaximalDate: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
+							Pagination: models.Pagination{PageSize: 23, Page: 42},
 						}).
-						Return(presentationTodos, nil)
+						Return(presentationTodos, models.PageInfo{TotalCount: 1000}, nil)
 
 					return useCase
 				}(),
@@ -779,7 +845,7 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos/2006-01-02",
+					"http://example.com/api/v1/todos?page_size=23&page=42",
 					nil,
 				),
 			},
@@ -790,31 +856,42 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"1000"},
+					"Link": {
+						"<http://example.com/api/v1/todos?page=1&page_size=23>; rel=\"first\", " +
+							"<http://example.com/api/v1/todos?page=41&page_size=23>; rel=\"prev\", " +
+							"<http://example.com/api/v1/todos?page=43&page_size=23>; rel=\"next\", " +
+							"<http://example.com/api/v1/todos?page=44&page_size=23>; rel=\"last\"",
+					},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`[{"url":"http://example.com/api/v1/todos/5",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":12},` +
+						`"order":12,` +
+						`"tags":[]},` +
 						`{"url":"http://example.com/api/v1/todos/23",` +
-						`"date":"2006-01-02",` +
+						`"date":"2006-01-03",` +
 						`"title":"test2",` +
 						`"completed":false,` +
-						`"order":42}]`,
+						`"order":42,` +
+						`"tags":[]}]`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "success with the title fragment",
+			name: "success with the cursor",
 			fields: fields{
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
 					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
 					presentationTodos := []models.PresentationTodoRecord{
 						{
-							URL: "http://example.com/api/v1/todos/5",
+							URL: "http://example.com/api/v1/todos/23",
 							Date: utilmodels.Date(time.Date(
 								2006, time.January, 2,
 								0, 0, 0, 0,
@@ -822,37 +899,32 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 							)),
 							Title:     "test",
 							Completed: true,
-							Order:     12,
-						},
-						{
-							URL: "http://example.com/api/v1/todos/23",
-							Date: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
-							Title:     "test2",
-							Completed: false,
 							Order:     42,
+							Tags:      []string{},
 						},
 					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
 						On("GetAll", baseURL, models.Query{
-							MinimalDate: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
-							MaximalDate: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
-							TitleFragment: "test",
+							Pagination: models.Pagination{
+								PageSize: 1,
+								Cursor: models.Cursor{
+									Date: utilmodels.Date(time.Date(
+										2006, time.January, 2,
+										0, 0, 0, 0,
+										time.UTC,
+									)),
+									Order: 12,
+									ID:    5,
+								},
+							},
 						}).
-						Return(presentationTodos, nil)
+						Return(
+							presentationTodos,
+							models.PageInfo{TotalCount: 5, NextCursor: "next-cursor"},
+							nil,
+						)
 
 					return useCase
 				}(),
@@ -861,7 +933,9 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos/2006-01-02?title_fragment=test",
+					"http://example.com/api/v1/todos?page_size=1&cursor="+
+						// {"date":"2006-01-02","order":12,"id":5}
+						"eyJkYXRlIjoiMjAwNi0wMS0wMiIsIm9yZGVyIjoxMiwiaWQiOjV9",
 					nil,
 				),
 			},
@@ -872,112 +946,90 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Next-Cursor": {"next-cursor"},
+					"X-Total-Count": {"5"},
+					"Link": {
+						"<http://example.com/api/v1/todos?page_size=1>; " +
+							"rel=\"first\", " +
+							"<http://example.com/api/v1/todos?cursor=next-cursor&page_size=1>; " +
+							"rel=\"next\"",
+					},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					`[{"url":"http://example.com/api/v1/todos/5",` +
+					`{"todos":[{"url":"http://example.com/api/v1/todos/23",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":12},` +
-						`{"url":"http://example.com/api/v1/todos/23",` +
-						`"date":"2006-01-02",` +
-						`"title":"test2",` +
-						`"completed":false,` +
-						`"order":42}]`,
+						`"order":42,` +
+						`"tags":[]}],` +
+						`"next_cursor":"next-cursor"}`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "success with the pagination",
+			name: "error with the minimal_date parameter",
 			fields: fields{
 				URLScheme: "http",
-				UseCase: func() TodoRecordUseCase {
-					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
-					presentationTodos := []models.PresentationTodoRecord{
-						{
-							URL: "http://example.com/api/v1/todos/5",
-							Date: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
-							Title:     "test",
-							Completed: true,
-							Order:     12,
-						},
-						{
-							URL: "http://example.com/api/v1/todos/23",
-							Date: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
-							Title:     "test2",
-							Completed: false,
-							Order:     42,
-						},
-					}
-
-					useCase := &MockTodoRecordUseCase{}
-					useCase.InnerMock.
-						On("GetAll", baseURL, models.Query{
-							MinimalDate: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
-							MaximalDate: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
-							Pagination: models.Pagination{PageSize: 23, Page: 42},
-						}).
-						Return(presentationTodos, nil)
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the minimal_date parameter: " +
+						"unable to parse the date: " +
+						"parsing time \"incorrect\" as \"2006-01-02\": " +
+						"cannot parse \"incorrect\" as \"2006\""
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
 
-					return useCase
+					return logger
 				}(),
-				Logger: &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos/2006-01-02?page_size=23&page=42",
+					"http://example.com/api/v1/todos?minimal_date=incorrect",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusOK) + " " +
-					http.StatusText(http.StatusOK),
-				StatusCode: http.StatusOK,
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					`[{"url":"http://example.com/api/v1/todos/5",` +
-						`"date":"2006-01-02",` +
-						`"title":"test",` +
-						`"completed":true,` +
-						`"order":12},` +
-						`{"url":"http://example.com/api/v1/todos/23",` +
-						`"date":"2006-01-02",` +
-						`"title":"test2",` +
-						`"completed":false,` +
-						`"order":42}]`,
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the minimal_date parameter: ` +
+						`unable to parse the date: ` +
+						`parsing time \"incorrect\" as \"2006-01-02\": ` +
+						`cannot parse \"incorrect\" as \"2006\"",` +
+						`"instance":"/api/v1/todos?minimal_date=incorrect",` +
+						`"code":"invalid_date",` +
+						`"parameter":"minimal_date"}`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error on date getting",
+			name: "error with the maximal_date parameter",
 			fields: fields{
 				URLScheme: "http",
 				UseCase:   &MockTodoRecordUseCase{},
 				Logger: func() httputils.Logger {
-					message := "unable to get a date: " +
-						"unable to find a date"
+					message := "unable to get the maximal_date parameter: " +
+						"unable to parse the date: " +
+						"parsing time \"incorrect\" as \"2006-01-02\": " +
+						"cannot parse \"incorrect\" as \"2006\""
 					logger := &MockLogger{}
 					logger.InnerMock.
 						On("Print", []interface{}{message}).
@@ -990,7 +1042,7 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos/incorrect",
+					"http://example.com/api/v1/todos?maximal_date=incorrect",
 					nil,
 				),
 			},
@@ -1001,10 +1053,20 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get a date: " +
-						"unable to find a date",
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the maximal_date parameter: ` +
+						`unable to parse the date: ` +
+						`parsing time \"incorrect\" as \"2006-01-02\": ` +
+						`cannot parse \"incorrect\" as \"2006\"",` +
+						`"instance":"/api/v1/todos?maximal_date=incorrect",` +
+						`"code":"invalid_date",` +
+						`"parameter":"maximal_date"}`,
 				))),
 				ContentLength: -1,
 			},
@@ -1030,7 +1092,7 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos/2006-01-02?page_size=incorrect",
+					"http://example.com/api/v1/todos?page_size=incorrect",
 					nil,
 				),
 			},
@@ -1041,11 +1103,21 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get the page_size parameter: " +
-						"value is incorrect: " +
-						"strconv.Atoi: parsing \"incorrect\": invalid syntax",
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the page_size parameter: ` +
+						`value is incorrect: ` +
+						`strconv.Atoi: ` +
+						`parsing \"incorrect\": ` +
+						`invalid syntax",` +
+						`"instance":"/api/v1/todos?page_size=incorrect",` +
+						`"code":"invalid_page_size",` +
+						`"parameter":"page_size"}`,
 				))),
 				ContentLength: -1,
 			},
@@ -1071,7 +1143,7 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos/2006-01-02?page=incorrect",
+					"http://example.com/api/v1/todos?page=incorrect",
 					nil,
 				),
 			},
@@ -1082,44 +1154,37 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get the page parameter: " +
-						"value is incorrect: " +
-						"strconv.Atoi: parsing \"incorrect\": invalid syntax",
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the page parameter: ` +
+						`value is incorrect: ` +
+						`strconv.Atoi: ` +
+						`parsing \"incorrect\": ` +
+						`invalid syntax",` +
+						`"instance":"/api/v1/todos?page=incorrect",` +
+						`"code":"invalid_page",` +
+						`"parameter":"page"}`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error with the use case",
+			name: "error with the cursor parameter",
 			fields: fields{
 				URLScheme: "http",
-				UseCase: func() TodoRecordUseCase {
-					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
-
-					useCase := &MockTodoRecordUseCase{}
-					useCase.InnerMock.
-						On("GetAll", baseURL, models.Query{
-							MinimalDate: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
-							MaximalDate: utilmodels.Date(time.Date(
-								2006, time.January, 2,
-								0, 0, 0, 0,
-								time.UTC,
-							)),
-						}).
-						Return([]models.PresentationTodoRecord(nil), iotest.ErrTimeout)
-
-					return useCase
-				}(),
+				UseCase:   &MockTodoRecordUseCase{},
 				Logger: func() httputils.Logger {
+					message := "unable to get the cursor parameter: " +
+						"unable to decode the cursor: " +
+						"illegal base64 data at input byte 8"
 					logger := &MockLogger{}
 					logger.InnerMock.
-						On("Print", []interface{}{"timeout"}).
+						On("Print", []interface{}{message}).
 						Return().
 						Times(1)
 
@@ -1129,87 +1194,4570 @@ func TestTodoRecord_GetAllByDate(t *testing.T) {
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos/2006-01-02",
+					"http://example.com/api/v1/todos?page_size=1&cursor=incorrect",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
-					http.StatusText(http.StatusInternalServerError),
-				StatusCode:    http.StatusInternalServerError,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{},
-				Body:          ioutil.NopCloser(bytes.NewReader([]byte("timeout"))),
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the cursor parameter: ` +
+						`unable to decode the cursor: ` +
+						`illegal base64 data at input byte 8",` +
+						`"instance":"/api/v1/todos?` +
+						`page_size=1\u0026cursor=incorrect",` +
+						`"code":"invalid_cursor",` +
+						`"parameter":"cursor"}`,
+				))),
 				ContentLength: -1,
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			responseRecorder := httptest.NewRecorder()
-			handler := TodoRecord{
-				URLScheme: tt.fields.URLScheme,
-				UseCase:   tt.fields.UseCase,
-				Logger:    tt.fields.Logger,
-			}
-			handler.GetAllByDate(responseRecorder, tt.args.request)
-
-			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
-			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
-			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
-		})
-	}
-}
-
-func TestTodoRecord_GetSingle(t *testing.T) {
-	type fields struct {
-		URLScheme string
-		UseCase   TodoRecordUseCase
-		Logger    httputils.Logger
-	}
-	type args struct {
-		request *http.Request
-	}
-
-	tests := []struct {
-		name         string
-		fields       fields
-		args         args
-		wantResponse *http.Response
-	}{
 		{
-			name: "success",
+			name: "error with the cursor and page parameters",
 			fields: fields{
 				URLScheme: "http",
-				UseCase: func() TodoRecordUseCase {
-					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
-					presentationTodo := models.PresentationTodoRecord{
-						URL: "http://example.com/api/v1/todos/12",
-						Date: utilmodels.Date(time.Date(
-							2006, time.January, 2,
-							0, 0, 0, 0,
-							time.UTC,
-						)),
-						Title:     "test",
-						Completed: true,
-						Order:     23,
-					}
-
-					useCase := &MockTodoRecordUseCase{}
-					useCase.InnerMock.
-						On("GetSingle", baseURL, 12).
-						Return(presentationTodo, nil)
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "the cursor parameter can't be used with the page one"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
 
-					return useCase
+					return logger
 				}(),
-				Logger: &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodGet,
-					"http://example.com/api/v1/todos/12",
+					"http://example.com/api/v1/todos?page_size=1&page=2&cursor=eyJpZCI6MX0",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"the cursor parameter can't be used with the page one",` +
+						`"instance":"/api/v1/todos?` +
+						`page_size=1\u0026page=2\u0026cursor=eyJpZCI6MX0",` +
+						`"code":"invalid_cursor",` +
+						`"parameter":"cursor"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the completed parameter",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the completed parameter: " +
+						"strconv.ParseBool: parsing \"incorrect\": invalid syntax"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos?completed=incorrect",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the completed parameter: ` +
+						`strconv.ParseBool: ` +
+						`parsing \"incorrect\": ` +
+						`invalid syntax",` +
+						`"instance":"/api/v1/todos?completed=incorrect",` +
+						`"code":"invalid_completed",` +
+						`"parameter":"completed"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the search_notes parameter",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the search_notes parameter: " +
+						`strconv.ParseBool: parsing "incorrect": invalid syntax`
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos?search_notes=incorrect",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the search_notes parameter: ` +
+						`strconv.ParseBool: parsing \"incorrect\": invalid syntax",` +
+						`"instance":"/api/v1/todos?search_notes=incorrect",` +
+						`"code":"invalid_search_notes",` +
+						`"parameter":"search_notes"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the render parameter",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the render parameter: " +
+						`unknown value "pdf"`
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos?render=pdf",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the render parameter: ` +
+						`unknown value \"pdf\"",` +
+						`"instance":"/api/v1/todos?render=pdf",` +
+						`"code":"invalid_render",` +
+						`"parameter":"render"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the max_order parameter",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the max_order parameter: " +
+						"value is incorrect: " +
+						"strconv.Atoi: parsing \"incorrect\": invalid syntax"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos?max_order=incorrect",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the max_order parameter: ` +
+						`value is incorrect: ` +
+						`strconv.Atoi: ` +
+						`parsing \"incorrect\": ` +
+						`invalid syntax",` +
+						`"instance":"/api/v1/todos?max_order=incorrect",` +
+						`"code":"invalid_order",` +
+						`"parameter":"max_order"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the min_priority parameter",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the min_priority parameter: " +
+						"value too greater"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos?min_priority=5",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the min_priority parameter: ` +
+						`value too greater",` +
+						`"instance":"/api/v1/todos?min_priority=5",` +
+						`"code":"invalid_priority",` +
+						`"parameter":"min_priority"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the updated_before parameter",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the updated_before parameter: " +
+						`parsing time "incorrect" as "2006-01-02T15:04:05Z07:00": ` +
+						`cannot parse "incorrect" as "2006"`
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos?updated_before=incorrect",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the updated_before parameter: ` +
+						`parsing time \"incorrect\" as \"2006-01-02T15:04:05Z07:00\": ` +
+						`cannot parse \"incorrect\" as \"2006\"",` +
+						`"instance":"/api/v1/todos?updated_before=incorrect",` +
+						`"code":"invalid_timestamp",` +
+						`"parameter":"updated_before"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the ids parameter",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the ids parameter: incorrect ID \"0\""
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos?ids=5,0",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the ids parameter: incorrect ID \"0\"",` +
+						`"instance":"/api/v1/todos?ids=5,0",` +
+						`"code":"invalid_ids",` +
+						`"parameter":"ids"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the sort parameter",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the sort parameter: " +
+						`unknown field "unknown"`
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos?sort=unknown",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the sort parameter: ` +
+						`unknown field \"unknown\"",` +
+						`"instance":"/api/v1/todos?sort=unknown",` +
+						`"code":"invalid_sort",` +
+						`"parameter":"sort"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the sort parameter in the cursor mode",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "the sort parameter can't be used in the cursor mode"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos?page_size=1&sort=title",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"the sort parameter can't be used in the cursor mode",` +
+						`"instance":"/api/v1/todos?page_size=1\u0026sort=title",` +
+						`"code":"invalid_sort",` +
+						`"parameter":"sort"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the relevance sort without the search",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "the relevance sort requires the q parameter"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos?sort=-relevance",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"the relevance sort requires the q parameter",` +
+						`"instance":"/api/v1/todos?sort=-relevance",` +
+						`"code":"invalid_sort",` +
+						`"parameter":"sort"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the use case",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{}).
+						Return([]models.PresentationTodoRecord(nil), models.PageInfo{}, iotest.ErrTimeout)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"timeout"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/todos",` +
+						`"code":"internal_error"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.GetAll(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_GetAllByList(t *testing.T) {
+	type fields struct {
+		URLScheme   string
+		UseCase     TodoRecordUseCase
+		ListUseCase ListUseCase
+		Logger      httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{
+						{
+							URL: "http://example.com/api/v1/todos/5",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title:     "test",
+							Completed: true,
+							Order:     12,
+							Tags:      []string{},
+							ListID:    23,
+						},
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							TitleFragment: "test",
+							ListID:        23,
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 1}, nil)
+
+					return useCase
+				}(),
+				ListUseCase: func() ListUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationList := models.PresentationList{
+						URL:      "http://example.com/api/v1/lists/23",
+						Title:    "test",
+						TodosURL: "http://example.com/api/v1/lists/23/todos",
+					}
+
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 23).
+						Return(presentationList, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/lists/23/todos?title_fragment=test",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"1"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"url":"http://example.com/api/v1/todos/5",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":true,` +
+						`"order":12,` +
+						`"tags":[],` +
+						`"list_id":23}]`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the ID",
+			fields: fields{
+				URLScheme:   "http",
+				UseCase:     &MockTodoRecordUseCase{},
+				ListUseCase: &MockListUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get an ID: unable to find an ID"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/lists/incorrect/todos",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get an ID: unable to find an ID",` +
+						`"instance":"/api/v1/lists/incorrect/todos",` +
+						`"code":"invalid_id",` +
+						`"parameter":"id"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with an unknown list",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				ListUseCase: func() ListUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					err := fmt.Errorf("unable to get the list: %w", models.ErrNotFound)
+
+					useCase := &MockListUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 23).
+						Return(models.PresentationList{}, err)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					message := "unable to get the list: not found"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/lists/23/todos",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Not Found",` +
+						`"status":404,` +
+						`"detail":"unable to get the list: not found",` +
+						`"instance":"/api/v1/lists/23/todos",` +
+						`"code":"record_not_found"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme:   tt.fields.URLScheme,
+				UseCase:     tt.fields.UseCase,
+				ListUseCase: tt.fields.ListUseCase,
+				Logger:      tt.fields.Logger,
+			}
+			handler.GetAllByList(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.ListUseCase.(*MockListUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_GetAllByParent(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{
+						{
+							URL: "http://example.com/api/v1/todos/5",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title:     "test",
+							Completed: true,
+							Order:     12,
+							Tags:      []string{},
+							ParentID:  23,
+						},
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							TitleFragment: "test",
+							ParentID:      23,
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 1}, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/23/children?title_fragment=test",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"1"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"url":"http://example.com/api/v1/todos/5",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":true,` +
+						`"order":12,` +
+						`"tags":[],` +
+						`"parent_id":23}]`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the ID",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get an ID: unable to find an ID"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/incorrect/children",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get an ID: unable to find an ID",` +
+						`"instance":"/api/v1/todos/incorrect/children",` +
+						`"code":"invalid_id",` +
+						`"parameter":"id"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.GetAllByParent(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_GetAllByDate(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success without to-do records",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							TimeZone: time.UTC,
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 0}, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/2006-01-02",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"0"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with to-do records",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{
+						{
+							URL: "http://example.com/api/v1/todos/5",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title:     "test",
+							Completed: true,
+							Order:     12,
+							Tags:      []string{},
+						},
+						{
+							URL: "http://example.com/api/v1/todos/23",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title:     "test2",
+							Completed: false,
+							Order:     42,
+							Tags:      []string{},
+						},
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							TimeZone: time.UTC,
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 2}, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/2006-01-02",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"2"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"url":"http://example.com/api/v1/todos/5",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":true,` +
+						`"order":12,` +
+						`"tags":[]},` +
+						`{"url":"http://example.com/api/v1/todos/23",` +
+						`"date":"2006-01-02",` +
+						`"title":"test2",` +
+						`"completed":false,` +
+						`"order":42,` +
+						`"tags":[]}]`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the title fragment",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{
+						{
+							URL: "http://example.com/api/v1/todos/5",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title:     "test",
+							Completed: true,
+							Order:     12,
+							Tags:      []string{},
+						},
+						{
+							URL: "http://example.com/api/v1/todos/23",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title:     "test2",
+							Completed: false,
+							Order:     42,
+							Tags:      []string{},
+						},
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							TimeZone:      time.UTC,
+							TitleFragment: "test",
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 2}, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/2006-01-02?title_fragment=test",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"2"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"url":"http://example.com/api/v1/todos/5",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":true,` +
+						`"order":12,` +
+						`"tags":[]},` +
+						`{"url":"http://example.com/api/v1/todos/23",` +
+						`"date":"2006-01-02",` +
+						`"title":"test2",` +
+						`"completed":false,` +
+						`"order":42,` +
+						`"tags":[]}]`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the pagination",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{
+						{
+							URL: "http://example.com/api/v1/todos/5",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title:     "test",
+							Completed: true,
+							Order:     12,
+							Tags:      []string{},
+						},
+						{
+							URL: "http://example.com/api/v1/todos/23",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title:     "test2",
+							Completed: false,
+							Order:     42,
+							Tags:      []string{},
+						},
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							TimeZone:   time.UTC,
+							Pagination: models.Pagination{PageSize: 23, Page: 42},
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 1000}, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/2006-01-02?page_size=23&page=42",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"1000"},
+					"Link": {
+						"<http://example.com/api/v1/todos/2006-01-02?page=1&page_size=23>; rel=\"first\", " +
+							"<http://example.com/api/v1/todos/2006-01-02?page=41&page_size=23>; rel=\"prev\", " +
+							"<http://example.com/api/v1/todos/2006-01-02?page=43&page_size=23>; rel=\"next\", " +
+							"<http://example.com/api/v1/todos/2006-01-02?page=44&page_size=23>; rel=\"last\"",
+					},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"url":"http://example.com/api/v1/todos/5",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":true,` +
+						`"order":12,` +
+						`"tags":[]},` +
+						`{"url":"http://example.com/api/v1/todos/23",` +
+						`"date":"2006-01-02",` +
+						`"title":"test2",` +
+						`"completed":false,` +
+						`"order":42,` +
+						`"tags":[]}]`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the tz parameter",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{}
+
+					timeZone, err := time.LoadLocation("Asia/Tokyo")
+					require.NoError(t, err)
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							TimeZone: timeZone,
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 0}, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/2006-01-02?tz=Asia/Tokyo",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"0"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with today",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{}
+
+					timeZone, err := time.LoadLocation("Asia/Tokyo")
+					require.NoError(t, err)
+
+					year, month, day := time.Now().In(timeZone).Date()
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							Date: utilmodels.Date(
+								time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+							),
+							TimeZone: timeZone,
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 0}, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/today?tz=Asia/Tokyo",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"0"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the tz parameter",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the tz parameter: " +
+						"unknown time zone Incorrect/Zone"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/2006-01-02?tz=Incorrect/Zone",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the tz parameter: ` +
+						`unknown time zone Incorrect/Zone",` +
+						`"instance":"/api/v1/todos/2006-01-02?tz=Incorrect/Zone",` +
+						`"code":"invalid_tz",` +
+						`"parameter":"tz"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on date getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get a date: " +
+						"unable to find a date"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/incorrect",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get a date: ` +
+						`unable to find a date",` +
+						`"instance":"/api/v1/todos/incorrect",` +
+						`"code":"invalid_date",` +
+						`"parameter":"date"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the page_size parameter",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the page_size parameter: " +
+						"value is incorrect: " +
+						"strconv.Atoi: parsing \"incorrect\": invalid syntax"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/2006-01-02?page_size=incorrect",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the page_size parameter: ` +
+						`value is incorrect: ` +
+						`strconv.Atoi: ` +
+						`parsing \"incorrect\": ` +
+						`invalid syntax",` +
+						`"instance":"/api/v1/todos/2006-01-02?page_size=incorrect",` +
+						`"code":"invalid_page_size",` +
+						`"parameter":"page_size"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the page parameter",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the page parameter: " +
+						"value is incorrect: " +
+						"strconv.Atoi: parsing \"incorrect\": invalid syntax"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/2006-01-02?page=incorrect",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the page parameter: ` +
+						`value is incorrect: ` +
+						`strconv.Atoi: ` +
+						`parsing \"incorrect\": ` +
+						`invalid syntax",` +
+						`"instance":"/api/v1/todos/2006-01-02?page=incorrect",` +
+						`"code":"invalid_page",` +
+						`"parameter":"page"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the use case",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							TimeZone: time.UTC,
+						}).
+						Return([]models.PresentationTodoRecord(nil), models.PageInfo{}, iotest.ErrTimeout)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"timeout"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/2006-01-02",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/todos/2006-01-02",` +
+						`"code":"internal_error"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.GetAllByDate(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_GetSingle(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodo := models.PresentationTodoRecord{
+						URL: "http://example.com/api/v1/todos/12",
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test",
+						Completed: true,
+						Order:     23,
+						Tags:      []string{},
+						Version:   5,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 12).
+						Return(presentationTodo, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5"`},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":true,` +
+						`"order":23,` +
+						`"tags":[]}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the rendered notes",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodo := models.PresentationTodoRecord{
+						URL: "http://example.com/api/v1/todos/12",
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:   "test",
+						Notes:   "*one* <script>alert(1)</script>",
+						Order:   23,
+						Tags:    []string{},
+						Version: 5,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 12).
+						Return(presentationTodo, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12?render=html",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5"`},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"notes":"*one* \u003cscript\u003ealert(1)\u003c/script\u003e",` +
+						`"completed":false,` +
+						`"order":23,` +
+						`"tags":[],` +
+						`"notes_html":"\u003cp\u003e\u003cem\u003eone\u003c/em\u003e ` +
+						`alert(1)\u003c/p\u003e\n"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with a matched If-None-Match header",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodo := models.PresentationTodoRecord{
+						URL: "http://example.com/api/v1/todos/12",
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test",
+						Completed: true,
+						Order:     23,
+						Tags:      []string{},
+						Version:   5,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 12).
+						Return(presentationTodo, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/api/v1/todos/12",
+						nil,
+					)
+					request.Header.Set("If-None-Match", `"4", W/"5"`)
+
+					return request
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotModified) + " " +
+					http.StatusText(http.StatusNotModified),
+				StatusCode:    http.StatusNotModified,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{"Etag": {`"5"`}},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the children",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodo := models.PresentationTodoRecord{
+						URL: "http://example.com/api/v1/todos/12",
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:      "test",
+						Tags:       []string{},
+						Version:    5,
+						ChildCount: 1,
+						ChildrenCompletion: func() *float64 {
+							childrenCompletion := 1.0
+							return &childrenCompletion
+						}(),
+						Children: []models.PresentationTodoRecord{
+							{
+								URL: "http://example.com/api/v1/todos/13",
+								Date: utilmodels.Date(time.Date(
+									2006, time.January, 2,
+									0, 0, 0, 0,
+									time.UTC,
+								)),
+								Title:     "child",
+								Completed: true,
+								Tags:      []string{},
+								ParentID:  12,
+								Version:   2,
+							},
+						},
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetTree", baseURL, 12).
+						Return(presentationTodo, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12?include=children",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5-70007361"`},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":false,` +
+						`"order":0,` +
+						`"tags":[],` +
+						`"child_count":1,` +
+						`"children_completion":1,` +
+						`"children":[` +
+						`{"url":"http://example.com/api/v1/todos/13",` +
+						`"date":"2006-01-02",` +
+						`"title":"child",` +
+						`"completed":true,` +
+						`"order":0,` +
+						`"tags":[],` +
+						`"parent_id":12}` +
+						`]}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on ID getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get an ID: " +
+						"unable to find an ID"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get an ID: ` +
+						`unable to find an ID",` +
+						`"instance":"/api/v1/todos/",` +
+						`"code":"invalid_id",` +
+						`"parameter":"id"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with an invalid include",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the include parameter: " +
+						`unknown value "parents"`
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12?include=parents",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the include parameter: ` +
+						`unknown value \"parents\"",` +
+						`"instance":"/api/v1/todos/12?include=parents",` +
+						`"code":"invalid_include",` +
+						`"parameter":"include"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on to-do record getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 12).
+						Return(models.PresentationTodoRecord{}, iotest.ErrTimeout)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"timeout"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"internal_error"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with a not found to-do record",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					err := fmt.Errorf(
+						"unable to get the to-do record: %w",
+						models.ErrNotFound,
+					)
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 12).
+						Return(models.PresentationTodoRecord{}, err)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					message := "unable to get the to-do record: not found"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Not Found",` +
+						`"status":404,` +
+						`"detail":"unable to get the to-do record: ` +
+						`not found",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"record_not_found"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.GetSingle(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_Create(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodoIn := models.PresentationTodoRecord{
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test",
+						Completed: true,
+						Order:     23,
+					}
+					presentationTodoOut := models.PresentationTodoRecord{
+						URL: "http://example.com/api/v1/todos/12",
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test",
+						Completed: true,
+						Order:     23,
+						Tags:      []string{},
+						Version:   5,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Create", baseURL, presentationTodoIn, "").
+						Return(presentationTodoOut, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/todos/",
+					bytes.NewReader([]byte(`{
+						"date": "2006-01-02",
+						"title": "test",
+						"completed": true,
+						"order": 23
+					}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5"`},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":true,` +
+						`"order":23,` +
+						`"tags":[]}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on request body getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the request body: " +
+						"unable to unmarshal the JSON data: " +
+						"invalid character 'i' looking for beginning of value"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/todos/",
+					bytes.NewReader([]byte("incorrect")),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the request body: ` +
+						`unable to unmarshal the JSON data: ` +
+						`invalid character 'i' looking for beginning of value",` +
+						`"instance":"/api/v1/todos/",` +
+						`"code":"invalid_request_body",` +
+						`"parameter":"body"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on to-do record creating",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodoIn := models.PresentationTodoRecord{
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test",
+						Completed: true,
+						Order:     23,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Create", baseURL, presentationTodoIn, "").
+						Return(models.PresentationTodoRecord{}, iotest.ErrTimeout)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"timeout"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/todos/",
+					bytes.NewReader([]byte(`{
+						"date": "2006-01-02",
+						"title": "test",
+						"completed": true,
+						"order": 23
+					}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/todos/",` +
+						`"code":"internal_error"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.Create(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_Batch(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success in the best-effort mode",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					batch := models.Batch{
+						Mode: models.BatchModeBestEffort,
+						Operations: []models.BatchOperation{
+							{
+								Action: models.BatchActionCreate,
+								Todo: &models.PresentationTodoRecord{
+									Date: utilmodels.Date(time.Date(
+										2006, time.January, 2,
+										0, 0, 0, 0,
+										time.UTC,
+									)),
+									Title: "test",
+								},
+							},
+							{Action: models.BatchActionDelete, ID: 12, Version: 5},
+							{Action: models.BatchActionDelete, ID: 23},
+						},
+					}
+					results := []models.BatchResult{
+						{
+							Todo: &models.PresentationTodoRecord{
+								URL: "http://example.com/api/v1/todos/42",
+								Date: utilmodels.Date(time.Date(
+									2006, time.January, 2,
+									0, 0, 0, 0,
+									time.UTC,
+								)),
+								Title:   "test",
+								Tags:    []string{},
+								Version: 1,
+							},
+						},
+						{Err: models.ErrVersionMismatch},
+						{},
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.On("Batch", baseURL, batch, "").Return(results, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/todos/batch",
+					bytes.NewReader([]byte(`{
+						"mode": "best_effort",
+						"operations": [
+							{
+								"action": "create",
+								"todo": {"date": "2006-01-02", "title": "test"}
+							},
+							{"action": "delete", "id": 12, "version": 5},
+							{"action": "delete", "id": 23}
+						]
+					}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"committed":true,"results":[` +
+						`{"status":200,"todo":{` +
+						`"url":"http://example.com/api/v1/todos/42",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":false,` +
+						`"order":0,` +
+						`"tags":[]}},` +
+						`{"status":412,"problem":{` +
+						`"type":"about:blank",` +
+						`"title":"Precondition Failed",` +
+						`"status":412,` +
+						`"detail":"version mismatch",` +
+						`"instance":"/api/v1/todos/batch",` +
+						`"code":"version_mismatch"}},` +
+						`{"status":204}]}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the rollback in the all-or-nothing mode",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					batch := models.Batch{
+						Operations: []models.BatchOperation{
+							{Action: models.BatchActionDelete, ID: 12},
+							{Action: models.BatchActionDelete, ID: 23},
+						},
+					}
+					results := []models.BatchResult{
+						{Err: models.ErrRolledBack},
+						{Err: models.ErrNotFound},
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.On("Batch", baseURL, batch, "").Return(results, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/todos/batch",
+					bytes.NewReader([]byte(`{
+						"operations": [
+							{"action": "delete", "id": 12},
+							{"action": "delete", "id": 23}
+						]
+					}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"committed":false,"results":[` +
+						`{"status":424,"problem":{` +
+						`"type":"about:blank",` +
+						`"title":"Failed Dependency",` +
+						`"status":424,` +
+						`"detail":"rolled back",` +
+						`"instance":"/api/v1/todos/batch",` +
+						`"code":"batch_rolled_back"}},` +
+						`{"status":404,"problem":{` +
+						`"type":"about:blank",` +
+						`"title":"Not Found",` +
+						`"status":404,` +
+						`"detail":"not found",` +
+						`"instance":"/api/v1/todos/batch",` +
+						`"code":"record_not_found"}}]}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on request body getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the request body: " +
+						"unable to unmarshal the JSON data: " +
+						"invalid character 'i' looking for beginning of value"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/todos/batch",
+					bytes.NewReader([]byte("incorrect")),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the request body: ` +
+						`unable to unmarshal the JSON data: ` +
+						`invalid character 'i' looking for beginning of value",` +
+						`"instance":"/api/v1/todos/batch",` +
+						`"code":"invalid_request_body",` +
+						`"parameter":"body"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on batch executing",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					batch := models.Batch{
+						Operations: []models.BatchOperation{
+							{Action: models.BatchActionDelete, ID: 12},
+						},
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Batch", baseURL, batch, "").
+						Return([]models.BatchResult(nil), iotest.ErrTimeout)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"timeout"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/todos/batch",
+					bytes.NewReader([]byte(`{
+						"operations": [{"action": "delete", "id": 12}]
+					}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/todos/batch",` +
+						`"code":"internal_error"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.Batch(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_Update(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodoIn := models.PresentationTodoRecord{
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test",
+						Completed: true,
+						Order:     23,
+					}
+					presentationTodoOut := models.PresentationTodoRecord{
+						URL: "http://example.com/api/v1/todos/12",
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test",
+						Completed: true,
+						Order:     23,
+						Tags:      []string{},
+						Version:   5,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Update", baseURL, 12, presentationTodoIn, 0, false, false, "").
+						Return(presentationTodoOut, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPut,
+					"http://example.com/api/v1/todos/12",
+					bytes.NewReader([]byte(`{
+						"date": "2006-01-02",
+						"title": "test",
+						"completed": true,
+						"order": 23
+					}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5"`},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":true,` +
+						`"order":23,` +
+						`"tags":[]}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on ID getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get an ID: " +
+						"unable to find an ID"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPut,
+					"http://example.com/api/v1/todos/",
+					bytes.NewReader([]byte(`{
+						"date": "2006-01-02",
+						"title": "test",
+						"completed": true,
+						"order": 23
+					}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get an ID: ` +
+						`unable to find an ID",` +
+						`"instance":"/api/v1/todos/",` +
+						`"code":"invalid_id",` +
+						`"parameter":"id"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on request body getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the request body: " +
+						"unable to unmarshal the JSON data: " +
+						"invalid character 'i' looking for beginning of value"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPut,
+					"http://example.com/api/v1/todos/12",
+					bytes.NewReader([]byte("incorrect")),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the request body: ` +
+						`unable to unmarshal the JSON data: ` +
+						`invalid character 'i' looking for beginning of value",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"invalid_request_body",` +
+						`"parameter":"body"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on to-do record updating",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodoIn := models.PresentationTodoRecord{
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test",
+						Completed: true,
+						Order:     23,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Update", baseURL, 12, presentationTodoIn, 0, false, false, "").
+						Return(models.PresentationTodoRecord{}, iotest.ErrTimeout)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"timeout"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPut,
+					"http://example.com/api/v1/todos/12",
+					bytes.NewReader([]byte(`{
+						"date": "2006-01-02",
+						"title": "test",
+						"completed": true,
+						"order": 23
+					}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"internal_error"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.Update(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_Patch(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					todoPatchTitle := "test"
+					todoPatch := models.TodoRecordPatch{Title: &todoPatchTitle}
+					presentationTodo := models.PresentationTodoRecord{
+						URL: "http://example.com/api/v1/todos/12",
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test",
+						Completed: true,
+						Order:     23,
+						Tags:      []string{},
+						Version:   5,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Patch", baseURL, 12, todoPatch, 0, false, false, "").
+						Return(presentationTodo, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPatch,
+					"http://example.com/api/v1/todos/12",
+					bytes.NewReader([]byte(`{"title": "test"}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5"`},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":true,` +
+						`"order":23,` +
+						`"tags":[]}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on ID getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get an ID: " +
+						"unable to find an ID"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPatch,
+					"http://example.com/api/v1/todos/",
+					bytes.NewReader([]byte(`{"title": "test"}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get an ID: ` +
+						`unable to find an ID",` +
+						`"instance":"/api/v1/todos/",` +
+						`"code":"invalid_id",` +
+						`"parameter":"id"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on request body getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the request body: " +
+						"unable to unmarshal the JSON data: " +
+						"invalid character 'i' looking for beginning of value"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPatch,
+					"http://example.com/api/v1/todos/12",
+					bytes.NewReader([]byte("incorrect")),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the request body: ` +
+						`unable to unmarshal the JSON data: ` +
+						`invalid character 'i' looking for beginning of value",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"invalid_request_body",` +
+						`"parameter":"body"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the forced completion",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					todoPatchCompleted := true
+					todoPatch := models.TodoRecordPatch{Completed: &todoPatchCompleted}
+					presentationTodo := models.PresentationTodoRecord{
+						URL: "http://example.com/api/v1/todos/12",
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test",
+						Completed: true,
+						Order:     23,
+						Tags:      []string{},
+						BlockedBy: []int{5},
+						Blocked:   true,
+						Version:   5,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Patch", baseURL, 12, todoPatch, 0, false, true, "").
+						Return(presentationTodo, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPatch,
+					"http://example.com/api/v1/todos/12?force=true",
+					bytes.NewReader([]byte(`{"completed": true}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5-5b173100"`},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":true,` +
+						`"order":23,` +
+						`"tags":[],` +
+						`"blocked_by":[5],` +
+						`"blocked":true}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on force getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the force parameter: " +
+						`strconv.ParseBool: parsing "incorrect": invalid syntax`
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPatch,
+					"http://example.com/api/v1/todos/12?force=incorrect",
+					bytes.NewReader([]byte(`{"completed": true}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the force parameter: ` +
+						`strconv.ParseBool: parsing \"incorrect\": invalid syntax",` +
+						`"instance":"/api/v1/todos/12?force=incorrect",` +
+						`"code":"invalid_force",` +
+						`"parameter":"force"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the open blockers",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					todoPatchCompleted := true
+					todoPatch := models.TodoRecordPatch{Completed: &todoPatchCompleted}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Patch", baseURL, 12, todoPatch, 0, false, false, "").
+						Return(models.PresentationTodoRecord{}, models.ErrBlocked)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"blocked"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPatch,
+					"http://example.com/api/v1/todos/12",
+					bytes.NewReader([]byte(`{"completed": true}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusConflict) + " " +
+					http.StatusText(http.StatusConflict),
+				StatusCode: http.StatusConflict,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Conflict",` +
+						`"status":409,` +
+						`"detail":"blocked",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"record_blocked"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on to-do record patching",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					todoPatchTitle := "test"
+					todoPatch := models.TodoRecordPatch{Title: &todoPatchTitle}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Patch", baseURL, 12, todoPatch, 0, false, false, "").
+						Return(models.PresentationTodoRecord{}, iotest.ErrTimeout)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"timeout"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPatch,
+					"http://example.com/api/v1/todos/12",
+					bytes.NewReader([]byte(`{"title": "test"}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"internal_error"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.Patch(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_DeleteAll(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success with filters",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					completed := true
+					query := models.Query{
+						MinimalDate: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						TitleFragment: "test",
+						Tags:          []string{"one", "two"},
+						Completed:     &completed,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.On("DeleteAll", query, "").Return(5, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos"+
+						"?minimal_date=2006-01-02"+
+						"&title_fragment=test"+
+						"&tags=one,two"+
+						"&completed=true"+
+						"&sort=title"+
+						"&page_size=2"+
+						"&page=1",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"deleted_count":5}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the confirmation",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.On("DeleteAll", models.Query{}, "").Return(2, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos?confirm=all",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"deleted_count":2}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error without the confirmation",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"the deletion of all the to-do records requires " +
+							"the confirm=all parameter"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"the deletion of all the to-do records requires ` +
+						`the confirm=all parameter",` +
+						`"instance":"/api/v1/todos",` +
+						`"code":"confirmation_required",` +
+						`"parameter":"confirm"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with an incorrect confirmation",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"unable to get the confirm parameter: unknown value \"yes\""}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos?confirm=yes",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the confirm parameter: unknown value \"yes\"",` +
+						`"instance":"/api/v1/todos?confirm=yes",` +
+						`"code":"invalid_confirm",` +
+						`"parameter":"confirm"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with an incorrect date",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"unable to get the maximal_date parameter: " +
+							"unable to parse the date: " +
+							"parsing time \"incorrect\" as \"2006-01-02\": " +
+							"cannot parse \"incorrect\" as \"2006\""}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos?maximal_date=incorrect",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the maximal_date parameter: ` +
+						`unable to parse the date: ` +
+						`parsing time \"incorrect\" as \"2006-01-02\": ` +
+						`cannot parse \"incorrect\" as \"2006\"",` +
+						`"instance":"/api/v1/todos?maximal_date=incorrect",` +
+						`"code":"invalid_date",` +
+						`"parameter":"maximal_date"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("DeleteAll", models.Query{}, "").
+						Return(0, iotest.ErrTimeout)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"timeout"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos?confirm=all",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/todos?confirm=all",` +
+						`"code":"internal_error"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.DeleteAll(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_DeleteSingle(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.On("DeleteSingle", 12, 0, false, "").Return(nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos/12",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNoContent) + " " +
+					http.StatusText(http.StatusNoContent),
+				StatusCode:    http.StatusNoContent,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the If-Match header",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodo := models.PresentationTodoRecord{
+						URL:       "http://example.com/api/v1/todos/12",
+						Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+						Title:     "test",
+						BlockedBy: []int{5},
+						Blocked:   true,
+						Version:   5,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 12).
+						Return(presentationTodo, nil)
+					useCase.InnerMock.On("DeleteSingle", 12, 5, false, "").Return(nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(
+						http.MethodDelete,
+						"http://example.com/api/v1/todos/12",
+						nil,
+					)
+					request.Header.Set("If-Match", `"5-5b173100"`)
+
+					return request
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNoContent) + " " +
+					http.StatusText(http.StatusNoContent),
+				StatusCode:    http.StatusNoContent,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with several entity tags in the If-Match header",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodo := models.PresentationTodoRecord{
+						URL:     "http://example.com/api/v1/todos/12",
+						Date:    utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+						Title:   "test",
+						Version: 6,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 12).
+						Return(presentationTodo, nil)
+					useCase.InnerMock.On("DeleteSingle", 12, 6, false, "").Return(nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(
+						http.MethodDelete,
+						"http://example.com/api/v1/todos/12",
+						nil,
+					)
+					request.Header.Set("If-Match", `W/"6", "abc", "5", "6"`)
+
+					return request
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNoContent) + " " +
+					http.StatusText(http.StatusNoContent),
+				StatusCode:    http.StatusNoContent,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the X-Actor header",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.On("DeleteSingle", 12, 0, false, "admin").Return(nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(
+						http.MethodDelete,
+						"http://example.com/api/v1/todos/12",
+						nil,
+					)
+					request.Header.Set("X-Actor", "admin")
+
+					return request
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNoContent) + " " +
+					http.StatusText(http.StatusNoContent),
+				StatusCode:    http.StatusNoContent,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the cascade",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.On("DeleteSingle", 12, 0, true, "").Return(nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos/12?cascade=true",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNoContent) + " " +
+					http.StatusText(http.StatusNoContent),
+				StatusCode:    http.StatusNoContent,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on ID getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get an ID: " +
+						"unable to find an ID"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos/",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get an ID: ` +
+						`unable to find an ID",` +
+						`"instance":"/api/v1/todos/",` +
+						`"code":"invalid_id",` +
+						`"parameter":"id"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on If-Match header getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the If-Match header: " +
+						"entity tag isn't quoted"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(
+						http.MethodDelete,
+						"http://example.com/api/v1/todos/12",
+						nil,
+					)
+					request.Header.Set("If-Match", `"5", 6`)
+
+					return request
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the If-Match header: ` +
+						`entity tag isn't quoted",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"invalid_if_match",` +
+						`"parameter":"If-Match"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on cascade getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the cascade parameter: " +
+						`strconv.ParseBool: parsing "incorrect": invalid syntax`
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos/12?cascade=incorrect",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the cascade parameter: ` +
+						`strconv.ParseBool: parsing \"incorrect\": invalid syntax",` +
+						`"instance":"/api/v1/todos/12?cascade=incorrect",` +
+						`"code":"invalid_cascade",` +
+						`"parameter":"cascade"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with children",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					err := fmt.Errorf(
+						"unable to delete the to-do record: %w: "+
+							"the to-do record #12 has children",
+						models.ErrConflict,
+					)
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.On("DeleteSingle", 12, 0, false, "").Return(err)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					message := "unable to delete the to-do record: conflict: " +
+						"the to-do record #12 has children"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos/12",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusConflict) + " " +
+					http.StatusText(http.StatusConflict),
+				StatusCode: http.StatusConflict,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Conflict",` +
+						`"status":409,` +
+						`"detail":"unable to delete the to-do record: conflict: ` +
+						`the to-do record #12 has children",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"record_conflict"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on to-do record deleting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.On("DeleteSingle", 12, 0, false, "").Return(iotest.ErrTimeout)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"timeout"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos/12",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"internal_error"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with a not found to-do record",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					err := fmt.Errorf(
+						"unable to delete the to-do record: %w",
+						models.ErrNotFound,
+					)
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.On("DeleteSingle", 12, 0, false, "").Return(err)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					message := "unable to delete the to-do record: not found"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos/12",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Not Found",` +
+						`"status":404,` +
+						`"detail":"unable to delete the to-do record: ` +
+						`not found",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"record_not_found"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with a weak entity tag in the If-Match header",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					err := fmt.Errorf(
+						"unable to delete the to-do record: %w",
+						models.ErrVersionMismatch,
+					)
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("DeleteSingle", 12, unmatchedVersion, false, "").
+						Return(err)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					message := "unable to delete the to-do record: version mismatch"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(
+						http.MethodDelete,
+						"http://example.com/api/v1/todos/12",
+						nil,
+					)
+					request.Header.Set("If-Match", `W/"5"`)
+
+					return request
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusPreconditionFailed) + " " +
+					http.StatusText(http.StatusPreconditionFailed),
+				StatusCode: http.StatusPreconditionFailed,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Precondition Failed",` +
+						`"status":412,` +
+						`"detail":"unable to delete the to-do record: ` +
+						`version mismatch",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"version_mismatch"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with a version mismatch",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodo := models.PresentationTodoRecord{
+						URL:     "http://example.com/api/v1/todos/12",
+						Date:    utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+						Title:   "test",
+						Version: 5,
+					}
+					err := fmt.Errorf(
+						"unable to delete the to-do record: %w",
+						models.ErrVersionMismatch,
+					)
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 12).
+						Return(presentationTodo, nil)
+					useCase.InnerMock.On("DeleteSingle", 12, 5, false, "").Return(err)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					message := "unable to delete the to-do record: version mismatch"
+
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(
+						http.MethodDelete,
+						"http://example.com/api/v1/todos/12",
+						nil,
+					)
+					request.Header.Set("If-Match", `"5"`)
+
+					return request
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusPreconditionFailed) + " " +
+					http.StatusText(http.StatusPreconditionFailed),
+				StatusCode: http.StatusPreconditionFailed,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Precondition Failed",` +
+						`"status":412,` +
+						`"detail":"unable to delete the to-do record: ` +
+						`version mismatch",` +
+						`"instance":"/api/v1/todos/12",` +
+						`"code":"version_mismatch"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.DeleteSingle(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_GetTrash(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					deletedAt := time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC)
+					presentationTodos := []models.PresentationTodoRecord{
+						{
+							URL: "http://example.com/api/v1/todos/5",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title:     "test",
+							Completed: true,
+							Order:     12,
+							Tags:      []string{},
+							DeletedAt: &deletedAt,
+						},
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{
+							TitleFragment: "test",
+							Deleted:       true,
+						}).
+						Return(presentationTodos, models.PageInfo{TotalCount: 1}, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/trash?title_fragment=test",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Total-Count": {"1"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"url":"http://example.com/api/v1/todos/5",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":true,` +
+						`"order":12,` +
+						`"tags":[],` +
+						`"deleted_at":"2006-01-03T15:04:05Z"}]`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the use case",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetAll", baseURL, models.Query{Deleted: true}).
+						Return([]models.PresentationTodoRecord(nil), models.PageInfo{}, iotest.ErrTimeout)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"timeout"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/trash",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/trash",` +
+						`"code":"internal_error"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.GetTrash(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_Restore(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodo := models.PresentationTodoRecord{
+						URL: "http://example.com/api/v1/todos/12",
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test",
+						Completed: true,
+						Order:     23,
+						Tags:      []string{},
+						Version:   5,
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Restore", baseURL, 12, "").
+						Return(presentationTodo, nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/trash/12/restore",
 					nil,
 				),
 			},
@@ -1220,25 +5768,89 @@ func TestTodoRecord_GetSingle(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5"`},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
 					`{"url":"http://example.com/api/v1/todos/12",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
 						`"completed":true,` +
-						`"order":23}`,
+						`"order":23,` +
+						`"tags":[]}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on ID getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get an ID: " +
+						"unable to find an ID"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/trash/restore",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get an ID: ` +
+						`unable to find an ID",` +
+						`"instance":"/api/v1/trash/restore",` +
+						`"code":"invalid_id",` +
+						`"parameter":"id"}`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error on ID getting",
+			name: "error with a not found to-do record",
 			fields: fields{
 				URLScheme: "http",
-				UseCase:   &MockTodoRecordUseCase{},
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					err := fmt.Errorf(
+						"unable to restore the to-do record: %w",
+						models.ErrNotFound,
+					)
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("Restore", baseURL, 12, "").
+						Return(models.PresentationTodoRecord{}, err)
+
+					return useCase
+				}(),
 				Logger: func() httputils.Logger {
-					message := "unable to get an ID: " +
-						"unable to find an ID"
+					message := "unable to restore the to-do record: not found"
+
 					logger := &MockLogger{}
 					logger.InnerMock.
 						On("Print", []interface{}{message}).
@@ -1250,37 +5862,113 @@ func TestTodoRecord_GetSingle(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodGet,
-					"http://example.com/api/v1/todos/",
+					http.MethodPost,
+					"http://example.com/api/v1/trash/12/restore",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusBadRequest) + " " +
-					http.StatusText(http.StatusBadRequest),
-				StatusCode: http.StatusBadRequest,
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get an ID: " +
-						"unable to find an ID",
+					`{"type":"about:blank",` +
+						`"title":"Not Found",` +
+						`"status":404,` +
+						`"detail":"unable to restore the to-do record: ` +
+						`not found",` +
+						`"instance":"/api/v1/trash/12/restore",` +
+						`"code":"record_not_found"}`,
 				))),
 				ContentLength: -1,
 			},
 		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.Restore(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_PurgeTrash(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
 		{
-			name: "error on to-do record getting",
+			name: "success",
 			fields: fields{
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
-					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("PurgeTrash", time.Duration(0)).
+						Return(3, nil)
 
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/trash",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"deleted_count":3}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the use case",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
-						On("GetSingle", baseURL, 12).
-						Return(models.PresentationTodoRecord{}, iotest.ErrTimeout)
+						On("PurgeTrash", time.Duration(0)).
+						Return(0, iotest.ErrTimeout)
 
 					return useCase
 				}(),
@@ -1296,20 +5984,29 @@ func TestTodoRecord_GetSingle(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodGet,
-					"http://example.com/api/v1/todos/12",
+					http.MethodDelete,
+					"http://example.com/api/v1/trash",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
 				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
 					http.StatusText(http.StatusInternalServerError),
-				StatusCode:    http.StatusInternalServerError,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{},
-				Body:          ioutil.NopCloser(bytes.NewReader([]byte("timeout"))),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/trash",` +
+						`"code":"internal_error"}`,
+				))),
 				ContentLength: -1,
 			},
 		},
@@ -1322,7 +6019,7 @@ func TestTodoRecord_GetSingle(t *testing.T) {
 				UseCase:   tt.fields.UseCase,
 				Logger:    tt.fields.Logger,
 			}
-			handler.GetSingle(responseRecorder, tt.args.request)
+			handler.PurgeTrash(responseRecorder, tt.args.request)
 
 			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
 			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
@@ -1331,7 +6028,7 @@ func TestTodoRecord_GetSingle(t *testing.T) {
 	}
 }
 
-func TestTodoRecord_Create(t *testing.T) {
+func TestTodoRecord_AddDependency(t *testing.T) {
 	type fields struct {
 		URLScheme string
 		UseCase   TodoRecordUseCase
@@ -1353,17 +6050,7 @@ func TestTodoRecord_Create(t *testing.T) {
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
 					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
-					presentationTodoIn := models.PresentationTodoRecord{
-						Date: utilmodels.Date(time.Date(
-							2006, time.January, 2,
-							0, 0, 0, 0,
-							time.UTC,
-						)),
-						Title:     "test",
-						Completed: true,
-						Order:     23,
-					}
-					presentationTodoOut := models.PresentationTodoRecord{
+					presentationTodo := models.PresentationTodoRecord{
 						URL: "http://example.com/api/v1/todos/12",
 						Date: utilmodels.Date(time.Date(
 							2006, time.January, 2,
@@ -1371,14 +6058,17 @@ func TestTodoRecord_Create(t *testing.T) {
 							time.UTC,
 						)),
 						Title:     "test",
-						Completed: true,
 						Order:     23,
+						Tags:      []string{},
+						BlockedBy: []int{5},
+						Blocked:   true,
+						Version:   5,
 					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
-						On("Create", baseURL, presentationTodoIn).
-						Return(presentationTodoOut, nil)
+						On("AddDependency", baseURL, 12, models.Dependency{BlockerID: 5}).
+						Return(presentationTodo, nil)
 
 					return useCase
 				}(),
@@ -1387,42 +6077,212 @@ func TestTodoRecord_Create(t *testing.T) {
 			args: args{
 				request: httptest.NewRequest(
 					http.MethodPost,
-					"http://example.com/api/v1/todos/",
-					bytes.NewReader([]byte(`{
-						"date": "2006-01-02",
-						"title": "test",
-						"completed": true,
-						"order": 23
-					}`)),
+					"http://example.com/api/v1/todos/12/blockers",
+					bytes.NewReader([]byte(`{"blocker_id": 5}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"5-5b173100"`},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":false,` +
+						`"order":23,` +
+						`"tags":[],` +
+						`"blocked_by":[5],` +
+						`"blocked":true}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on request body getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the request body: " +
+						"unable to unmarshal the JSON data: " +
+						"invalid character 'i' looking for beginning of value"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/todos/12/blockers",
+					bytes.NewReader([]byte("incorrect")),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the request body: ` +
+						`unable to unmarshal the JSON data: ` +
+						`invalid character 'i' looking for beginning of value",` +
+						`"instance":"/api/v1/todos/12/blockers",` +
+						`"code":"invalid_request_body",` +
+						`"parameter":"body"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with a cycle",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					err := fmt.Errorf(
+						"unable to add the dependency: %w",
+						models.ErrConflict,
+					)
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("AddDependency", baseURL, 12, models.Dependency{BlockerID: 5}).
+						Return(models.PresentationTodoRecord{}, err)
+
+					return useCase
+				}(),
+				Logger: func() httputils.Logger {
+					message := "unable to add the dependency: conflict"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/api/v1/todos/12/blockers",
+					bytes.NewReader([]byte(`{"blocker_id": 5}`)),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusConflict) + " " +
+					http.StatusText(http.StatusConflict),
+				StatusCode: http.StatusConflict,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Conflict",` +
+						`"status":409,` +
+						`"detail":"unable to add the dependency: conflict",` +
+						`"instance":"/api/v1/todos/12/blockers",` +
+						`"code":"record_conflict"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TodoRecord{
+				URLScheme: tt.fields.URLScheme,
+				UseCase:   tt.fields.UseCase,
+				Logger:    tt.fields.Logger,
+			}
+			handler.AddDependency(responseRecorder, tt.args.request)
+
+			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTodoRecord_DeleteDependency(t *testing.T) {
+	type fields struct {
+		URLScheme string
+		UseCase   TodoRecordUseCase
+		Logger    httputils.Logger
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.On("DeleteDependency", 12, 5).Return(nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodDelete,
+					"http://example.com/api/v1/todos/12/blockers/5",
+					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusOK) + " " +
-					http.StatusText(http.StatusOK),
-				StatusCode: http.StatusOK,
-				Proto:      "HTTP/1.1",
-				ProtoMajor: 1,
-				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
-				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					`{"url":"http://example.com/api/v1/todos/12",` +
-						`"date":"2006-01-02",` +
-						`"title":"test",` +
-						`"completed":true,` +
-						`"order":23}`,
-				))),
+				Status: strconv.Itoa(http.StatusNoContent) + " " +
+					http.StatusText(http.StatusNoContent),
+				StatusCode:    http.StatusNoContent,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error on request body getting",
+			name: "error on blocker ID getting",
 			fields: fields{
 				URLScheme: "http",
 				UseCase:   &MockTodoRecordUseCase{},
 				Logger: func() httputils.Logger {
-					message := "unable to get the request body: " +
-						"unable to unmarshal the JSON data: " +
-						"invalid character 'i' looking for beginning of value"
+					message := `unable to get the blocker ID: incorrect ID "incorrect"`
 					logger := &MockLogger{}
 					logger.InnerMock.
 						On("Print", []interface{}{message}).
@@ -1434,9 +6294,9 @@ func TestTodoRecord_Create(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodPost,
-					"http://example.com/api/v1/todos/",
-					bytes.NewReader([]byte("incorrect")),
+					http.MethodDelete,
+					"http://example.com/api/v1/todos/12/blockers/incorrect",
+					nil,
 				),
 			},
 			wantResponse: &http.Response{
@@ -1446,43 +6306,41 @@ func TestTodoRecord_Create(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get the request body: " +
-						"unable to unmarshal the JSON data: " +
-						"invalid character 'i' looking for beginning of value",
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the blocker ID: incorrect ID \"incorrect\"",` +
+						`"instance":"/api/v1/todos/12/blockers/incorrect",` +
+						`"code":"invalid_blocker_id",` +
+						`"parameter":"blocker_id"}`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error on to-do record creating",
+			name: "error with a not found dependency",
 			fields: fields{
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
-					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
-					presentationTodoIn := models.PresentationTodoRecord{
-						Date: utilmodels.Date(time.Date(
-							2006, time.January, 2,
-							0, 0, 0, 0,
-							time.UTC,
-						)),
-						Title:     "test",
-						Completed: true,
-						Order:     23,
-					}
+					err := fmt.Errorf(
+						"unable to delete the dependency: %w",
+						models.ErrNotFound,
+					)
 
 					useCase := &MockTodoRecordUseCase{}
-					useCase.InnerMock.
-						On("Create", baseURL, presentationTodoIn).
-						Return(models.PresentationTodoRecord{}, iotest.ErrTimeout)
+					useCase.InnerMock.On("DeleteDependency", 12, 5).Return(err)
 
 					return useCase
 				}(),
 				Logger: func() httputils.Logger {
+					message := "unable to delete the dependency: not found"
 					logger := &MockLogger{}
 					logger.InnerMock.
-						On("Print", []interface{}{"timeout"}).
+						On("Print", []interface{}{message}).
 						Return().
 						Times(1)
 
@@ -1491,25 +6349,29 @@ func TestTodoRecord_Create(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodPost,
-					"http://example.com/api/v1/todos/",
-					bytes.NewReader([]byte(`{
-						"date": "2006-01-02",
-						"title": "test",
-						"completed": true,
-						"order": 23
-					}`)),
+					http.MethodDelete,
+					"http://example.com/api/v1/todos/12/blockers/5",
+					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
-					http.StatusText(http.StatusInternalServerError),
-				StatusCode:    http.StatusInternalServerError,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{},
-				Body:          ioutil.NopCloser(bytes.NewReader([]byte("timeout"))),
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Not Found",` +
+						`"status":404,` +
+						`"detail":"unable to delete the dependency: not found",` +
+						`"instance":"/api/v1/todos/12/blockers/5",` +
+						`"code":"record_not_found"}`,
+				))),
 				ContentLength: -1,
 			},
 		},
@@ -1522,7 +6384,7 @@ func TestTodoRecord_Create(t *testing.T) {
 				UseCase:   tt.fields.UseCase,
 				Logger:    tt.fields.Logger,
 			}
-			handler.Create(responseRecorder, tt.args.request)
+			handler.DeleteDependency(responseRecorder, tt.args.request)
 
 			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
 			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
@@ -1531,7 +6393,7 @@ func TestTodoRecord_Create(t *testing.T) {
 	}
 }
 
-func TestTodoRecord_Update(t *testing.T) {
+func TestTodoRecord_GetHistory(t *testing.T) {
 	type fields struct {
 		URLScheme string
 		UseCase   TodoRecordUseCase
@@ -1553,32 +6415,37 @@ func TestTodoRecord_Update(t *testing.T) {
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
 					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
-					presentationTodoIn := models.PresentationTodoRecord{
-						Date: utilmodels.Date(time.Date(
-							2006, time.January, 2,
-							0, 0, 0, 0,
-							time.UTC,
-						)),
-						Title:     "test",
-						Completed: true,
-						Order:     23,
+					query := models.HistoryQuery{
+						Since: time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC),
 					}
-					presentationTodoOut := models.PresentationTodoRecord{
-						URL: "http://example.com/api/v1/todos/12",
-						Date: utilmodels.Date(time.Date(
-							2006, time.January, 2,
-							0, 0, 0, 0,
-							time.UTC,
-						)),
-						Title:     "test",
-						Completed: true,
-						Order:     23,
+					presentationRecords := []models.PresentationHistoryRecord{
+						{
+							Revision: 23,
+							TodoURL:  "http://example.com/api/v1/todos/12",
+							Action:   models.HistoryActionDelete,
+							OldValue: &models.PresentationTodoRecord{
+								URL: "http://example.com/api/v1/todos/12",
+								Date: utilmodels.Date(time.Date(
+									2006, time.January, 2,
+									0, 0, 0, 0,
+									time.UTC,
+								)),
+								Title: "test",
+								Tags:  []string{},
+							},
+							Actor: "admin",
+							ChangedAt: time.Date(
+								2006, time.January, 3,
+								15, 4, 5, 0,
+								time.UTC,
+							),
+						},
 					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
-						On("Update", baseURL, 12, presentationTodoIn).
-						Return(presentationTodoOut, nil)
+						On("GetHistory", baseURL, query).
+						Return(presentationRecords, "", nil)
 
 					return useCase
 				}(),
@@ -1586,14 +6453,9 @@ func TestTodoRecord_Update(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodPut,
-					"http://example.com/api/v1/todos/12",
-					bytes.NewReader([]byte(`{
-						"date": "2006-01-02",
-						"title": "test",
-						"completed": true,
-						"order": 23
-					}`)),
+					http.MethodGet,
+					"http://example.com/api/v1/history?since=2006-01-02T15:04:05Z",
+					nil,
 				),
 			},
 			wantResponse: &http.Response{
@@ -1603,25 +6465,99 @@ func TestTodoRecord_Update(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					`{"url":"http://example.com/api/v1/todos/12",` +
+					`[{"revision":23,` +
+						`"todo_url":"http://example.com/api/v1/todos/12",` +
+						`"action":"delete",` +
+						`"old_value":{"url":"http://example.com/api/v1/todos/12",` +
 						`"date":"2006-01-02",` +
 						`"title":"test",` +
-						`"completed":true,` +
-						`"order":23}`,
+						`"completed":false,` +
+						`"order":0,` +
+						`"tags":[]},` +
+						`"actor":"admin",` +
+						`"changed_at":"2006-01-03T15:04:05Z"}]`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error on ID getting",
+			name: "success with the page size",
+			fields: fields{
+				URLScheme: "http",
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					query := models.HistoryQuery{
+						PageSize: 1,
+						Cursor:   models.HistoryCursor{Revision: 5},
+					}
+					presentationRecords := []models.PresentationHistoryRecord{
+						{
+							Revision: 23,
+							TodoURL:  "http://example.com/api/v1/todos/12",
+							Action:   models.HistoryActionDelete,
+							ChangedAt: time.Date(
+								2006, time.January, 3,
+								15, 4, 5, 0,
+								time.UTC,
+							),
+						},
+					}
+
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetHistory", baseURL, query).
+						Return(presentationRecords, "eyJyZXZpc2lvbiI6MjN9", nil)
+
+					return useCase
+				}(),
+				Logger: &MockLogger{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/history?page_size=1&cursor=eyJyZXZpc2lvbiI6NX0",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":  {"application/json"},
+					"X-Next-Cursor": {"eyJyZXZpc2lvbiI6MjN9"},
+					"Link": {
+						`<http://example.com/api/v1/history?page_size=1>; rel="first", ` +
+							"<http://example.com/api/v1/history" +
+							`?cursor=eyJyZXZpc2lvbiI6MjN9&page_size=1>; rel="next"`,
+					},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"history":[{"revision":23,` +
+						`"todo_url":"http://example.com/api/v1/todos/12",` +
+						`"action":"delete",` +
+						`"changed_at":"2006-01-03T15:04:05Z"}],` +
+						`"next_cursor":"eyJyZXZpc2lvbiI6MjN9"}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the since parameter",
 			fields: fields{
 				URLScheme: "http",
 				UseCase:   &MockTodoRecordUseCase{},
 				Logger: func() httputils.Logger {
-					message := "unable to get an ID: " +
-						"unable to find an ID"
+					message := "unable to get the since parameter: " +
+						`parsing time "2006-01-02" as "2006-01-02T15:04:05Z07:00": ` +
+						`cannot parse "" as "T"`
 					logger := &MockLogger{}
 					logger.InnerMock.
 						On("Print", []interface{}{message}).
@@ -1633,14 +6569,9 @@ func TestTodoRecord_Update(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodPut,
-					"http://example.com/api/v1/todos/",
-					bytes.NewReader([]byte(`{
-						"date": "2006-01-02",
-						"title": "test",
-						"completed": true,
-						"order": 23
-					}`)),
+					http.MethodGet,
+					"http://example.com/api/v1/history?since=2006-01-02",
+					nil,
 				),
 			},
 			wantResponse: &http.Response{
@@ -1650,23 +6581,30 @@ func TestTodoRecord_Update(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get an ID: " +
-						"unable to find an ID",
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the since parameter: ` +
+						`parsing time \"2006-01-02\" as \"2006-01-02T15:04:05Z07:00\": ` +
+						`cannot parse \"\" as \"T\"",` +
+						`"instance":"/api/v1/history?since=2006-01-02",` +
+						`"code":"invalid_since",` +
+						`"parameter":"since"}`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error on request body getting",
+			name: "error with the cursor without the page size",
 			fields: fields{
 				URLScheme: "http",
 				UseCase:   &MockTodoRecordUseCase{},
 				Logger: func() httputils.Logger {
-					message := "unable to get the request body: " +
-						"unable to unmarshal the JSON data: " +
-						"invalid character 'i' looking for beginning of value"
+					message := "the cursor parameter requires the page_size one"
 					logger := &MockLogger{}
 					logger.InnerMock.
 						On("Print", []interface{}{message}).
@@ -1678,9 +6616,9 @@ func TestTodoRecord_Update(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodPut,
-					"http://example.com/api/v1/todos/12",
-					bytes.NewReader([]byte("incorrect")),
+					http.MethodGet,
+					"http://example.com/api/v1/history?cursor=eyJyZXZpc2lvbiI6NX0",
+					nil,
 				),
 			},
 			wantResponse: &http.Response{
@@ -1690,36 +6628,32 @@ func TestTodoRecord_Update(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get the request body: " +
-						"unable to unmarshal the JSON data: " +
-						"invalid character 'i' looking for beginning of value",
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"the cursor parameter requires the page_size one",` +
+						`"instance":"/api/v1/history?cursor=eyJyZXZpc2lvbiI6NX0",` +
+						`"code":"invalid_cursor",` +
+						`"parameter":"cursor"}`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error on to-do record updating",
+			name: "error with the use case",
 			fields: fields{
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
 					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
-					presentationTodoIn := models.PresentationTodoRecord{
-						Date: utilmodels.Date(time.Date(
-							2006, time.January, 2,
-							0, 0, 0, 0,
-							time.UTC,
-						)),
-						Title:     "test",
-						Completed: true,
-						Order:     23,
-					}
 
 					useCase := &MockTodoRecordUseCase{}
 					useCase.InnerMock.
-						On("Update", baseURL, 12, presentationTodoIn).
-						Return(models.PresentationTodoRecord{}, iotest.ErrTimeout)
+						On("GetHistory", baseURL, models.HistoryQuery{}).
+						Return([]models.PresentationHistoryRecord(nil), "", iotest.ErrTimeout)
 
 					return useCase
 				}(),
@@ -1735,25 +6669,29 @@ func TestTodoRecord_Update(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodPut,
-					"http://example.com/api/v1/todos/12",
-					bytes.NewReader([]byte(`{
-						"date": "2006-01-02",
-						"title": "test",
-						"completed": true,
-						"order": 23
-					}`)),
+					http.MethodGet,
+					"http://example.com/api/v1/history",
+					nil,
 				),
 			},
 			wantResponse: &http.Response{
 				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
 					http.StatusText(http.StatusInternalServerError),
-				StatusCode:    http.StatusInternalServerError,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{},
-				Body:          ioutil.NopCloser(bytes.NewReader([]byte("timeout"))),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Internal Server Error",` +
+						`"status":500,` +
+						`"detail":"timeout",` +
+						`"instance":"/api/v1/history",` +
+						`"code":"internal_error"}`,
+				))),
 				ContentLength: -1,
 			},
 		},
@@ -1766,7 +6704,7 @@ func TestTodoRecord_Update(t *testing.T) {
 				UseCase:   tt.fields.UseCase,
 				Logger:    tt.fields.Logger,
 			}
-			handler.Update(responseRecorder, tt.args.request)
+			handler.GetHistory(responseRecorder, tt.args.request)
 
 			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
 			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
@@ -1775,7 +6713,7 @@ func TestTodoRecord_Update(t *testing.T) {
 	}
 }
 
-func TestTodoRecord_Patch(t *testing.T) {
+func TestTodoRecord_GetHistoryByID(t *testing.T) {
 	type fields struct {
 		URLScheme string
 		UseCase   TodoRecordUseCase
@@ -1787,112 +6725,56 @@ func TestTodoRecord_Patch(t *testing.T) {
 
 	tests := []struct {
 		name         string
-		fields       fields
-		args         args
-		wantResponse *http.Response
-	}{
-		{
-			name: "success",
-			fields: fields{
-				URLScheme: "http",
-				UseCase: func() TodoRecordUseCase {
-					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
-					todoPatchTitle := "test"
-					todoPatch := models.TodoRecordPatch{Title: &todoPatchTitle}
-					presentationTodo := models.PresentationTodoRecord{
-						URL: "http://example.com/api/v1/todos/12",
-						Date: utilmodels.Date(time.Date(
-							2006, time.January, 2,
-							0, 0, 0, 0,
-							time.UTC,
-						)),
-						Title:     "test",
-						Completed: true,
-						Order:     23,
-					}
-
-					useCase := &MockTodoRecordUseCase{}
-					useCase.InnerMock.
-						On("Patch", baseURL, 12, todoPatch).
-						Return(presentationTodo, nil)
-
-					return useCase
-				}(),
-				Logger: &MockLogger{},
-			},
-			args: args{
-				request: httptest.NewRequest(
-					http.MethodPatch,
-					"http://example.com/api/v1/todos/12",
-					bytes.NewReader([]byte(`{"title": "test"}`)),
-				),
-			},
-			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusOK) + " " +
-					http.StatusText(http.StatusOK),
-				StatusCode: http.StatusOK,
-				Proto:      "HTTP/1.1",
-				ProtoMajor: 1,
-				ProtoMinor: 1,
-				Header:     http.Header{"Content-Type": {"application/json"}},
-				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					`{"url":"http://example.com/api/v1/todos/12",` +
-						`"date":"2006-01-02",` +
-						`"title":"test",` +
-						`"completed":true,` +
-						`"order":23}`,
-				))),
-				ContentLength: -1,
-			},
-		},
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
 		{
-			name: "error on ID getting",
+			name: "success",
 			fields: fields{
 				URLScheme: "http",
-				UseCase:   &MockTodoRecordUseCase{},
-				Logger: func() httputils.Logger {
-					message := "unable to get an ID: " +
-						"unable to find an ID"
-					logger := &MockLogger{}
-					logger.InnerMock.
-						On("Print", []interface{}{message}).
-						Return().
-						Times(1)
+				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationRecords := []models.PresentationHistoryRecord{}
 
-					return logger
+					useCase := &MockTodoRecordUseCase{}
+					useCase.InnerMock.
+						On("GetHistory", baseURL, models.HistoryQuery{TodoRecordID: 12}).
+						Return(presentationRecords, "", nil)
+
+					return useCase
 				}(),
+				Logger: &MockLogger{},
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodPatch,
-					"http://example.com/api/v1/todos/",
-					bytes.NewReader([]byte(`{"title": "test"}`)),
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12/history",
+					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusBadRequest) + " " +
-					http.StatusText(http.StatusBadRequest),
-				StatusCode: http.StatusBadRequest,
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
-				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get an ID: " +
-						"unable to find an ID",
-				))),
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error on request body getting",
+			name: "error on ID getting",
 			fields: fields{
 				URLScheme: "http",
 				UseCase:   &MockTodoRecordUseCase{},
 				Logger: func() httputils.Logger {
-					message := "unable to get the request body: " +
-						"unable to unmarshal the JSON data: " +
-						"invalid character 'i' looking for beginning of value"
+					message := "unable to get an ID: " +
+						"unable to find an ID"
 					logger := &MockLogger{}
 					logger.InnerMock.
 						On("Print", []interface{}{message}).
@@ -1904,9 +6786,9 @@ func TestTodoRecord_Patch(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodPatch,
-					"http://example.com/api/v1/todos/12",
-					bytes.NewReader([]byte("incorrect")),
+					http.MethodGet,
+					"http://example.com/api/v1/todos/history",
+					nil,
 				),
 			},
 			wantResponse: &http.Response{
@@ -1916,60 +6798,22 @@ func TestTodoRecord_Patch(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get the request body: " +
-						"unable to unmarshal the JSON data: " +
-						"invalid character 'i' looking for beginning of value",
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get an ID: ` +
+						`unable to find an ID",` +
+						`"instance":"/api/v1/todos/history",` +
+						`"code":"invalid_id",` +
+						`"parameter":"id"}`,
 				))),
 				ContentLength: -1,
 			},
 		},
-		{
-			name: "error on to-do record patching",
-			fields: fields{
-				URLScheme: "http",
-				UseCase: func() TodoRecordUseCase {
-					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
-					todoPatchTitle := "test"
-					todoPatch := models.TodoRecordPatch{Title: &todoPatchTitle}
-
-					useCase := &MockTodoRecordUseCase{}
-					useCase.InnerMock.
-						On("Patch", baseURL, 12, todoPatch).
-						Return(models.PresentationTodoRecord{}, iotest.ErrTimeout)
-
-					return useCase
-				}(),
-				Logger: func() httputils.Logger {
-					logger := &MockLogger{}
-					logger.InnerMock.
-						On("Print", []interface{}{"timeout"}).
-						Return().
-						Times(1)
-
-					return logger
-				}(),
-			},
-			args: args{
-				request: httptest.NewRequest(
-					http.MethodPatch,
-					"http://example.com/api/v1/todos/12",
-					bytes.NewReader([]byte(`{"title": "test"}`)),
-				),
-			},
-			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
-					http.StatusText(http.StatusInternalServerError),
-				StatusCode:    http.StatusInternalServerError,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{},
-				Body:          ioutil.NopCloser(bytes.NewReader([]byte("timeout"))),
-				ContentLength: -1,
-			},
-		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1979,7 +6823,7 @@ func TestTodoRecord_Patch(t *testing.T) {
 				UseCase:   tt.fields.UseCase,
 				Logger:    tt.fields.Logger,
 			}
-			handler.Patch(responseRecorder, tt.args.request)
+			handler.GetHistoryByID(responseRecorder, tt.args.request)
 
 			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
 			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
@@ -1988,7 +6832,7 @@ func TestTodoRecord_Patch(t *testing.T) {
 	}
 }
 
-func TestTodoRecord_DeleteAll(t *testing.T) {
+func TestTodoRecord_Revert(t *testing.T) {
 	type fields struct {
 		URLScheme string
 		UseCase   TodoRecordUseCase
@@ -2009,46 +6853,146 @@ func TestTodoRecord_DeleteAll(t *testing.T) {
 			fields: fields{
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodo := models.PresentationTodoRecord{
+						URL: "http://example.com/api/v1/todos/12",
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:   "test",
+						Tags:    []string{},
+						Version: 6,
+					}
+
 					useCase := &MockTodoRecordUseCase{}
-					useCase.InnerMock.On("DeleteAll").Return(nil)
+					useCase.InnerMock.
+						On("GetSingle", baseURL, 12).
+						Return(
+							models.PresentationTodoRecord{
+								URL:     "http://example.com/api/v1/todos/12",
+								Title:   "test",
+								Version: 5,
+							},
+							nil,
+						)
+					useCase.InnerMock.
+						On("Revert", baseURL, 12, 23, 5, "admin").
+						Return(presentationTodo, nil)
 
 					return useCase
 				}(),
 				Logger: &MockLogger{},
 			},
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(
+						http.MethodPost,
+						"http://example.com/api/v1/todos/12/revert?revision=23",
+						nil,
+					)
+					request.Header.Set("If-Match", `"5"`)
+					request.Header.Set("X-Actor", "admin")
+
+					return request
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Etag":         {`"6"`},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":false,` +
+						`"order":0,` +
+						`"tags":[]}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error on revision getting",
+			fields: fields{
+				URLScheme: "http",
+				UseCase:   &MockTodoRecordUseCase{},
+				Logger: func() httputils.Logger {
+					message := "unable to get the revision parameter: " +
+						"key is missed"
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{message}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodDelete,
-					"http://example.com/api/v1/todos",
+					http.MethodPost,
+					"http://example.com/api/v1/todos/12/revert",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusNoContent) + " " +
-					http.StatusText(http.StatusNoContent),
-				StatusCode:    http.StatusNoContent,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{},
-				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the revision parameter: ` +
+						`key is missed",` +
+						`"instance":"/api/v1/todos/12/revert",` +
+						`"code":"invalid_revision",` +
+						`"parameter":"revision"}`,
+				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error",
+			name: "error with a not found revision",
 			fields: fields{
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					err := fmt.Errorf(
+						"unable to revert the to-do record: "+
+							"unable to get the revision: %w",
+						models.ErrNotFound,
+					)
+
 					useCase := &MockTodoRecordUseCase{}
-					useCase.InnerMock.On("DeleteAll").Return(iotest.ErrTimeout)
+					useCase.InnerMock.
+						On("Revert", baseURL, 12, 23, 0, "").
+						Return(models.PresentationTodoRecord{}, err)
 
 					return useCase
 				}(),
 				Logger: func() httputils.Logger {
+					message := "unable to revert the to-do record: " +
+						"unable to get the revision: not found"
+
 					logger := &MockLogger{}
 					logger.InnerMock.
-						On("Print", []interface{}{"timeout"}).
+						On("Print", []interface{}{message}).
 						Return().
 						Times(1)
 
@@ -2057,20 +7001,30 @@ func TestTodoRecord_DeleteAll(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodDelete,
-					"http://example.com/api/v1/todos",
+					http.MethodPost,
+					"http://example.com/api/v1/todos/12/revert?revision=23",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
-					http.StatusText(http.StatusInternalServerError),
-				StatusCode:    http.StatusInternalServerError,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{},
-				Body:          ioutil.NopCloser(bytes.NewReader([]byte("timeout"))),
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Not Found",` +
+						`"status":404,` +
+						`"detail":"unable to revert the to-do record: ` +
+						`unable to get the revision: not found",` +
+						`"instance":"/api/v1/todos/12/revert?revision=23",` +
+						`"code":"record_not_found"}`,
+				))),
 				ContentLength: -1,
 			},
 		},
@@ -2083,7 +7037,7 @@ func TestTodoRecord_DeleteAll(t *testing.T) {
 				UseCase:   tt.fields.UseCase,
 				Logger:    tt.fields.Logger,
 			}
-			handler.DeleteAll(responseRecorder, tt.args.request)
+			handler.Revert(responseRecorder, tt.args.request)
 
 			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
 			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
@@ -2092,7 +7046,7 @@ func TestTodoRecord_DeleteAll(t *testing.T) {
 	}
 }
 
-func TestTodoRecord_DeleteSingle(t *testing.T) {
+func TestTodoRecord_GetOccurrences(t *testing.T) {
 	type fields struct {
 		URLScheme string
 		UseCase   TodoRecordUseCase
@@ -2113,8 +7067,42 @@ func TestTodoRecord_DeleteSingle(t *testing.T) {
 			fields: fields{
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					presentationTodos := []models.PresentationTodoRecord{
+						{
+							URL: "http://example.com/api/v1/todos/12",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 2,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title:      "test",
+							Tags:       []string{},
+							Recurrence: "FREQ=WEEKLY",
+						},
+						{
+							URL: "http://example.com/api/v1/todos/12",
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 9,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title:      "test",
+							Tags:       []string{},
+							Recurrence: "FREQ=WEEKLY",
+						},
+					}
+
 					useCase := &MockTodoRecordUseCase{}
-					useCase.InnerMock.On("DeleteSingle", 12).Return(nil)
+					useCase.InnerMock.
+						On(
+							"GetOccurrences",
+							baseURL,
+							12,
+							time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+							time.Date(2006, time.January, 14, 0, 0, 0, 0, time.UTC),
+						).
+						Return(presentationTodos, nil)
 
 					return useCase
 				}(),
@@ -2122,31 +7110,49 @@ func TestTodoRecord_DeleteSingle(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodDelete,
-					"http://example.com/api/v1/todos/12",
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12/occurrences"+
+						"?minimal_date=2006-01-01&maximal_date=2006-01-14",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusNoContent) + " " +
-					http.StatusText(http.StatusNoContent),
-				StatusCode:    http.StatusNoContent,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{},
-				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-02",` +
+						`"title":"test",` +
+						`"completed":false,` +
+						`"order":0,` +
+						`"tags":[],` +
+						`"recurrence":"FREQ=WEEKLY"},` +
+						`{"url":"http://example.com/api/v1/todos/12",` +
+						`"date":"2006-01-09",` +
+						`"title":"test",` +
+						`"completed":false,` +
+						`"order":0,` +
+						`"tags":[],` +
+						`"recurrence":"FREQ=WEEKLY"}]`,
+				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error on ID getting",
+			name: "error without the maximal_date parameter",
 			fields: fields{
 				URLScheme: "http",
 				UseCase:   &MockTodoRecordUseCase{},
 				Logger: func() httputils.Logger {
-					message := "unable to get an ID: " +
-						"unable to find an ID"
+					message := "unable to get the maximal_date parameter: " +
+						"key is missed"
 					logger := &MockLogger{}
 					logger.InnerMock.
 						On("Print", []interface{}{message}).
@@ -2158,8 +7164,9 @@ func TestTodoRecord_DeleteSingle(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodDelete,
-					"http://example.com/api/v1/todos/",
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12/occurrences"+
+						"?minimal_date=2006-01-01",
 					nil,
 				),
 			},
@@ -2170,28 +7177,53 @@ func TestTodoRecord_DeleteSingle(t *testing.T) {
 				Proto:      "HTTP/1.1",
 				ProtoMajor: 1,
 				ProtoMinor: 1,
-				Header:     http.Header{},
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
 				Body: ioutil.NopCloser(bytes.NewReader([]byte(
-					"unable to get an ID: " +
-						"unable to find an ID",
+					`{"type":"about:blank",` +
+						`"title":"Bad Request",` +
+						`"status":400,` +
+						`"detail":"unable to get the maximal_date parameter: ` +
+						`key is missed",` +
+						`"instance":"/api/v1/todos/12/occurrences` +
+						`?minimal_date=2006-01-01",` +
+						`"code":"invalid_date",` +
+						`"parameter":"maximal_date"}`,
 				))),
 				ContentLength: -1,
 			},
 		},
 		{
-			name: "error on to-do record deleting",
+			name: "error with a not found to-do record",
 			fields: fields{
 				URLScheme: "http",
 				UseCase: func() TodoRecordUseCase {
+					baseURL := &url.URL{Scheme: "http", Host: "example.com"}
+					err := fmt.Errorf(
+						"unable to get the to-do record: %w",
+						models.ErrNotFound,
+					)
+
 					useCase := &MockTodoRecordUseCase{}
-					useCase.InnerMock.On("DeleteSingle", 12).Return(iotest.ErrTimeout)
+					useCase.InnerMock.
+						On(
+							"GetOccurrences",
+							baseURL,
+							12,
+							time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+							time.Date(2006, time.January, 14, 0, 0, 0, 0, time.UTC),
+						).
+						Return([]models.PresentationTodoRecord(nil), err)
 
 					return useCase
 				}(),
 				Logger: func() httputils.Logger {
+					message := "unable to get the to-do record: not found"
+
 					logger := &MockLogger{}
 					logger.InnerMock.
-						On("Print", []interface{}{"timeout"}).
+						On("Print", []interface{}{message}).
 						Return().
 						Times(1)
 
@@ -2200,20 +7232,32 @@ func TestTodoRecord_DeleteSingle(t *testing.T) {
 			},
 			args: args{
 				request: httptest.NewRequest(
-					http.MethodDelete,
-					"http://example.com/api/v1/todos/12",
+					http.MethodGet,
+					"http://example.com/api/v1/todos/12/occurrences"+
+						"?minimal_date=2006-01-01&maximal_date=2006-01-14",
 					nil,
 				),
 			},
 			wantResponse: &http.Response{
-				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
-					http.StatusText(http.StatusInternalServerError),
-				StatusCode:    http.StatusInternalServerError,
-				Proto:         "HTTP/1.1",
-				ProtoMajor:    1,
-				ProtoMinor:    1,
-				Header:        http.Header{},
-				Body:          ioutil.NopCloser(bytes.NewReader([]byte("timeout"))),
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/problem+json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"type":"about:blank",` +
+						`"title":"Not Found",` +
+						`"status":404,` +
+						`"detail":"unable to get the to-do record: ` +
+						`not found",` +
+						`"instance":"/api/v1/todos/12/occurrences` +
+						`?minimal_date=2006-01-01\u0026maximal_date=2006-01-14",` +
+						`"code":"record_not_found"}`,
+				))),
 				ContentLength: -1,
 			},
 		},
@@ -2226,7 +7270,7 @@ func TestTodoRecord_DeleteSingle(t *testing.T) {
 				UseCase:   tt.fields.UseCase,
 				Logger:    tt.fields.Logger,
 			}
-			handler.DeleteSingle(responseRecorder, tt.args.request)
+			handler.GetOccurrences(responseRecorder, tt.args.request)
 
 			tt.fields.UseCase.(*MockTodoRecordUseCase).InnerMock.AssertExpectations(t)
 			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
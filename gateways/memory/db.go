@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+)
+
+// DB ...
+type DB struct {
+	locker           sync.RWMutex
+	lastTodoRecordID int
+	todoRecords      map[int]models.TodoRecord
+	todoDependencies map[dependency]struct{}
+	lastListID       int
+	lists            map[int]models.List
+	// historyRecords are ordered by the revision, which is the index plus one
+	historyRecords []models.HistoryRecord
+}
+
+// NewDB ...
+func NewDB() *DB {
+	return &DB{
+		todoRecords:      map[int]models.TodoRecord{},
+		todoDependencies: map[dependency]struct{}{},
+		lists:            map[int]models.List{},
+	}
+}
+
+// dependency means the to-do record is blocked by the blocker one.
+type dependency struct {
+	todoRecordID int
+	blockerID    int
+}
+
+type snapshot struct {
+	lastTodoRecordID int
+	todoRecords      map[int]models.TodoRecord
+	todoDependencies map[dependency]struct{}
+	historyRecords   []models.HistoryRecord
+}
+
+// makeSnapshot doesn't copy the tags of the to-do records,
+// because the storages never modify them in place.
+// Likewise, the history records are only appended, so the snapshot
+// just keeps the current length of their slice.
+func (db *DB) makeSnapshot() snapshot {
+	todoRecords := make(map[int]models.TodoRecord, len(db.todoRecords))
+	for id, todo := range db.todoRecords {
+		todoRecords[id] = todo
+	}
+
+	todoDependencies :=
+		make(map[dependency]struct{}, len(db.todoDependencies))
+	for dependency := range db.todoDependencies {
+		todoDependencies[dependency] = struct{}{}
+	}
+
+	return snapshot{
+		lastTodoRecordID: db.lastTodoRecordID,
+		todoRecords:      todoRecords,
+		todoDependencies: todoDependencies,
+		historyRecords:   db.historyRecords,
+	}
+}
+
+func (db *DB) restoreSnapshot(snapshot snapshot) {
+	db.lastTodoRecordID = snapshot.lastTodoRecordID
+	db.todoRecords = snapshot.todoRecords
+	db.todoDependencies = snapshot.todoDependencies
+	db.historyRecords = snapshot.historyRecords
+}
@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	usecases "github.com/irenicaa/go-todo-backend/v2/use-cases"
+)
+
+// List ...
+type List struct {
+	db *DB
+}
+
+// NewList ...
+func NewList(db *DB) List {
+	return List{db: db}
+}
+
+// GetAll ...
+func (storage List) GetAll() ([]models.List, error) {
+	storage.db.locker.RLock()
+	defer storage.db.locker.RUnlock()
+
+	var lists []models.List
+	for _, list := range storage.db.lists {
+		lists = append(lists, list)
+	}
+
+	sort.Slice(lists, func(i int, j int) bool {
+		return lists[i].ID < lists[j].ID
+	})
+	return lists, nil
+}
+
+// GetSingle ...
+func (storage List) GetSingle(id int) (models.List, error) {
+	storage.db.locker.RLock()
+	defer storage.db.locker.RUnlock()
+
+	list, ok := storage.db.lists[id]
+	if !ok {
+		return models.List{}, models.ErrNotFound
+	}
+
+	return list, nil
+}
+
+// Create ...
+func (storage List) Create(list models.List) (models.List, error) {
+	storage.db.locker.Lock()
+	defer storage.db.locker.Unlock()
+
+	storage.db.lastListID++
+	list.ID = storage.db.lastListID
+	storage.db.lists[list.ID] = list
+
+	return list, nil
+}
+
+// Update ...
+func (storage List) Update(id int, list models.List) (models.List, error) {
+	storage.db.locker.Lock()
+	defer storage.db.locker.Unlock()
+
+	if _, ok := storage.db.lists[id]; !ok {
+		return models.List{}, models.ErrNotFound
+	}
+
+	list.ID = id
+	storage.db.lists[id] = list
+
+	return list, nil
+}
+
+// DeleteSingle ...
+func (storage List) DeleteSingle(
+	id int,
+	handler func(todoRecordStorage usecases.TodoRecordStorage) error,
+) error {
+	storage.db.locker.Lock()
+	defer storage.db.locker.Unlock()
+
+	if _, ok := storage.db.lists[id]; !ok {
+		return models.ErrNotFound
+	}
+
+	snapshot := storage.db.makeSnapshot()
+	err := handler(TodoRecord{db: storage.db, isInTransaction: true})
+	if err != nil {
+		storage.db.restoreSnapshot(snapshot)
+		return err
+	}
+
+	// emulate the foreign key of the DB storages
+	// for the trashed to-do records
+	for todoID, todo := range storage.db.todoRecords {
+		if todo.ListID == id {
+			todo.ListID = 0
+			storage.db.todoRecords[todoID] = todo
+		}
+	}
+
+	delete(storage.db.lists, id)
+	return nil
+}
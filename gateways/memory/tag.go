@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+)
+
+// Tag ...
+type Tag struct {
+	db *DB
+}
+
+// NewTag ...
+func NewTag(db *DB) Tag {
+	return Tag{db: db}
+}
+
+// GetAll ...
+func (storage Tag) GetAll() ([]models.Tag, error) {
+	storage.db.locker.RLock()
+	defer storage.db.locker.RUnlock()
+
+	counts := map[string]int{}
+	for _, todo := range storage.db.todoRecords {
+		if todo.DeletedAt != nil {
+			continue
+		}
+
+		for _, tag := range todo.Tags {
+			counts[tag]++
+		}
+	}
+
+	var tags []models.Tag
+	for name, count := range counts {
+		tags = append(tags, models.Tag{Name: name, Count: count})
+	}
+
+	sort.Slice(tags, func(i int, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+
+		return tags[i].Name < tags[j].Name
+	})
+	return tags, nil
+}
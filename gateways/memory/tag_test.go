@@ -0,0 +1,11 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/irenicaa/go-todo-backend/v2/gateways/storagetest"
+)
+
+func TestTag(t *testing.T) {
+	storagetest.RunTagTests(t, newStorages)
+}
@@ -0,0 +1,755 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	usecases "github.com/irenicaa/go-todo-backend/v2/use-cases"
+)
+
+// TodoRecord ...
+type TodoRecord struct {
+	db *DB
+	// isInTransaction means the DB is locked by the InTransaction() method
+	isInTransaction bool
+}
+
+// NewTodoRecord ...
+func NewTodoRecord(db *DB) TodoRecord {
+	return TodoRecord{db: db}
+}
+
+// GetAll ...
+func (storage TodoRecord) GetAll(query models.Query) (
+	[]models.TodoRecord,
+	error,
+) {
+	defer storage.rLock()()
+
+	var todos []models.TodoRecord
+	for _, todo := range storage.db.todoRecords {
+		todo = storage.addComputedFields(todo)
+		if !matchQuery(todo, query) {
+			continue
+		}
+
+		todos = append(todos, models.HighlightTodoRecord(todo, query))
+	}
+
+	sortFields := models.CompleteSort(query.Sort, models.DefaultSort)
+	sort.Slice(todos, func(i int, j int) bool {
+		for _, field := range sortFields {
+			// emulate NULLS LAST of the DB storages
+			if field.Name == models.SortByCompletedAt &&
+				(todos[i].CompletedAt == nil) != (todos[j].CompletedAt == nil) {
+				return todos[j].CompletedAt == nil
+			}
+
+			result := compareTodoRecords(todos[i], todos[j], field.Name)
+			if result != 0 {
+				return (result < 0) != field.Descending
+			}
+		}
+
+		return false
+	})
+	if query.Pagination != (models.Pagination{}) {
+		todos = paginate(todos, query.Pagination, sortFields)
+	}
+
+	return todos, nil
+}
+
+// Count ...
+func (storage TodoRecord) Count(query models.Query) (int, error) {
+	defer storage.rLock()()
+
+	var count int
+	for _, todo := range storage.db.todoRecords {
+		if matchQuery(storage.addComputedFields(todo), query) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// GetSingle ...
+func (storage TodoRecord) GetSingle(id int) (models.TodoRecord, error) {
+	defer storage.rLock()()
+
+	todo, ok := storage.db.todoRecords[id]
+	if !ok || todo.DeletedAt != nil {
+		return models.TodoRecord{}, models.ErrNotFound
+	}
+
+	return storage.addComputedFields(todo), nil
+}
+
+// Create ...
+func (storage TodoRecord) Create(todo models.TodoRecord) (
+	models.TodoRecord,
+	error,
+) {
+	defer storage.lock()()
+
+	if err := storage.checkListID(todo.ListID); err != nil {
+		return models.TodoRecord{}, err
+	}
+	if err := storage.checkParentID(todo.ParentID); err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	storage.db.lastTodoRecordID++
+	todo.ID = storage.db.lastTodoRecordID
+	todo.DueAt = copyTimestamp(todo.DueAt)
+	todo.Tags = normalizeTags(todo.Tags)
+	todo.Version = 1
+	todo.CreatedAt = time.Now()
+	todo.UpdatedAt = todo.CreatedAt
+	todo.CompletedAt = nil
+	updateCompletedAt(&todo, false)
+	storage.db.todoRecords[todo.ID] = todo
+
+	return storage.addComputedFields(todo), nil
+}
+
+// Update ...
+func (storage TodoRecord) Update(
+	id int,
+	todo models.TodoRecord,
+	version int,
+) (models.TodoRecord, error) {
+	defer storage.lock()()
+
+	existingTodo, err := storage.getSingleWithVersion(id, version)
+	if err != nil {
+		return models.TodoRecord{}, err
+	}
+	if err := storage.checkListID(todo.ListID); err != nil {
+		return models.TodoRecord{}, err
+	}
+	if err := storage.checkParentID(todo.ParentID); err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	todo.ID = id
+	todo.DueAt = copyTimestamp(todo.DueAt)
+	todo.Tags = normalizeTags(todo.Tags)
+	todo.Version = existingTodo.Version + 1
+	todo.CreatedAt = existingTodo.CreatedAt
+	todo.UpdatedAt = time.Now()
+	todo.CompletedAt = existingTodo.CompletedAt
+	updateCompletedAt(&todo, existingTodo.Completed)
+	storage.db.todoRecords[id] = todo
+
+	return storage.addComputedFields(todo), nil
+}
+
+// Patch ...
+func (storage TodoRecord) Patch(
+	id int,
+	todoPatch models.TodoRecordPatch,
+	version int,
+) (models.TodoRecord, error) {
+	defer storage.lock()()
+
+	todo, err := storage.getSingleWithVersion(id, version)
+	if err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	wasCompleted := todo.Completed
+	todo.Patch(todoPatch)
+	if err := storage.checkListID(todo.ListID); err != nil {
+		return models.TodoRecord{}, err
+	}
+	if err := storage.checkParentID(todo.ParentID); err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	todo.Tags = normalizeTags(todo.Tags)
+	todo.Version++
+	todo.UpdatedAt = time.Now()
+	updateCompletedAt(&todo, wasCompleted)
+	storage.db.todoRecords[id] = todo
+
+	return storage.addComputedFields(todo), nil
+}
+
+// DeleteAll moves the to-do records to the trash;
+// it ignores the pagination and sorting of the query.
+func (storage TodoRecord) DeleteAll(query models.Query) (int, error) {
+	defer storage.lock()()
+
+	query.Deleted = false
+	deletedAt := time.Now()
+
+	var count int
+	for id, todo := range storage.db.todoRecords {
+		if matchQuery(storage.addComputedFields(todo), query) {
+			todo.DeletedAt = &deletedAt
+			storage.db.todoRecords[id] = todo
+
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// DeleteSingle moves the to-do record to the trash.
+func (storage TodoRecord) DeleteSingle(id int, version int) error {
+	defer storage.lock()()
+
+	todo, err := storage.getSingleWithVersion(id, version)
+	if err != nil {
+		return err
+	}
+
+	deletedAt := time.Now()
+	todo.DeletedAt = &deletedAt
+	storage.db.todoRecords[id] = todo
+
+	return nil
+}
+
+// Restore moves the to-do record back from the trash.
+func (storage TodoRecord) Restore(id int) (models.TodoRecord, error) {
+	defer storage.lock()()
+
+	todo, ok := storage.db.todoRecords[id]
+	if !ok || todo.DeletedAt == nil {
+		return models.TodoRecord{}, models.ErrNotFound
+	}
+
+	todo.DeletedAt = nil
+	storage.db.todoRecords[id] = todo
+
+	return storage.addComputedFields(todo), nil
+}
+
+// Purge deletes permanently the to-do records trashed
+// at least the retention ago.
+func (storage TodoRecord) Purge(retention time.Duration) (int, error) {
+	defer storage.lock()()
+
+	maximalDeletedAt := time.Now().Add(-retention)
+
+	var count int
+	for id, todo := range storage.db.todoRecords {
+		if todo.DeletedAt != nil && !todo.DeletedAt.After(maximalDeletedAt) {
+			delete(storage.db.todoRecords, id)
+			count++
+		}
+	}
+
+	// emulate the foreign key of the DB storages
+	for id, todo := range storage.db.todoRecords {
+		_, ok := storage.db.todoRecords[todo.ParentID]
+		if todo.ParentID != 0 && !ok {
+			todo.ParentID = 0
+			storage.db.todoRecords[id] = todo
+		}
+	}
+	for dependency := range storage.db.todoDependencies {
+		_, ok := storage.db.todoRecords[dependency.todoRecordID]
+		_, isBlockerOK := storage.db.todoRecords[dependency.blockerID]
+		if !ok || !isBlockerOK {
+			delete(storage.db.todoDependencies, dependency)
+		}
+	}
+
+	return count, nil
+}
+
+// AddDependency ignores the existing dependency.
+func (storage TodoRecord) AddDependency(id int, blockerID int) error {
+	defer storage.lock()()
+
+	// emulate the foreign keys of the DB storages
+	for _, todoID := range []int{id, blockerID} {
+		if _, ok := storage.db.todoRecords[todoID]; !ok {
+			return fmt.Errorf(
+				"%w: the to-do record #%d doesn't exist",
+				models.ErrConflict,
+				todoID,
+			)
+		}
+	}
+
+	todoDependency := dependency{todoRecordID: id, blockerID: blockerID}
+	storage.db.todoDependencies[todoDependency] = struct{}{}
+	return nil
+}
+
+// DeleteDependency ...
+func (storage TodoRecord) DeleteDependency(id int, blockerID int) error {
+	defer storage.lock()()
+
+	todoDependency := dependency{todoRecordID: id, blockerID: blockerID}
+	if _, ok := storage.db.todoDependencies[todoDependency]; !ok {
+		return models.ErrNotFound
+	}
+
+	delete(storage.db.todoDependencies, todoDependency)
+	return nil
+}
+
+// GetBlockerIDs includes the trashed blockers.
+func (storage TodoRecord) GetBlockerIDs(id int) ([]int, error) {
+	defer storage.rLock()()
+
+	return storage.getBlockerIDs(id), nil
+}
+
+// InTransaction ...
+//
+// It emulates the savepoints of the DB storages: a failed nested call
+// rolls back only its own changes.
+func (storage TodoRecord) InTransaction(
+	handler func(storage usecases.TodoRecordStorage) error,
+) error {
+	defer storage.lock()()
+
+	snapshot := storage.db.makeSnapshot()
+	err := handler(TodoRecord{db: storage.db, isInTransaction: true})
+	if err != nil {
+		storage.db.restoreSnapshot(snapshot)
+		return err
+	}
+
+	return nil
+}
+
+// lock returns the unlocking function; it does nothing inside a transaction,
+// which already holds the lock.
+func (storage TodoRecord) lock() func() {
+	if storage.isInTransaction {
+		return func() {}
+	}
+
+	storage.db.locker.Lock()
+	return storage.db.locker.Unlock
+}
+
+// rLock is the read-only version of the lock() method.
+func (storage TodoRecord) rLock() func() {
+	if storage.isInTransaction {
+		return func() {}
+	}
+
+	storage.db.locker.RLock()
+	return storage.db.locker.RUnlock
+}
+
+func (storage TodoRecord) getSingleWithVersion(id int, version int) (
+	models.TodoRecord,
+	error,
+) {
+	todo, ok := storage.db.todoRecords[id]
+	if !ok || todo.DeletedAt != nil {
+		return models.TodoRecord{}, models.ErrNotFound
+	}
+	if version != 0 && todo.Version != version {
+		return models.TodoRecord{}, models.ErrVersionMismatch
+	}
+
+	return todo, nil
+}
+
+// checkListID emulates the foreign key of the DB storages.
+func (storage TodoRecord) checkListID(listID int) error {
+	if listID == 0 {
+		return nil
+	}
+	if _, ok := storage.db.lists[listID]; !ok {
+		return fmt.Errorf(
+			"%w: the list #%d doesn't exist",
+			models.ErrConflict,
+			listID,
+		)
+	}
+
+	return nil
+}
+
+// checkParentID emulates the foreign key of the DB storages.
+func (storage TodoRecord) checkParentID(parentID int) error {
+	if parentID == 0 {
+		return nil
+	}
+	if _, ok := storage.db.todoRecords[parentID]; !ok {
+		return fmt.Errorf(
+			"%w: the parent to-do record #%d doesn't exist",
+			models.ErrConflict,
+			parentID,
+		)
+	}
+
+	return nil
+}
+
+// addComputedFields emulates the computed fields of the DB storages;
+// it overwrites the ones kept in the stored to-do record.
+func (storage TodoRecord) addComputedFields(
+	todo models.TodoRecord,
+) models.TodoRecord {
+	todo = storage.countChildren(todo)
+
+	todo.BlockerIDs = storage.getBlockerIDs(todo.ID)
+	todo.Blocked = false
+	for _, blockerID := range todo.BlockerIDs {
+		blocker := storage.db.todoRecords[blockerID]
+		if !blocker.Completed && blocker.DeletedAt == nil {
+			todo.Blocked = true
+			break
+		}
+	}
+
+	return todo
+}
+
+func (storage TodoRecord) getBlockerIDs(id int) []int {
+	var blockerIDs []int
+	for dependency := range storage.db.todoDependencies {
+		if dependency.todoRecordID == id {
+			blockerIDs = append(blockerIDs, dependency.blockerID)
+		}
+	}
+	sort.Ints(blockerIDs)
+
+	return blockerIDs
+}
+
+// countChildren counts the direct children except the trashed ones.
+func (storage TodoRecord) countChildren(
+	todo models.TodoRecord,
+) models.TodoRecord {
+	todo.ChildCount, todo.CompletedChildCount = 0, 0
+	for _, child := range storage.db.todoRecords {
+		if child.ParentID != todo.ID || child.DeletedAt != nil {
+			continue
+		}
+
+		todo.ChildCount++
+		if child.Completed {
+			todo.CompletedChildCount++
+		}
+	}
+
+	return todo
+}
+
+func matchQuery(todo models.TodoRecord, query models.Query) bool {
+	if (todo.DeletedAt != nil) != query.Deleted {
+		return false
+	}
+	if query.MinimalDate != (utilmodels.Date{}) &&
+		todo.Date.Before(time.Time(query.MinimalDate)) {
+		return false
+	}
+	if query.MaximalDate != (utilmodels.Date{}) &&
+		todo.Date.After(time.Time(query.MaximalDate)) {
+		return false
+	}
+	if query.Date != (utilmodels.Date{}) {
+		if todo.DueAt == nil {
+			if !todo.Date.Equal(time.Time(query.Date)) {
+				return false
+			}
+		} else {
+			start, end := query.DateRange()
+			if !matchTimestamp(*todo.DueAt, start, end) {
+				return false
+			}
+		}
+	}
+	if query.TitleFragment != "" &&
+		!containsFragment(todo.Title, query.TitleFragment) &&
+		!(query.SearchNotes && containsFragment(todo.Notes, query.TitleFragment)) {
+		return false
+	}
+	if len(query.Tags) != 0 && !containsAnyTag(todo.Tags, query.Tags) {
+		return false
+	}
+	if len(query.AllTags) != 0 && !containsAllTags(todo.Tags, query.AllTags) {
+		return false
+	}
+	if query.ListID != 0 && todo.ListID != query.ListID {
+		return false
+	}
+	if query.ParentID != 0 && todo.ParentID != query.ParentID {
+		return false
+	}
+	if query.Completed != nil && todo.Completed != *query.Completed {
+		return false
+	}
+	if query.Blocked != nil && todo.Blocked != *query.Blocked {
+		return false
+	}
+	if query.MinimalOrder != nil && todo.Order < *query.MinimalOrder {
+		return false
+	}
+	if query.MaximalOrder != nil && todo.Order > *query.MaximalOrder {
+		return false
+	}
+	if query.MinimalPriority != nil && todo.Priority < *query.MinimalPriority {
+		return false
+	}
+	if len(query.IDs) != 0 && !containsID(query.IDs, todo.ID) {
+		return false
+	}
+	if query.Search != "" {
+		searchedText := todo.Title
+		if query.SearchNotes {
+			// the search terms can't contain the separator
+			searchedText += "\n" + todo.Notes
+		}
+
+		terms := models.SplitSearchTerms(query.Search)
+		if !models.MatchSearchTerms(searchedText, terms) {
+			return false
+		}
+	}
+	if !matchTimestamp(todo.CreatedAt, query.CreatedAfter, query.CreatedBefore) ||
+		!matchTimestamp(todo.UpdatedAt, query.UpdatedAfter, query.UpdatedBefore) {
+		return false
+	}
+	if !query.DueAfter.IsZero() || !query.DueBefore.IsZero() {
+		if todo.DueAt == nil ||
+			!matchTimestamp(*todo.DueAt, query.DueAfter, query.DueBefore) {
+			return false
+		}
+	}
+	if !query.CompletedAfter.IsZero() || !query.CompletedBefore.IsZero() {
+		if todo.CompletedAt == nil || !matchTimestamp(
+			*todo.CompletedAt,
+			query.CompletedAfter,
+			query.CompletedBefore,
+		) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchTimestamp checks the timestamp against the half-open range;
+// the zero bounds are ignored.
+func matchTimestamp(timestamp time.Time, after time.Time, before time.Time) bool {
+	if !after.IsZero() && timestamp.Before(after) {
+		return false
+	}
+	if !before.IsZero() && !timestamp.Before(before) {
+		return false
+	}
+
+	return true
+}
+
+// updateCompletedAt sets or clears the completion time
+// when the completion flag flips.
+func updateCompletedAt(todo *models.TodoRecord, wasCompleted bool) {
+	switch {
+	case !todo.Completed:
+		todo.CompletedAt = nil
+	case !wasCompleted || todo.CompletedAt == nil:
+		completedAt := todo.UpdatedAt
+		todo.CompletedAt = &completedAt
+	}
+}
+
+func containsID(ids []int, id int) bool {
+	for _, currentID := range ids {
+		if currentID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func compareTodoRecords(
+	todo models.TodoRecord,
+	otherTodo models.TodoRecord,
+	field string,
+) int {
+	switch field {
+	case models.SortByDate:
+		switch {
+		case todo.Date.Before(otherTodo.Date):
+			return -1
+		case todo.Date.After(otherTodo.Date):
+			return 1
+		default:
+			return 0
+		}
+	case models.SortByTitle:
+		return strings.Compare(todo.Title, otherTodo.Title)
+	case models.SortByCompleted:
+		return compareInts(boolToInt(todo.Completed), boolToInt(otherTodo.Completed))
+	case models.SortByOrder:
+		return compareInts(todo.Order, otherTodo.Order)
+	case models.SortByPriority:
+		return compareInts(todo.Priority, otherTodo.Priority)
+	case models.SortByCreatedAt:
+		return compareTimestamps(todo.CreatedAt, otherTodo.CreatedAt)
+	case models.SortByUpdatedAt:
+		return compareTimestamps(todo.UpdatedAt, otherTodo.UpdatedAt)
+	case models.SortByCompletedAt:
+		if todo.CompletedAt == nil || otherTodo.CompletedAt == nil {
+			return 0
+		}
+
+		return compareTimestamps(*todo.CompletedAt, *otherTodo.CompletedAt)
+	case models.SortByRelevance:
+		// it's a simplified relevance: the shorter title,
+		// the larger part of it is matched
+		return compareInts(
+			utf8.RuneCountInString(otherTodo.Title),
+			utf8.RuneCountInString(todo.Title),
+		)
+	default:
+		return compareInts(todo.ID, otherTodo.ID)
+	}
+}
+
+func compareTimestamps(timestamp time.Time, otherTimestamp time.Time) int {
+	switch {
+	case timestamp.Before(otherTimestamp):
+		return -1
+	case timestamp.After(otherTimestamp):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInts(value int, otherValue int) int {
+	switch {
+	case value < otherValue:
+		return -1
+	case value > otherValue:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+
+	return 0
+}
+
+func paginate(
+	todos []models.TodoRecord,
+	pagination models.Pagination,
+	sortFields []models.SortField,
+) []models.TodoRecord {
+	var offset int
+	if pagination.IsCursorMode() {
+		if pagination.Cursor != (models.Cursor{}) {
+			offset = sort.Search(len(todos), func(index int) bool {
+				return isAfterCursor(todos[index], pagination.Cursor, sortFields)
+			})
+		}
+	} else {
+		offset = (pagination.Page - 1) * pagination.PageSize
+		if offset < 0 {
+			offset = 0
+		}
+	}
+	if offset >= len(todos) {
+		return nil
+	}
+
+	end := offset + pagination.PageSize
+	if end > len(todos) {
+		end = len(todos)
+	}
+
+	return todos[offset:end]
+}
+
+// isAfterCursor checks the record position in the complete sort
+// relative to the cursor; the sort should contain only the cursor fields.
+func isAfterCursor(
+	todo models.TodoRecord,
+	cursor models.Cursor,
+	sortFields []models.SortField,
+) bool {
+	cursorTodo := models.TodoRecord{
+		ID:       cursor.ID,
+		Date:     time.Time(cursor.Date),
+		Order:    cursor.Order,
+		Priority: cursor.Priority,
+	}
+	for _, field := range sortFields {
+		result := compareTodoRecords(todo, cursorTodo, field.Name)
+		if result != 0 {
+			return (result > 0) != field.Descending
+		}
+	}
+
+	return false
+}
+
+// copyTimestamp returns a copy of the timestamp in UTC like the DB storages,
+// so the stored to-do records never share it with the callers.
+func copyTimestamp(timestamp *time.Time) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+
+	timestampCopy := timestamp.UTC()
+	return &timestampCopy
+}
+
+// normalizeTags returns a sorted copy of the tags without duplicates,
+// so the stored to-do records never share it with the callers.
+func normalizeTags(tags []string) []string {
+	var normalizedTags []string
+	for _, tag := range tags {
+		if !containsAnyTag(normalizedTags, []string{tag}) {
+			normalizedTags = append(normalizedTags, tag)
+		}
+	}
+
+	sort.Strings(normalizedTags)
+	return normalizedTags
+}
+
+func containsFragment(text string, fragment string) bool {
+	return strings.Contains(strings.ToLower(text), strings.ToLower(fragment))
+}
+
+func containsAnyTag(tags []string, wantedTags []string) bool {
+	for _, wantedTag := range wantedTags {
+		for _, tag := range tags {
+			if tag == wantedTag {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func containsAllTags(tags []string, wantedTags []string) bool {
+	for _, wantedTag := range wantedTags {
+		if !containsAnyTag(tags, []string{wantedTag}) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+)
+
+// AddHistoryRecord ignores the revision and the change time of the record.
+func (storage TodoRecord) AddHistoryRecord(record models.HistoryRecord) error {
+	defer storage.lock()()
+
+	record.Revision = len(storage.db.historyRecords) + 1
+	record.OldValue = copyTodoRecord(record.OldValue)
+	record.NewValue = copyTodoRecord(record.NewValue)
+	if record.NewValue != nil {
+		patch := models.NewHistoryPatch(*record.NewValue)
+		record.NewValuePatch = &patch
+	}
+	record.ChangedAt = time.Now()
+	storage.db.historyRecords = append(storage.db.historyRecords, record)
+
+	return nil
+}
+
+// GetHistory returns the history records in the chronological order.
+func (storage TodoRecord) GetHistory(query models.HistoryQuery) (
+	[]models.HistoryRecord,
+	error,
+) {
+	defer storage.rLock()()
+
+	var records []models.HistoryRecord
+	for _, record := range storage.db.historyRecords {
+		if query.TodoRecordID != 0 &&
+			record.TodoRecordID != query.TodoRecordID {
+			continue
+		}
+		if !query.Since.IsZero() && record.ChangedAt.Before(query.Since) {
+			continue
+		}
+		if record.Revision <= query.Cursor.Revision {
+			continue
+		}
+
+		records = append(records, record)
+		if len(records) == query.PageSize {
+			break
+		}
+	}
+
+	return records, nil
+}
+
+// GetHistoryRecord ...
+func (storage TodoRecord) GetHistoryRecord(revision int) (
+	models.HistoryRecord,
+	error,
+) {
+	defer storage.rLock()()
+
+	if revision < 1 || revision > len(storage.db.historyRecords) {
+		return models.HistoryRecord{}, models.ErrNotFound
+	}
+
+	return storage.db.historyRecords[revision-1], nil
+}
+
+// copyTodoRecord detaches the history value from the caller's variable
+// and drops the fields not stored by the DB storages.
+func copyTodoRecord(todo *models.TodoRecord) *models.TodoRecord {
+	if todo == nil {
+		return nil
+	}
+
+	todoCopy := models.NewHistoryValue(*todo).TodoRecord()
+	return &todoCopy
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/irenicaa/go-todo-backend/v2/gateways/storagetest"
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoRecord(t *testing.T) {
+	storagetest.RunTodoRecordTests(t, newStorages)
+}
+
+// the relevance is simplified to the title length, see the compareFields()
+// function
+func TestTodoRecord_withRelevanceSort(t *testing.T) {
+	storage := NewTodoRecord(NewDB())
+
+	for i, title := range []string{"Buy milk", "Buy bread", "Milk the cow today"} {
+		_, err := storage.Create(models.TodoRecord{
+			Date:  time.Date(2006, time.January, 2+i, 0, 0, 0, 0, time.UTC),
+			Title: title,
+			Order: i,
+		})
+		require.NoError(t, err)
+	}
+
+	gotTodos, err := storage.GetAll(models.Query{
+		Search: "milk",
+		Sort: []models.SortField{
+			{Name: models.SortByRelevance, Descending: true},
+		},
+	})
+	require.NoError(t, err)
+
+	var gotTitles []string
+	for _, todo := range gotTodos {
+		gotTitles = append(gotTitles, todo.Title)
+	}
+	assert.Equal(t, []string{"Buy milk", "Milk the cow today"}, gotTitles)
+}
+
+func newStorages(t *testing.T) storagetest.Storages {
+	db := NewDB()
+	return storagetest.Storages{
+		TodoRecord: NewTodoRecord(db),
+		List:       NewList(db),
+		Tag:        NewTag(db),
+	}
+}
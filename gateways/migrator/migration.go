@@ -0,0 +1,89 @@
+package migrator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var migrationNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration ...
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// LoadMigrations ...
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	filenames, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the migration files: %v", err)
+	}
+
+	migrationsByVersion := map[int]*Migration{}
+	for _, filename := range filenames {
+		match := migrationNamePattern.FindStringSubmatch(filename)
+		if match == nil {
+			return nil, fmt.Errorf("incorrect migration filename: %q", filename)
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to parse the version of the migration %q: %v",
+				filename,
+				err,
+			)
+		}
+
+		migration, ok := migrationsByVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			migrationsByVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version: %d", version)
+		}
+
+		content, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to read the migration %q: %v",
+				filename,
+				err,
+			)
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	var migrations []Migration
+	for _, migration := range migrationsByVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf(
+				"migration %d has no up script",
+				migration.Version,
+			)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+	if len(migrations) == 0 {
+		return nil, errors.New("no migrations are found")
+	}
+
+	sort.Slice(migrations, func(i int, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
@@ -0,0 +1,96 @@
+package migrator
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	type args struct {
+		fsys fs.FS
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []Migration
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				fsys: fstest.MapFS{
+					"000002_two.up.sql":   {Data: []byte("up 2")},
+					"000001_one.up.sql":   {Data: []byte("up 1")},
+					"000001_one.down.sql": {Data: []byte("down 1")},
+					"000002_two.down.sql": {Data: []byte("down 2")},
+					"migrations.go":       {Data: []byte("package migrations")},
+				},
+			},
+			want: []Migration{
+				{Version: 1, Name: "one", Up: "up 1", Down: "down 1"},
+				{Version: 2, Name: "two", Up: "up 2", Down: "down 2"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success without a down script",
+			args: args{
+				fsys: fstest.MapFS{
+					"000001_one.up.sql": {Data: []byte("up 1")},
+				},
+			},
+			want:    []Migration{{Version: 1, Name: "one", Up: "up 1"}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with an incorrect filename",
+			args: args{
+				fsys: fstest.MapFS{
+					"000001_one.up.sql": {Data: []byte("up 1")},
+					"one.sql":           {Data: []byte("up 2")},
+				},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with a duplicate version",
+			args: args{
+				fsys: fstest.MapFS{
+					"000001_one.up.sql": {Data: []byte("up 1")},
+					"000001_two.up.sql": {Data: []byte("up 2")},
+				},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error without an up script",
+			args: args{
+				fsys: fstest.MapFS{
+					"000001_one.down.sql": {Data: []byte("down 1")},
+				},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error without migrations",
+			args:    args{fsys: fstest.MapFS{}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadMigrations(tt.args.fsys)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// MigrationStatus ...
+type MigrationStatus struct {
+	Migration Migration
+	Applied   bool
+}
+
+// Migrator ...
+type Migrator struct {
+	pool       *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator ...
+func NewMigrator(pool *sql.DB, migrations []Migration) Migrator {
+	return Migrator{pool: pool, migrations: migrations}
+}
+
+// Version ...
+func (migrator Migrator) Version() (int, error) {
+	// the table is compatible with the one of the migrate/migrate tool
+	_, err := migrator.pool.Exec(
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version bigint NOT NULL PRIMARY KEY,
+			dirty boolean NOT NULL
+		)`,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("unable to create the schema table: %v", err)
+	}
+
+	var version int
+	var dirty bool
+	err = migrator.pool.
+		QueryRow("SELECT version, dirty FROM schema_migrations LIMIT 1").
+		Scan(&version, &dirty)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, fmt.Errorf("unable to get the schema version: %v", err)
+	}
+	if dirty {
+		return 0, fmt.Errorf("schema is dirty at version %d", version)
+	}
+
+	return version, nil
+}
+
+// Status ...
+func (migrator Migrator) Status() ([]MigrationStatus, error) {
+	version, err := migrator.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []MigrationStatus
+	for _, migration := range migrator.migrations {
+		statuses = append(statuses, MigrationStatus{
+			Migration: migration,
+			Applied:   migration.Version <= version,
+		})
+	}
+
+	return statuses, nil
+}
+
+// Up ...
+func (migrator Migrator) Up() ([]Migration, error) {
+	version, err := migrator.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	var appliedMigrations []Migration
+	for _, migration := range migrator.migrations {
+		if migration.Version <= version {
+			continue
+		}
+
+		err := migrator.apply(migration.Up, migration.Version)
+		if err != nil {
+			return appliedMigrations, fmt.Errorf(
+				"unable to apply the migration %d: %v",
+				migration.Version,
+				err,
+			)
+		}
+
+		appliedMigrations = append(appliedMigrations, migration)
+	}
+
+	return appliedMigrations, nil
+}
+
+// Down ...
+func (migrator Migrator) Down() (Migration, error) {
+	version, err := migrator.Version()
+	if err != nil {
+		return Migration{}, err
+	}
+	if version == 0 {
+		return Migration{}, errors.New("there are no applied migrations")
+	}
+
+	var previousVersion int
+	for index, migration := range migrator.migrations {
+		if migration.Version != version {
+			continue
+		}
+		if migration.Down == "" {
+			return Migration{}, fmt.Errorf(
+				"migration %d has no down script",
+				migration.Version,
+			)
+		}
+		if index > 0 {
+			previousVersion = migrator.migrations[index-1].Version
+		}
+
+		if err := migrator.apply(migration.Down, previousVersion); err != nil {
+			return Migration{}, fmt.Errorf(
+				"unable to revert the migration %d: %v",
+				migration.Version,
+				err,
+			)
+		}
+
+		return migration, nil
+	}
+
+	return Migration{}, fmt.Errorf("unknown schema version %d", version)
+}
+
+func (migrator Migrator) apply(script string, version int) error {
+	transaction, err := migrator.pool.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to begin a transaction: %v", err)
+	}
+	defer transaction.Rollback()
+
+	if _, err := transaction.Exec(script); err != nil {
+		return fmt.Errorf("unable to execute the script: %v", err)
+	}
+
+	// the placeholder syntax differs between the DBs,
+	// so the version is inlined; it's an integer anyway
+	_, err = transaction.Exec("DELETE FROM schema_migrations")
+	if err != nil {
+		return fmt.Errorf("unable to reset the schema version: %v", err)
+	}
+	if version != 0 {
+		_, err = transaction.Exec(fmt.Sprintf(
+			"INSERT INTO schema_migrations (version, dirty) VALUES (%d, FALSE)",
+			version,
+		))
+		if err != nil {
+			return fmt.Errorf("unable to set the schema version: %v", err)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return fmt.Errorf("unable to commit the transaction: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package migrator
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+var testMigrations = []Migration{
+	{
+		Version: 1,
+		Name:    "create_first_table",
+		Up:      "CREATE TABLE first (id integer)",
+		Down:    "DROP TABLE first",
+	},
+	{
+		Version: 2,
+		Name:    "create_second_table",
+		Up:      "CREATE TABLE second (id integer); CREATE TABLE third (id integer)",
+		Down:    "DROP TABLE second; DROP TABLE third",
+	},
+}
+
+func TestMigrator_Up(t *testing.T) {
+	tests := []struct {
+		name           string
+		migrations     []Migration
+		prepare        func(t *testing.T, migrator Migrator)
+		want           []Migration
+		wantErr        assert.ErrorAssertionFunc
+		wantVersion    int
+		wantTableNames []string
+	}{
+		{
+			name:           "success with an empty DB",
+			migrations:     testMigrations,
+			prepare:        func(t *testing.T, migrator Migrator) {},
+			want:           testMigrations,
+			wantErr:        assert.NoError,
+			wantVersion:    2,
+			wantTableNames: []string{"first", "schema_migrations", "second", "third"},
+		},
+		{
+			name:       "success with a partially migrated DB",
+			migrations: testMigrations,
+			prepare: func(t *testing.T, migrator Migrator) {
+				_, err := NewMigrator(migrator.pool, testMigrations[:1]).Up()
+				require.NoError(t, err)
+			},
+			want:           testMigrations[1:],
+			wantErr:        assert.NoError,
+			wantVersion:    2,
+			wantTableNames: []string{"first", "schema_migrations", "second", "third"},
+		},
+		{
+			name:       "success with a fully migrated DB",
+			migrations: testMigrations,
+			prepare: func(t *testing.T, migrator Migrator) {
+				_, err := migrator.Up()
+				require.NoError(t, err)
+			},
+			want:           nil,
+			wantErr:        assert.NoError,
+			wantVersion:    2,
+			wantTableNames: []string{"first", "schema_migrations", "second", "third"},
+		},
+		{
+			name: "error with a migration",
+			migrations: []Migration{
+				testMigrations[0],
+				{
+					Version: 2,
+					Name:    "incorrect",
+					Up:      "CREATE TABLE second (id integer); incorrect",
+				},
+			},
+			prepare:        func(t *testing.T, migrator Migrator) {},
+			want:           testMigrations[:1],
+			wantErr:        assert.Error,
+			wantVersion:    1,
+			wantTableNames: []string{"first", "schema_migrations"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrator := NewMigrator(openDB(t), tt.migrations)
+			tt.prepare(t, migrator)
+
+			got, err := migrator.Up()
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+
+			gotVersion, err := migrator.Version()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersion, gotVersion)
+			assert.Equal(t, tt.wantTableNames, getTableNames(t, migrator.pool))
+		})
+	}
+}
+
+func TestMigrator_Down(t *testing.T) {
+	tests := []struct {
+		name           string
+		migrations     []Migration
+		prepare        func(t *testing.T, migrator Migrator)
+		want           Migration
+		wantErr        assert.ErrorAssertionFunc
+		wantVersion    int
+		wantTableNames []string
+	}{
+		{
+			name:       "success with the last migration",
+			migrations: testMigrations,
+			prepare: func(t *testing.T, migrator Migrator) {
+				_, err := migrator.Up()
+				require.NoError(t, err)
+			},
+			want:           testMigrations[1],
+			wantErr:        assert.NoError,
+			wantVersion:    1,
+			wantTableNames: []string{"first", "schema_migrations"},
+		},
+		{
+			name:       "success with the first migration",
+			migrations: testMigrations,
+			prepare: func(t *testing.T, migrator Migrator) {
+				_, err := NewMigrator(migrator.pool, testMigrations[:1]).Up()
+				require.NoError(t, err)
+			},
+			want:           testMigrations[0],
+			wantErr:        assert.NoError,
+			wantVersion:    0,
+			wantTableNames: []string{"schema_migrations"},
+		},
+		{
+			name:           "error without applied migrations",
+			migrations:     testMigrations,
+			prepare:        func(t *testing.T, migrator Migrator) {},
+			want:           Migration{},
+			wantErr:        assert.Error,
+			wantVersion:    0,
+			wantTableNames: []string{"schema_migrations"},
+		},
+		{
+			name: "error without a down script",
+			migrations: []Migration{
+				{Version: 1, Name: "irreversible", Up: "CREATE TABLE first (id integer)"},
+			},
+			prepare: func(t *testing.T, migrator Migrator) {
+				_, err := migrator.Up()
+				require.NoError(t, err)
+			},
+			want:           Migration{},
+			wantErr:        assert.Error,
+			wantVersion:    1,
+			wantTableNames: []string{"first", "schema_migrations"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrator := NewMigrator(openDB(t), tt.migrations)
+			tt.prepare(t, migrator)
+
+			got, err := migrator.Down()
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+
+			gotVersion, err := migrator.Version()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersion, gotVersion)
+			assert.Equal(t, tt.wantTableNames, getTableNames(t, migrator.pool))
+		})
+	}
+}
+
+func TestMigrator_Status(t *testing.T) {
+	migrator := NewMigrator(openDB(t), testMigrations)
+	_, err := NewMigrator(migrator.pool, testMigrations[:1]).Up()
+	require.NoError(t, err)
+
+	got, err := migrator.Status()
+	require.NoError(t, err)
+
+	assert.Equal(t, []MigrationStatus{
+		{Migration: testMigrations[0], Applied: true},
+		{Migration: testMigrations[1], Applied: false},
+	}, got)
+}
+
+func openDB(t *testing.T) *sql.DB {
+	pool, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	pool.SetMaxOpenConns(1)
+
+	return pool
+}
+
+func getTableNames(t *testing.T, pool *sql.DB) []string {
+	rows, err := pool.Query(
+		"SELECT name FROM sqlite_master WHERE type = 'table' ORDER BY name",
+	)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var tableNames []string
+	for rows.Next() {
+		var tableName string
+		err := rows.Scan(&tableName)
+		require.NoError(t, err)
+
+		tableNames = append(tableNames, tableName)
+	}
+
+	return tableNames
+}
@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	sqlitedriver "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+func wrapError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrNotFound
+	}
+
+	var sqliteErr *sqlitedriver.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+
+	switch sqliteErr.Code() {
+	case sqlite3.SQLITE_CONSTRAINT_UNIQUE,
+		sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY,
+		sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+		return fmt.Errorf("%w: %s", models.ErrConflict, sqliteErr.Error())
+	case sqlite3.SQLITE_CONSTRAINT_NOTNULL, sqlite3.SQLITE_CONSTRAINT_CHECK:
+		return fmt.Errorf("%w: %s", models.ErrInvalid, sqliteErr.Error())
+	case sqlite3.SQLITE_ERROR:
+		// the constraint violations in the statements with RETURNING
+		// are reported with the generic code, so only the message is left
+		message := sqliteErr.Error()
+		switch {
+		case strings.Contains(message, "UNIQUE constraint failed"),
+			strings.Contains(message, "FOREIGN KEY constraint failed"):
+			return fmt.Errorf("%w: %s", models.ErrConflict, message)
+		case strings.Contains(message, "NOT NULL constraint failed"),
+			strings.Contains(message, "CHECK constraint failed"):
+			return fmt.Errorf("%w: %s", models.ErrInvalid, message)
+		}
+	}
+
+	return err
+}
+
+func checkAffectedRows(result sql.Result) error {
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to get the number of affected rows: %v", err)
+	}
+	if affectedRows == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
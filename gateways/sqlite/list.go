@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	usecases "github.com/irenicaa/go-todo-backend/v2/use-cases"
+)
+
+// List ...
+type List struct {
+	pool *sql.DB
+}
+
+// NewList ...
+func NewList(pool *sql.DB) List {
+	return List{pool: pool}
+}
+
+// GetAll ...
+func (db List) GetAll() ([]models.List, error) {
+	rows, err := db.pool.Query("SELECT id, title FROM lists ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a cursor: %w", wrapError(err))
+	}
+	defer rows.Close()
+
+	var lists []models.List
+	for rows.Next() {
+		var list models.List
+		if err := rows.Scan(&list.ID, &list.Title); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal the row: %v", err)
+		}
+
+		lists = append(lists, list)
+	}
+
+	return lists, nil
+}
+
+// GetSingle ...
+func (db List) GetSingle(id int) (models.List, error) {
+	var list models.List
+	err := db.pool.
+		QueryRow("SELECT id, title FROM lists WHERE id = ?", id).
+		Scan(&list.ID, &list.Title)
+	if err != nil {
+		return models.List{}, wrapError(err)
+	}
+
+	return list, nil
+}
+
+// Create ...
+func (db List) Create(list models.List) (models.List, error) {
+	err := db.pool.
+		QueryRow("INSERT INTO lists (title) VALUES (?) RETURNING id", list.Title).
+		Scan(&list.ID)
+	if err != nil {
+		return models.List{}, wrapError(err)
+	}
+
+	return list, nil
+}
+
+// Update ...
+func (db List) Update(id int, list models.List) (models.List, error) {
+	err := db.pool.
+		QueryRow(
+			"UPDATE lists SET title = ? WHERE id = ? RETURNING id",
+			list.Title,
+			id,
+		).
+		Scan(&list.ID)
+	if err != nil {
+		return models.List{}, wrapError(err)
+	}
+
+	return list, nil
+}
+
+// DeleteSingle ...
+func (db List) DeleteSingle(
+	id int,
+	handler func(todoRecordStorage usecases.TodoRecordStorage) error,
+) error {
+	return inTransaction(db.pool, func(tx *sql.Tx) error {
+		if err := handler(TodoRecord{pool: db.pool, tx: tx}); err != nil {
+			return err
+		}
+
+		// the foreign key moves the trashed to-do records to the inbox
+		result, err := tx.Exec("DELETE FROM lists WHERE id = ?", id)
+		if err != nil {
+			return wrapError(err)
+		}
+
+		return checkAffectedRows(result)
+	})
+}
@@ -0,0 +1,6 @@
+CREATE TABLE todo_records (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	title text NOT NULL,
+	completed boolean NOT NULL,
+	"order" integer NOT NULL
+)
@@ -0,0 +1,2 @@
+ALTER TABLE todo_records
+ADD COLUMN "date" date NOT NULL DEFAULT '0001-01-01';
@@ -0,0 +1,2 @@
+DROP TABLE todo_record_tags;
+DROP TABLE tags;
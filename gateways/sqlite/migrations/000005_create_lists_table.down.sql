@@ -0,0 +1,6 @@
+DROP INDEX todo_records_list_id_index;
+
+ALTER TABLE todo_records
+DROP COLUMN list_id;
+
+DROP TABLE lists;
@@ -0,0 +1,4 @@
+DROP INDEX todo_records_deleted_at_index;
+
+ALTER TABLE todo_records
+DROP COLUMN deleted_at;
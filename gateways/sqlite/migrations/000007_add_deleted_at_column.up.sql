@@ -0,0 +1,4 @@
+ALTER TABLE todo_records
+ADD COLUMN deleted_at timestamp;
+
+CREATE INDEX todo_records_deleted_at_index ON todo_records (deleted_at);
@@ -0,0 +1,10 @@
+DROP INDEX todo_records_completed_at_index;
+DROP INDEX todo_records_updated_at_index;
+DROP INDEX todo_records_created_at_index;
+
+ALTER TABLE todo_records
+DROP COLUMN completed_at;
+ALTER TABLE todo_records
+DROP COLUMN updated_at;
+ALTER TABLE todo_records
+DROP COLUMN created_at;
@@ -0,0 +1,20 @@
+-- SQLite can't add a column with a non-constant default,
+-- so the storage sets the timestamps explicitly
+ALTER TABLE todo_records
+ADD COLUMN created_at timestamp;
+ALTER TABLE todo_records
+ADD COLUMN updated_at timestamp;
+ALTER TABLE todo_records
+ADD COLUMN completed_at timestamp;
+
+UPDATE todo_records
+SET
+	created_at = strftime('%Y-%m-%d %H:%M:%f', 'now'),
+	updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now'),
+	completed_at = CASE
+		WHEN completed THEN strftime('%Y-%m-%d %H:%M:%f', 'now')
+	END;
+
+CREATE INDEX todo_records_created_at_index ON todo_records (created_at);
+CREATE INDEX todo_records_updated_at_index ON todo_records (updated_at);
+CREATE INDEX todo_records_completed_at_index ON todo_records (completed_at);
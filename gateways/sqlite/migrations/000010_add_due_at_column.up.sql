@@ -0,0 +1,4 @@
+ALTER TABLE todo_records
+ADD COLUMN due_at timestamp;
+
+CREATE INDEX todo_records_due_at_index ON todo_records (due_at);
@@ -0,0 +1,2 @@
+ALTER TABLE todo_records
+ADD COLUMN recurrence text NOT NULL DEFAULT '';
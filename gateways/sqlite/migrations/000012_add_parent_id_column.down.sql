@@ -0,0 +1,4 @@
+DROP INDEX todo_records_parent_id_index;
+
+ALTER TABLE todo_records
+DROP COLUMN parent_id;
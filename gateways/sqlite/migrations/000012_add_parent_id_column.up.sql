@@ -0,0 +1,4 @@
+ALTER TABLE todo_records
+ADD COLUMN parent_id integer REFERENCES todo_records (id) ON DELETE SET NULL;
+
+CREATE INDEX todo_records_parent_id_index ON todo_records (parent_id);
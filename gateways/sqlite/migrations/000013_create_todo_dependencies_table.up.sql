@@ -0,0 +1,10 @@
+CREATE TABLE todo_dependencies (
+	todo_record_id integer NOT NULL
+		REFERENCES todo_records (id) ON DELETE CASCADE,
+	blocker_id integer NOT NULL REFERENCES todo_records (id) ON DELETE CASCADE,
+	PRIMARY KEY (todo_record_id, blocker_id),
+	CHECK (todo_record_id <> blocker_id)
+);
+
+CREATE INDEX todo_dependencies_blocker_id_index
+ON todo_dependencies (blocker_id);
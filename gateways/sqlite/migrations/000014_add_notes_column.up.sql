@@ -0,0 +1,2 @@
+ALTER TABLE todo_records
+ADD COLUMN notes text NOT NULL DEFAULT '';
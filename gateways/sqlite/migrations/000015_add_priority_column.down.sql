@@ -0,0 +1,4 @@
+DROP INDEX todo_records_priority_index;
+
+ALTER TABLE todo_records
+DROP COLUMN priority;
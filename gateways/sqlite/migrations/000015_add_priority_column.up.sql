@@ -0,0 +1,4 @@
+ALTER TABLE todo_records
+ADD COLUMN priority integer NOT NULL DEFAULT 0;
+
+CREATE INDEX todo_records_priority_index ON todo_records (priority);
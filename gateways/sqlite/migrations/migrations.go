@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS ...
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+// OpenDB ...
+func OpenDB(dataSourceName string) (*sql.DB, error) {
+	// foreign keys are disabled by default in SQLite
+	// and have to be enabled for each connection
+	separator := "?"
+	if strings.Contains(dataSourceName, "?") {
+		separator = "&"
+	}
+	dataSourceName += separator + "_pragma=foreign_keys(1)"
+
+	pool, err := sql.Open("sqlite", dataSourceName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a pool of DB connections: %v", err)
+	}
+
+	// SQLite serializes writes anyway, and a single connection also keeps
+	// in-memory databases shared between all the requests
+	pool.SetMaxOpenConns(1)
+
+	return pool, nil
+}
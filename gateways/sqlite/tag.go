@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+)
+
+// Tag ...
+type Tag struct {
+	pool *sql.DB
+}
+
+// NewTag ...
+func NewTag(pool *sql.DB) Tag {
+	return Tag{pool: pool}
+}
+
+// GetAll ...
+func (db Tag) GetAll() ([]models.Tag, error) {
+	rows, err := db.pool.Query(
+		`SELECT tags.name, count(*) AS count
+		FROM tags
+			JOIN todo_record_tags ON todo_record_tags.tag_id = tags.id
+			JOIN todo_records ON todo_records.id = todo_record_tags.todo_record_id
+		WHERE todo_records.deleted_at IS NULL
+		GROUP BY tags.name
+		ORDER BY count DESC, tags.name`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a cursor: %w", wrapError(err))
+	}
+	defer rows.Close()
+
+	var tags []models.Tag
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.Name, &tag.Count); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal the row: %v", err)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,13 @@
+// +build integration
+
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/irenicaa/go-todo-backend/v2/gateways/storagetest"
+)
+
+func TestTag(t *testing.T) {
+	storagetest.RunTagTests(t, newStorages)
+}
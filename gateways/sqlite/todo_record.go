@@ -0,0 +1,893 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	usecases "github.com/irenicaa/go-todo-backend/v2/use-cases"
+)
+
+const (
+	dateFormat = "2006-01-02"
+	// currentTimestamp has the fixed-width format in UTC,
+	// so such timestamps are comparable as strings
+	currentTimestamp = "strftime('%Y-%m-%d %H:%M:%f', 'now')"
+	// timestampFormat matches the format of the currentTimestamp constant
+	timestampFormat = "2006-01-02 15:04:05.000"
+	// completedAtUpdate takes the new completion flag as an argument;
+	// the completed column keeps the old one in the UPDATE statement
+	completedAtUpdate = `CASE
+		WHEN NOT COALESCE(?, completed) THEN NULL
+		WHEN completed THEN completed_at
+		ELSE ` + currentTimestamp + `
+	END`
+	todoRecordTagsSource = `todo_record_tags
+		JOIN tags ON tags.id = todo_record_tags.tag_id
+		WHERE todo_record_tags.todo_record_id = todo_records.id`
+	// todoRecordChildrenSource selects the direct children of the to-do record
+	// except the trashed ones
+	todoRecordChildrenSource = `todo_records AS children
+		WHERE children.parent_id = todo_records.id AND children.deleted_at IS NULL`
+	// todoRecordBlockedCondition checks the open blockers of the to-do record
+	todoRecordBlockedCondition = `EXISTS (
+		SELECT 1
+		FROM todo_dependencies
+			JOIN todo_records AS blockers
+				ON blockers.id = todo_dependencies.blocker_id
+		WHERE todo_dependencies.todo_record_id = todo_records.id
+			AND NOT blockers.completed
+			AND blockers.deleted_at IS NULL
+	)`
+	todoRecordColumns = `id, title, notes, completed, "order", priority, "date",
+		due_at, recurrence, list_id, parent_id, version, created_at, updated_at, completed_at,
+		deleted_at,
+		(SELECT count(*) FROM ` + todoRecordChildrenSource + `),
+		(SELECT count(*) FROM ` + todoRecordChildrenSource + ` AND children.completed),
+		` + todoRecordBlockedCondition + `, (
+		SELECT json_group_array(blocker_id)
+		FROM (
+			SELECT blocker_id
+			FROM todo_dependencies
+			WHERE todo_record_id = todo_records.id
+			ORDER BY blocker_id
+		)
+	), (
+		SELECT json_group_array(name)
+		FROM (SELECT tags.name FROM ` + todoRecordTagsSource + ` ORDER BY tags.name)
+	)`
+)
+
+var todoRecordSortColumns = map[string]string{
+	models.SortByID:        "id",
+	models.SortByDate:      `"date"`,
+	models.SortByTitle:     "title",
+	models.SortByCompleted: "completed",
+	models.SortByOrder:     `"order"`,
+	models.SortByPriority:  "priority",
+	// it's a simplified relevance: the shorter title,
+	// the larger part of it is matched
+	models.SortByRelevance:   "-length(title)",
+	models.SortByCreatedAt:   "created_at",
+	models.SortByUpdatedAt:   "updated_at",
+	models.SortByCompletedAt: "completed_at",
+}
+
+// TodoRecord ...
+type TodoRecord struct {
+	pool *sql.DB
+	tx   *sql.Tx
+}
+
+// NewTodoRecord ...
+func NewTodoRecord(pool *sql.DB) TodoRecord {
+	return TodoRecord{pool: pool}
+}
+
+// GetAll ...
+func (db TodoRecord) GetAll(query models.Query) ([]models.TodoRecord, error) {
+	where, args := makeTodoRecordWhere(query)
+	sql := "SELECT " + todoRecordColumns + " FROM todo_records" + where
+
+	if query.Pagination.IsCursorMode() &&
+		query.Pagination.Cursor != (models.Cursor{}) {
+		cursorCondition, cursorArgs := makeTodoRecordCursorCondition(
+			query.Sort,
+			query.Pagination.Cursor,
+		)
+		sql += " AND " + cursorCondition
+		args = append(args, cursorArgs...)
+	}
+
+	sql += makeTodoRecordOrder(query.Sort)
+	if query.Pagination.IsCursorMode() {
+		sql += fmt.Sprintf(" LIMIT %d", query.Pagination.PageSize)
+	} else if query.Pagination != (models.Pagination{}) {
+		sql += fmt.Sprintf(
+			" LIMIT %d OFFSET %d",
+			query.Pagination.PageSize,
+			(query.Pagination.Page-1)*query.Pagination.PageSize,
+		)
+	}
+
+	rows, err := db.getExecutor().Query(sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a cursor: %w", wrapError(err))
+	}
+	defer rows.Close()
+
+	var todos []models.TodoRecord
+	for rows.Next() {
+		todo, err := scanTodoRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal the row: %v", err)
+		}
+
+		todos = append(todos, models.HighlightTodoRecord(todo, query))
+	}
+
+	return todos, nil
+}
+
+// Count ...
+func (db TodoRecord) Count(query models.Query) (int, error) {
+	where, args := makeTodoRecordWhere(query)
+
+	var count int
+	err := db.getExecutor().
+		QueryRow("SELECT count(*) FROM todo_records"+where, args...).
+		Scan(&count)
+	if err != nil {
+		return 0,
+			fmt.Errorf("unable to count the to-do records: %w", wrapError(err))
+	}
+
+	return count, nil
+}
+
+// GetSingle ...
+func (db TodoRecord) GetSingle(id int) (models.TodoRecord, error) {
+	return getSingle(db.getExecutor(), id)
+}
+
+// Create ...
+func (db TodoRecord) Create(todo models.TodoRecord) (
+	models.TodoRecord,
+	error,
+) {
+	var createdTodo models.TodoRecord
+	err := db.inTransaction(func(tx *sql.Tx) error {
+		var id int
+		err := tx.
+			QueryRow(
+				`INSERT INTO todo_records (
+					title,
+					notes,
+					completed,
+					"order",
+					priority,
+					"date",
+					due_at,
+					recurrence,
+					list_id,
+					parent_id,
+					created_at,
+					updated_at,
+					completed_at
+				)
+				VALUES (
+					?,
+					?,
+					?,
+					?,
+					?,
+					?,
+					?,
+					?,
+					NULLIF(?, 0),
+					NULLIF(?, 0),
+					`+currentTimestamp+`,
+					`+currentTimestamp+`,
+					CASE WHEN ? THEN `+currentTimestamp+` END
+				)
+				RETURNING id`,
+				todo.Title,
+				todo.Notes,
+				todo.Completed,
+				todo.Order,
+				todo.Priority,
+				todo.Date.Format(dateFormat),
+				formatTimestamp(todo.DueAt),
+				todo.Recurrence,
+				todo.ListID,
+				todo.ParentID,
+				todo.Completed,
+			).
+			Scan(&id)
+		if err != nil {
+			return wrapError(err)
+		}
+
+		if err := setTags(tx, id, todo.Tags); err != nil {
+			return err
+		}
+
+		createdTodo, err = getSingle(tx, id)
+		return err
+	})
+	if err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	return createdTodo, nil
+}
+
+// Update ...
+func (db TodoRecord) Update(
+	id int,
+	todo models.TodoRecord,
+	version int,
+) (models.TodoRecord, error) {
+	var updatedTodo models.TodoRecord
+	err := db.inTransaction(func(tx *sql.Tx) error {
+		err := tx.
+			QueryRow(
+				`UPDATE todo_records
+				SET
+					title = ?,
+					notes = ?,
+					completed = ?,
+					"order" = ?,
+					priority = ?,
+					"date" = ?,
+					due_at = ?,
+					recurrence = ?,
+					list_id = NULLIF(?, 0),
+					parent_id = NULLIF(?, 0),
+					version = version + 1,
+					updated_at = `+currentTimestamp+`,
+					completed_at = `+completedAtUpdate+`
+				WHERE id = ? AND (? = 0 OR version = ?) AND deleted_at IS NULL
+				RETURNING id`,
+				todo.Title,
+				todo.Notes,
+				todo.Completed,
+				todo.Order,
+				todo.Priority,
+				todo.Date.Format(dateFormat),
+				formatTimestamp(todo.DueAt),
+				todo.Recurrence,
+				todo.ListID,
+				todo.ParentID,
+				todo.Completed,
+				id,
+				version,
+				version,
+			).
+			Scan(&id)
+		if err != nil {
+			return wrapError(err)
+		}
+
+		if err := setTags(tx, id, todo.Tags); err != nil {
+			return err
+		}
+
+		updatedTodo, err = getSingle(tx, id)
+		return err
+	})
+	if err != nil {
+		return models.TodoRecord{}, db.wrapVersionError(id, err)
+	}
+
+	return updatedTodo, nil
+}
+
+// Patch ...
+func (db TodoRecord) Patch(
+	id int,
+	todoPatch models.TodoRecordPatch,
+	version int,
+) (models.TodoRecord, error) {
+	var date *string
+	if todoPatch.Date != nil {
+		formattedDate := time.Time(*todoPatch.Date).Format(dateFormat)
+		date = &formattedDate
+	}
+
+	var patchedTodo models.TodoRecord
+	err := db.inTransaction(func(tx *sql.Tx) error {
+		err := tx.
+			QueryRow(
+				`UPDATE todo_records
+				SET
+					title = COALESCE(?, title),
+					notes = COALESCE(?, notes),
+					completed = COALESCE(?, completed),
+					"order" = COALESCE(?, "order"),
+					priority = COALESCE(?, priority),
+					"date" = COALESCE(?, "date"),
+					due_at = CASE WHEN ? THEN ? ELSE due_at END,
+					recurrence = COALESCE(?, recurrence),
+					list_id = CASE WHEN ? IS NULL THEN list_id ELSE NULLIF(?, 0) END,
+					parent_id = CASE
+						WHEN ? IS NULL THEN parent_id
+						ELSE NULLIF(?, 0)
+					END,
+					version = version + 1,
+					updated_at = `+currentTimestamp+`,
+					completed_at = `+completedAtUpdate+`
+				WHERE id = ? AND (? = 0 OR version = ?) AND deleted_at IS NULL
+				RETURNING id`,
+				todoPatch.Title,
+				todoPatch.Notes,
+				todoPatch.Completed,
+				todoPatch.Order,
+				todoPatch.Priority,
+				date,
+				todoPatch.DueAt.IsSet,
+				formatTimestamp(todoPatch.DueAt.Value),
+				todoPatch.Recurrence,
+				todoPatch.ListID,
+				todoPatch.ListID,
+				todoPatch.ParentID,
+				todoPatch.ParentID,
+				todoPatch.Completed,
+				id,
+				version,
+				version,
+			).
+			Scan(&id)
+		if err != nil {
+			return wrapError(err)
+		}
+
+		if todoPatch.Tags != nil {
+			if err := setTags(tx, id, *todoPatch.Tags); err != nil {
+				return err
+			}
+		}
+
+		patchedTodo, err = getSingle(tx, id)
+		return err
+	})
+	if err != nil {
+		return models.TodoRecord{}, db.wrapVersionError(id, err)
+	}
+
+	return patchedTodo, nil
+}
+
+// DeleteAll moves the to-do records to the trash;
+// it ignores the pagination and sorting of the query.
+func (db TodoRecord) DeleteAll(query models.Query) (int, error) {
+	query.Deleted = false
+	where, args := makeTodoRecordWhere(query)
+	result, err := db.getExecutor().Exec(
+		"UPDATE todo_records SET deleted_at = "+currentTimestamp+where,
+		args...,
+	)
+	if err != nil {
+		return 0, wrapError(err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0,
+			fmt.Errorf("unable to get the number of affected rows: %v", err)
+	}
+
+	return int(count), nil
+}
+
+// DeleteSingle moves the to-do record to the trash.
+func (db TodoRecord) DeleteSingle(id int, version int) error {
+	result, err := db.getExecutor().Exec(
+		`UPDATE todo_records
+		SET deleted_at = `+currentTimestamp+`
+		WHERE id = ? AND (? = 0 OR version = ?) AND deleted_at IS NULL`,
+		id,
+		version,
+		version,
+	)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return db.wrapVersionError(id, checkAffectedRows(result))
+}
+
+// Restore moves the to-do record back from the trash.
+func (db TodoRecord) Restore(id int) (models.TodoRecord, error) {
+	var restoredTodo models.TodoRecord
+	err := db.inTransaction(func(tx *sql.Tx) error {
+		err := tx.
+			QueryRow(
+				`UPDATE todo_records
+				SET deleted_at = NULL
+				WHERE id = ? AND deleted_at IS NOT NULL
+				RETURNING id`,
+				id,
+			).
+			Scan(&id)
+		if err != nil {
+			return wrapError(err)
+		}
+
+		restoredTodo, err = getSingle(tx, id)
+		return err
+	})
+	if err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	return restoredTodo, nil
+}
+
+// Purge deletes permanently the to-do records trashed
+// at least the retention ago.
+func (db TodoRecord) Purge(retention time.Duration) (int, error) {
+	result, err := db.getExecutor().Exec(
+		`DELETE FROM todo_records
+		WHERE deleted_at <= strftime('%Y-%m-%d %H:%M:%f', 'now', ?)`,
+		fmt.Sprintf("-%f seconds", retention.Seconds()),
+	)
+	if err != nil {
+		return 0, wrapError(err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0,
+			fmt.Errorf("unable to get the number of affected rows: %v", err)
+	}
+
+	return int(count), nil
+}
+
+// AddDependency ignores the existing dependency.
+func (db TodoRecord) AddDependency(id int, blockerID int) error {
+	_, err := db.getExecutor().Exec(
+		`INSERT INTO todo_dependencies (todo_record_id, blocker_id)
+		VALUES (?, ?)
+		ON CONFLICT DO NOTHING`,
+		id,
+		blockerID,
+	)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return nil
+}
+
+// DeleteDependency ...
+func (db TodoRecord) DeleteDependency(id int, blockerID int) error {
+	result, err := db.getExecutor().Exec(
+		`DELETE FROM todo_dependencies
+		WHERE todo_record_id = ? AND blocker_id = ?`,
+		id,
+		blockerID,
+	)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return checkAffectedRows(result)
+}
+
+// GetBlockerIDs includes the trashed blockers.
+func (db TodoRecord) GetBlockerIDs(id int) ([]int, error) {
+	rows, err := db.getExecutor().Query(
+		`SELECT blocker_id
+		FROM todo_dependencies
+		WHERE todo_record_id = ?
+		ORDER BY blocker_id`,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a cursor: %w", wrapError(err))
+	}
+	defer rows.Close()
+
+	var blockerIDs []int
+	for rows.Next() {
+		var blockerID int
+		if err := rows.Scan(&blockerID); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal the row: %v", err)
+		}
+
+		blockerIDs = append(blockerIDs, blockerID)
+	}
+
+	return blockerIDs, nil
+}
+
+// InTransaction ...
+//
+// The nested calls are isolated by savepoints,
+// so the handler can skip a failed one.
+func (db TodoRecord) InTransaction(
+	handler func(storage usecases.TodoRecordStorage) error,
+) error {
+	return db.inTransaction(func(tx *sql.Tx) error {
+		return handler(TodoRecord{pool: db.pool, tx: tx})
+	})
+}
+
+func (db TodoRecord) inTransaction(handler func(tx *sql.Tx) error) error {
+	return inNestedTransaction(db.pool, db.tx, handler)
+}
+
+func (db TodoRecord) getExecutor() executor {
+	if db.tx != nil {
+		return db.tx
+	}
+
+	return db.pool
+}
+
+// wrapVersionError distinguishes a missing to-do record
+// from the one with another version.
+func (db TodoRecord) wrapVersionError(id int, err error) error {
+	if !errors.Is(err, models.ErrNotFound) {
+		return err
+	}
+
+	var exists bool
+	err = db.getExecutor().
+		QueryRow(
+			`SELECT EXISTS (
+				SELECT 1 FROM todo_records WHERE id = ? AND deleted_at IS NULL
+			)`,
+			id,
+		).
+		Scan(&exists)
+	if err != nil {
+		return wrapError(err)
+	}
+	if !exists {
+		return models.ErrNotFound
+	}
+
+	return models.ErrVersionMismatch
+}
+
+func getSingle(executor executor, id int) (models.TodoRecord, error) {
+	row := executor.QueryRow(
+		"SELECT "+todoRecordColumns+
+			" FROM todo_records WHERE id = ? AND deleted_at IS NULL",
+		id,
+	)
+	todo, err := scanTodoRecord(row)
+	if err != nil {
+		return models.TodoRecord{}, wrapError(err)
+	}
+
+	return todo, nil
+}
+
+func setTags(executor executor, todoID int, tags []string) error {
+	_, err := executor.Exec(
+		"DELETE FROM todo_record_tags WHERE todo_record_id = ?",
+		todoID,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to delete the tags: %w", wrapError(err))
+	}
+
+	for _, tag := range tags {
+		_, err := executor.Exec(
+			"INSERT INTO tags (name) VALUES (?) ON CONFLICT (name) DO NOTHING",
+			tag,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to create the tag: %w", wrapError(err))
+		}
+
+		_, err = executor.Exec(
+			`INSERT INTO todo_record_tags (todo_record_id, tag_id)
+			SELECT ?, id FROM tags WHERE name = ?
+			ON CONFLICT DO NOTHING`,
+			todoID,
+			tag,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to add the tag: %w", wrapError(err))
+		}
+	}
+
+	return nil
+}
+
+// formatTimestamp returns nil for the nil timestamp,
+// so it's stored as NULL.
+func formatTimestamp(timestamp *time.Time) *string {
+	if timestamp == nil {
+		return nil
+	}
+
+	formattedTimestamp := timestamp.UTC().Format(timestampFormat)
+	return &formattedTimestamp
+}
+
+func makePlaceholders(count int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", count), ", ")
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTodoRecord(row scanner) (models.TodoRecord, error) {
+	var todo models.TodoRecord
+	var listID, parentID sql.NullInt64
+	var dueAt, completedAt, deletedAt sql.NullTime
+	var blockerIDs, tags string
+	err := row.Scan(
+		&todo.ID,
+		&todo.Title,
+		&todo.Notes,
+		&todo.Completed,
+		&todo.Order,
+		&todo.Priority,
+		&todo.Date,
+		&dueAt,
+		&todo.Recurrence,
+		&listID,
+		&parentID,
+		&todo.Version,
+		&todo.CreatedAt,
+		&todo.UpdatedAt,
+		&completedAt,
+		&deletedAt,
+		&todo.ChildCount,
+		&todo.CompletedChildCount,
+		&todo.Blocked,
+		&blockerIDs,
+		&tags,
+	)
+	if err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	// the zero list ID means the inbox, which is stored as NULL
+	todo.ListID = int(listID.Int64)
+	todo.ParentID = int(parentID.Int64)
+	if dueAt.Valid {
+		todo.DueAt = &dueAt.Time
+	}
+	if completedAt.Valid {
+		todo.CompletedAt = &completedAt.Time
+	}
+	if deletedAt.Valid {
+		todo.DeletedAt = &deletedAt.Time
+	}
+
+	// SQLite has no arrays, so the blocker IDs and the tags are aggregated
+	// into the JSON arrays
+	err = json.Unmarshal([]byte(blockerIDs), &todo.BlockerIDs)
+	if err != nil {
+		return models.TodoRecord{},
+			fmt.Errorf("unable to unmarshal the blocker IDs: %v", err)
+	}
+	if len(todo.BlockerIDs) == 0 {
+		todo.BlockerIDs = nil
+	}
+
+	if err := json.Unmarshal([]byte(tags), &todo.Tags); err != nil {
+		return models.TodoRecord{},
+			fmt.Errorf("unable to unmarshal the tags: %v", err)
+	}
+	if len(todo.Tags) == 0 {
+		todo.Tags = nil
+	}
+
+	return todo, nil
+}
+
+// makeTodoRecordWhere ignores the pagination of the query.
+func makeTodoRecordWhere(query models.Query) (string, []interface{}) {
+	var args []interface{}
+
+	sql := " WHERE deleted_at IS NULL"
+	if query.Deleted {
+		sql = " WHERE deleted_at IS NOT NULL"
+	}
+
+	if query.MinimalDate != (utilmodels.Date{}) {
+		sql += ` AND "date" >= ?`
+		args = append(args, time.Time(query.MinimalDate).Format(dateFormat))
+	}
+	if query.MaximalDate != (utilmodels.Date{}) {
+		sql += ` AND "date" <= ?`
+		args = append(args, time.Time(query.MaximalDate).Format(dateFormat))
+	}
+	if query.Date != (utilmodels.Date{}) {
+		start, end := query.DateRange()
+		sql += ` AND ((due_at IS NULL AND "date" = ?)` +
+			` OR (due_at >= ? AND due_at < ?))`
+		args = append(
+			args,
+			time.Time(query.Date).Format(dateFormat),
+			start.UTC().Format(timestampFormat),
+			end.UTC().Format(timestampFormat),
+		)
+	}
+	if query.TitleFragment != "" {
+		// LIKE is case-insensitive for ASCII characters in SQLite
+		condition, conditionArgs :=
+			makeTodoRecordSearchCondition(query.TitleFragment, query.SearchNotes)
+		sql += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+	if len(query.Tags) != 0 {
+		sql += " AND EXISTS (SELECT 1 FROM " + todoRecordTagsSource +
+			" AND tags.name IN (" + makePlaceholders(len(query.Tags)) + "))"
+		for _, tag := range query.Tags {
+			args = append(args, tag)
+		}
+	}
+	if len(query.AllTags) != 0 {
+		allTags := map[string]struct{}{}
+		for _, tag := range query.AllTags {
+			allTags[tag] = struct{}{}
+		}
+
+		sql += " AND (SELECT count(*) FROM " + todoRecordTagsSource +
+			" AND tags.name IN (" + makePlaceholders(len(allTags)) + ")) = ?"
+		for tag := range allTags {
+			args = append(args, tag)
+		}
+		args = append(args, len(allTags))
+	}
+	if query.ListID != 0 {
+		sql += " AND list_id = ?"
+		args = append(args, query.ListID)
+	}
+	if query.ParentID != 0 {
+		sql += " AND parent_id = ?"
+		args = append(args, query.ParentID)
+	}
+	// SQLite has no native full-text search without a virtual table,
+	// so it's simplified to matching of all the terms
+	for _, term := range models.SplitSearchTerms(query.Search) {
+		condition, conditionArgs :=
+			makeTodoRecordSearchCondition(term, query.SearchNotes)
+		sql += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+	if query.Blocked != nil {
+		if *query.Blocked {
+			sql += " AND " + todoRecordBlockedCondition
+		} else {
+			sql += " AND NOT " + todoRecordBlockedCondition
+		}
+	}
+	if query.Completed != nil {
+		sql += " AND completed = ?"
+		args = append(args, *query.Completed)
+	}
+	if query.MinimalOrder != nil {
+		sql += ` AND "order" >= ?`
+		args = append(args, *query.MinimalOrder)
+	}
+	if query.MaximalOrder != nil {
+		sql += ` AND "order" <= ?`
+		args = append(args, *query.MaximalOrder)
+	}
+	if query.MinimalPriority != nil {
+		sql += " AND priority >= ?"
+		args = append(args, *query.MinimalPriority)
+	}
+	if len(query.IDs) != 0 {
+		sql += " AND id IN (" + makePlaceholders(len(query.IDs)) + ")"
+		for _, id := range query.IDs {
+			args = append(args, id)
+		}
+	}
+	timestampConditions := []struct {
+		condition string
+		timestamp time.Time
+	}{
+		{"created_at >= ?", query.CreatedAfter},
+		{"created_at < ?", query.CreatedBefore},
+		{"updated_at >= ?", query.UpdatedAfter},
+		{"updated_at < ?", query.UpdatedBefore},
+		{"completed_at >= ?", query.CompletedAfter},
+		{"completed_at < ?", query.CompletedBefore},
+		{"due_at >= ?", query.DueAfter},
+		{"due_at < ?", query.DueBefore},
+	}
+	for _, timestampCondition := range timestampConditions {
+		if !timestampCondition.timestamp.IsZero() {
+			sql += " AND " + timestampCondition.condition
+			args = append(
+				args,
+				timestampCondition.timestamp.UTC().Format(timestampFormat),
+			)
+		}
+	}
+
+	return sql, args
+}
+
+// makeTodoRecordSearchCondition matches the fragment in the title
+// and optionally in the notes.
+func makeTodoRecordSearchCondition(
+	fragment string,
+	searchNotes bool,
+) (string, []interface{}) {
+	pattern := "%" + fragment + "%"
+	if searchNotes {
+		return "(title LIKE ? OR notes LIKE ?)", []interface{}{pattern, pattern}
+	}
+
+	return "title LIKE ?", []interface{}{pattern}
+}
+
+// makeTodoRecordCursorCondition selects the records after the cursor
+// in the complete sort, which should contain only the cursor fields.
+func makeTodoRecordCursorCondition(
+	sort []models.SortField,
+	cursor models.Cursor,
+) (string, []interface{}) {
+	var condition string
+	var args []interface{}
+	completeSort := models.CompleteSort(sort, models.DefaultSort)
+	for index := len(completeSort) - 1; index >= 0; index-- {
+		field := completeSort[index]
+		column := todoRecordSortColumns[field.Name]
+		operator := ">"
+		if field.Descending {
+			operator = "<"
+		}
+
+		var value interface{}
+		switch field.Name {
+		case models.SortByDate:
+			value = time.Time(cursor.Date).Format(dateFormat)
+		case models.SortByOrder:
+			value = cursor.Order
+		case models.SortByPriority:
+			value = cursor.Priority
+		default:
+			value = cursor.ID
+		}
+
+		if condition == "" {
+			condition = column + " " + operator + " ?"
+			args = []interface{}{value}
+
+			continue
+		}
+
+		condition = "(" + column + " " + operator + " ? OR (" +
+			column + " = ? AND " + condition + "))"
+		args = append([]interface{}{value, value}, args...)
+	}
+
+	return condition, args
+}
+
+func makeTodoRecordOrder(sort []models.SortField) string {
+	var items []string
+	for _, field := range models.CompleteSort(sort, models.DefaultSort) {
+		item := todoRecordSortColumns[field.Name]
+		if field.Descending {
+			item += " DESC"
+		}
+		if field.Name == models.SortByCompletedAt {
+			item += " NULLS LAST"
+		}
+
+		items = append(items, item)
+	}
+
+	return " ORDER BY " + strings.Join(items, ", ")
+}
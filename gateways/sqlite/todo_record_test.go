@@ -0,0 +1,81 @@
+// +build integration
+
+package sqlite
+
+import (
+	"database/sql"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/irenicaa/go-todo-backend/v2/gateways/migrator"
+	"github.com/irenicaa/go-todo-backend/v2/gateways/sqlite/migrations"
+	"github.com/irenicaa/go-todo-backend/v2/gateways/storagetest"
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var dataSourceName = flag.String(
+	"dataSourceName",
+	":memory:",
+	"DB connection string",
+)
+
+func TestTodoRecord(t *testing.T) {
+	storagetest.RunTodoRecordTests(t, newStorages)
+}
+
+// the relevance is simplified to the title length, see the sort columns
+func TestTodoRecord_withRelevanceSort(t *testing.T) {
+	pool := openDB(t)
+	storage := NewTodoRecord(pool)
+
+	_, err := storage.DeleteAll(models.Query{})
+	require.NoError(t, err)
+
+	for i, title := range []string{"Buy milk", "Buy bread", "Milk the cow today"} {
+		_, err := storage.Create(models.TodoRecord{
+			Date:  time.Date(2006, time.January, 2+i, 0, 0, 0, 0, time.UTC),
+			Title: title,
+			Order: i,
+		})
+		require.NoError(t, err)
+	}
+
+	gotTodos, err := storage.GetAll(models.Query{
+		Search: "milk",
+		Sort: []models.SortField{
+			{Name: models.SortByRelevance, Descending: true},
+		},
+	})
+	require.NoError(t, err)
+
+	var gotTitles []string
+	for _, todo := range gotTodos {
+		gotTitles = append(gotTitles, todo.Title)
+	}
+	assert.Equal(t, []string{"Buy milk", "Milk the cow today"}, gotTitles)
+}
+
+func newStorages(t *testing.T) storagetest.Storages {
+	pool := openDB(t)
+	return storagetest.Storages{
+		TodoRecord: NewTodoRecord(pool),
+		List:       NewList(pool),
+		Tag:        NewTag(pool),
+	}
+}
+
+func openDB(t *testing.T) *sql.DB {
+	pool, err := OpenDB(*dataSourceName)
+	require.NoError(t, err)
+
+	sqliteMigrations, err := migrator.LoadMigrations(migrations.FS)
+	require.NoError(t, err)
+
+	_, err = migrator.NewMigrator(pool, sqliteMigrations).Up()
+	require.NoError(t, err)
+
+	return pool
+}
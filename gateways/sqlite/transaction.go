@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type executor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func inTransaction(pool *sql.DB, handler func(tx *sql.Tx) error) error {
+	tx, err := pool.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to begin a transaction: %w", wrapError(err))
+	}
+
+	if err := handler(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("unable to commit the transaction: %w", wrapError(err))
+	}
+
+	return nil
+}
+
+// inNestedTransaction begins a new transaction without the passed one;
+// otherwise, it isolates the handler by a savepoint,
+// so its failure doesn't break the outer transaction.
+func inNestedTransaction(
+	pool *sql.DB,
+	tx *sql.Tx,
+	handler func(tx *sql.Tx) error,
+) error {
+	if tx == nil {
+		return inTransaction(pool, handler)
+	}
+
+	if _, err := tx.Exec("SAVEPOINT nested_transaction"); err != nil {
+		return fmt.Errorf("unable to create a savepoint: %w", wrapError(err))
+	}
+
+	if err := handler(tx); err != nil {
+		_, rollbackErr := tx.Exec("ROLLBACK TO SAVEPOINT nested_transaction")
+		if rollbackErr != nil {
+			return fmt.Errorf(
+				"unable to roll back to the savepoint: %w",
+				wrapError(rollbackErr),
+			)
+		}
+
+		return err
+	}
+
+	if _, err := tx.Exec("RELEASE SAVEPOINT nested_transaction"); err != nil {
+		return fmt.Errorf("unable to release the savepoint: %w", wrapError(err))
+	}
+
+	return nil
+}
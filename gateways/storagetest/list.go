@@ -0,0 +1,199 @@
+package storagetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	usecases "github.com/irenicaa/go-todo-backend/v2/use-cases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunListTests checks the implementation
+// of the usecases.ListStorage interface.
+func RunListTests(t *testing.T, newStorages NewStorages) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, newStorages NewStorages)
+	}{
+		{name: "withModifying", test: testListWithModifying},
+		{name: "withDeleting", test: testListWithDeleting},
+		{name: "withMissingRecord", test: testListWithMissingRecord},
+		{name: "withTodoRecords", test: testListWithTodoRecords},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStorages)
+		})
+	}
+}
+
+func testListWithModifying(t *testing.T, newStorages NewStorages) {
+	lists := newStorages(t).List
+
+	createdList, err := lists.Create(models.List{Title: "test"})
+	require.NoError(t, err)
+
+	gotList, err := lists.GetSingle(createdList.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.List{ID: createdList.ID, Title: "test"}, gotList)
+
+	updatedList, err := lists.Update(createdList.ID, models.List{Title: "test2"})
+	require.NoError(t, err)
+	assert.Equal(t, models.List{ID: createdList.ID, Title: "test2"}, updatedList)
+
+	gotLists, err := lists.GetAll()
+	require.NoError(t, err)
+	assert.Contains(t, gotLists, updatedList)
+}
+
+func testListWithDeleting(t *testing.T, newStorages NewStorages) {
+	tests := []struct {
+		name        string
+		handler     func(storage usecases.TodoRecordStorage, todoID int) error
+		wantTodo    func(todo models.TodoRecord) models.TodoRecord
+		wantTodoErr error
+		wantErr     error
+	}{
+		{
+			name: "without changes of the to-do records",
+			handler: func(storage usecases.TodoRecordStorage, todoID int) error {
+				return nil
+			},
+			wantTodo: func(todo models.TodoRecord) models.TodoRecord {
+				todo.ListID = 0
+				return todo
+			},
+			wantTodoErr: nil,
+			wantErr:     nil,
+		},
+		{
+			name: "with changes of the to-do records",
+			handler: func(storage usecases.TodoRecordStorage, todoID int) error {
+				return storage.DeleteSingle(todoID, 0)
+			},
+			wantTodo: func(todo models.TodoRecord) models.TodoRecord {
+				return models.TodoRecord{}
+			},
+			wantTodoErr: models.ErrNotFound,
+			wantErr:     nil,
+		},
+		{
+			name: "with an error",
+			handler: func(storage usecases.TodoRecordStorage, todoID int) error {
+				if err := storage.DeleteSingle(todoID, 0); err != nil {
+					return err
+				}
+
+				return models.ErrConflict
+			},
+			wantTodo: func(todo models.TodoRecord) models.TodoRecord {
+				return todo
+			},
+			wantTodoErr: nil,
+			wantErr:     models.ErrConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storages := newStorages(t)
+			lists, todos := storages.List, storages.TodoRecord
+
+			createdList, err := lists.Create(models.List{Title: "test"})
+			require.NoError(t, err)
+
+			createdTodo, err := todos.Create(models.TodoRecord{
+				Date:   time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:  "test",
+				Order:  23,
+				ListID: createdList.ID,
+			})
+			require.NoError(t, err)
+
+			err = lists.DeleteSingle(
+				createdList.ID,
+				func(storage usecases.TodoRecordStorage) error {
+					return tt.handler(storage, createdTodo.ID)
+				},
+			)
+			assert.Equal(t, tt.wantErr, err)
+
+			_, err = lists.GetSingle(createdList.ID)
+			if tt.wantErr == nil {
+				assert.Equal(t, models.ErrNotFound, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			gotTodo, err := todos.GetSingle(createdTodo.ID)
+			gotTodo.Date = gotTodo.Date.In(time.UTC)
+			resetTimestamps(&gotTodo)
+
+			wantTodo := tt.wantTodo(createdTodo)
+			resetTimestamps(&wantTodo)
+			assert.Equal(t, wantTodo, gotTodo)
+			assert.Equal(t, tt.wantTodoErr, err)
+		})
+	}
+}
+
+// deleteListOnly is the handler of the deletion of the lists,
+// which doesn't change their to-do records.
+func deleteListOnly(storage usecases.TodoRecordStorage) error {
+	return nil
+}
+
+func testListWithMissingRecord(t *testing.T, newStorages NewStorages) {
+	storages := newStorages(t)
+	lists, todos := storages.List, storages.TodoRecord
+
+	createdList, err := lists.Create(models.List{Title: "test"})
+	require.NoError(t, err)
+
+	err = lists.DeleteSingle(createdList.ID, deleteListOnly)
+	require.NoError(t, err)
+
+	_, err = lists.Update(createdList.ID, models.List{Title: "test2"})
+	assert.Equal(t, models.ErrNotFound, err)
+
+	err = lists.DeleteSingle(createdList.ID, deleteListOnly)
+	assert.Equal(t, models.ErrNotFound, err)
+
+	_, err = todos.Create(models.TodoRecord{
+		Date:   time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title:  "test",
+		Order:  23,
+		ListID: createdList.ID,
+	})
+	assert.ErrorIs(t, err, models.ErrConflict)
+}
+
+func testListWithTodoRecords(t *testing.T, newStorages NewStorages) {
+	storages := newStorages(t)
+	lists, todos := storages.List, storages.TodoRecord
+
+	createdList, err := lists.Create(models.List{Title: "test"})
+	require.NoError(t, err)
+
+	var createdTodos []models.TodoRecord
+	for _, listID := range []int{0, createdList.ID} {
+		createdTodo, err := todos.Create(models.TodoRecord{
+			Date:   time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			Title:  "test",
+			Order:  23,
+			ListID: listID,
+		})
+		require.NoError(t, err)
+
+		createdTodos = append(createdTodos, createdTodo)
+	}
+
+	gotTodos, err := todos.GetAll(models.Query{ListID: createdList.ID})
+	require.NoError(t, err)
+	for index := range gotTodos {
+		gotTodos[index].Date = gotTodos[index].Date.In(time.UTC)
+	}
+
+	assert.Equal(t, createdTodos[1:], gotTodos)
+}
@@ -0,0 +1,20 @@
+// Package storagetest implements the checks shared by the storages,
+// so each of them runs the same suite with its own constructor.
+package storagetest
+
+import (
+	"testing"
+
+	usecases "github.com/irenicaa/go-todo-backend/v2/use-cases"
+)
+
+// Storages share the same DB.
+type Storages struct {
+	TodoRecord usecases.TodoRecordStorage
+	List       usecases.ListStorage
+	Tag        usecases.TagStorage
+}
+
+// NewStorages may return the storages over a DB shared between the checks,
+// so the checks clear the to-do records themselves when it matters.
+type NewStorages func(t *testing.T) Storages
@@ -0,0 +1,53 @@
+package storagetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunTagTests checks the implementation
+// of the usecases.TagStorage interface.
+func RunTagTests(t *testing.T, newStorages NewStorages) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, newStorages NewStorages)
+	}{
+		{name: "GetAll", test: testTagGetAll},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStorages)
+		})
+	}
+}
+
+func testTagGetAll(t *testing.T, newStorages NewStorages) {
+	storages := newStorages(t)
+	storage := storages.TodoRecord
+
+	_, err := storage.DeleteAll(models.Query{})
+	require.NoError(t, err)
+	for i := 0; i <= 6; i++ {
+		_, err := storage.Create(models.TodoRecord{
+			Date:  time.Date(2006, time.January, 2+i, 0, 0, 0, 0, time.UTC),
+			Title: "test",
+			Order: i,
+			Tags:  makeTags(i),
+		})
+		require.NoError(t, err)
+	}
+
+	gotTags, err := storages.Tag.GetAll()
+	require.NoError(t, err)
+
+	wantTags := []models.Tag{
+		{Name: "even", Count: 4},
+		{Name: "odd", Count: 3},
+		{Name: "triple", Count: 3},
+	}
+	assert.Equal(t, wantTags, gotTags)
+}
@@ -0,0 +1,2089 @@
+package storagetest
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	usecases "github.com/irenicaa/go-todo-backend/v2/use-cases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunTodoRecordTests checks the implementation
+// of the usecases.TodoRecordStorage interface.
+func RunTodoRecordTests(t *testing.T, newStorages NewStorages) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, newStorages NewStorages)
+	}{
+		{name: "withGetting", test: testTodoRecordWithGetting},
+		{name: "withQuerying", test: testTodoRecordWithQuerying},
+		{name: "withQueryingByIDs", test: testTodoRecordWithQueryingByIDs},
+		{name: "withCounting", test: testTodoRecordWithCounting},
+		{name: "withTransaction", test: testTodoRecordWithTransaction},
+		{name: "withModifying", test: testTodoRecordWithModifying},
+		{name: "withDeleting", test: testTodoRecordWithDeleting},
+		{name: "withDeletingByQuery", test: testTodoRecordWithDeletingByQuery},
+		{name: "withTrash", test: testTodoRecordWithTrash},
+		{name: "withHistory", test: testTodoRecordWithHistory},
+		{name: "withHistoryPages", test: testTodoRecordWithHistoryPages},
+		{name: "withTimestamps", test: testTodoRecordWithTimestamps},
+		{name: "withDueTime", test: testTodoRecordWithDueTime},
+		{name: "withRecurrence", test: testTodoRecordWithRecurrence},
+		{name: "withParent", test: testTodoRecordWithParent},
+		{name: "withDependencies", test: testTodoRecordWithDependencies},
+		{name: "withNotes", test: testTodoRecordWithNotes},
+		{name: "withPriority", test: testTodoRecordWithPriority},
+		{name: "withMissingRecord", test: testTodoRecordWithMissingRecord},
+		{name: "withVersionMismatch", test: testTodoRecordWithVersionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStorages)
+		})
+	}
+}
+
+func testTodoRecordWithGetting(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	_, err := storage.DeleteAll(models.Query{})
+	require.NoError(t, err)
+
+	var createdTodos []models.TodoRecord
+	for i := 0; i <= 10; i++ {
+		originalTodo := models.TodoRecord{
+			Date:      time.Date(2006, time.January, 2+i, 0, 0, 0, 0, time.UTC),
+			Title:     "test" + strconv.Itoa(i),
+			Completed: true,
+			Order:     i,
+		}
+
+		createdTodo, err2 := storage.Create(originalTodo)
+		require.NoError(t, err2)
+		originalTodo.ID = createdTodo.ID
+		originalTodo.Version = createdTodo.Version
+		originalTodo.CreatedAt = createdTodo.CreatedAt
+		originalTodo.UpdatedAt = createdTodo.UpdatedAt
+		originalTodo.CompletedAt = createdTodo.CompletedAt
+
+		createdTodos = append(createdTodos, originalTodo)
+	}
+	// reversing
+	for i, j := 0, len(createdTodos)-1; i < j; i, j = i+1, j-1 {
+		createdTodos[i], createdTodos[j] = createdTodos[j], createdTodos[i]
+	}
+
+	gotTodos, err := storage.GetAll(models.Query{})
+	require.NoError(t, err)
+	for index := range gotTodos {
+		gotTodos[index].Date = gotTodos[index].Date.In(time.UTC)
+	}
+
+	assert.Equal(t, createdTodos, gotTodos)
+}
+
+func testTodoRecordWithQuerying(t *testing.T, newStorages NewStorages) {
+	tests := []struct {
+		name          string
+		originalTodos []models.TodoRecord
+		query         models.Query
+		wantTodos     []models.TodoRecord
+	}{
+		{
+			name: "with filtration by the minimal date",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 10; i++ {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{
+				MinimalDate: utilmodels.Date(time.Date(
+					2006, time.January, 7,
+					0, 0, 0, 0,
+					time.UTC,
+				)),
+			},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for i := 10; i >= 5; i-- {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with filtration by the maximal date",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 10; i++ {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{
+				MaximalDate: utilmodels.Date(time.Date(
+					2006, time.January, 7,
+					0, 0, 0, 0,
+					time.UTC,
+				)),
+			},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for i := 5; i >= 0; i-- {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with filtration by the date range",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 10; i++ {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{
+				MinimalDate: utilmodels.Date(time.Date(
+					2006, time.January, 5,
+					0, 0, 0, 0,
+					time.UTC,
+				)),
+				MaximalDate: utilmodels.Date(time.Date(
+					2006, time.January, 9,
+					0, 0, 0, 0,
+					time.UTC,
+				)),
+			},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for i := 7; i >= 3; i-- {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with search by the title fragment",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 10; i++ {
+					var mark string
+					if i%2 == 0 {
+						mark = "even"
+					} else {
+						mark = "odd"
+					}
+
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     fmt.Sprintf("test%d (%s)", i, mark),
+						Completed: true,
+						Order:     i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{TitleFragment: "even"},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for i := 10; i >= 0; i -= 2 {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     fmt.Sprintf("test%d (even)", i),
+						Completed: true,
+						Order:     i,
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with filtration by the completion status and the order range",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 10; i++ {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: i%2 == 0,
+						Order:     i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: func() models.Query {
+				completed, minimalOrder, maximalOrder := false, 3, 8
+				return models.Query{
+					Completed:    &completed,
+					MinimalOrder: &minimalOrder,
+					MaximalOrder: &maximalOrder,
+				}
+			}(),
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for i := 7; i >= 3; i -= 2 {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: false,
+						Order:     i,
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with the search and highlighting",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i, title := range []string{
+					"Buy milk",
+					"Buy bread",
+					"Milk the cow today",
+				} {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title: title,
+						Order: i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{Search: "milk", Highlight: true},
+			wantTodos: []models.TodoRecord{
+				{
+					Date: time.Date(
+						2006, time.January, 4,
+						0, 0, 0, 0,
+						time.UTC,
+					),
+					Title:     "Milk the cow today",
+					Order:     2,
+					Highlight: "<mark>Milk</mark> the cow today",
+				},
+				{
+					Date: time.Date(
+						2006, time.January, 2,
+						0, 0, 0, 0,
+						time.UTC,
+					),
+					Title:     "Buy milk",
+					Order:     0,
+					Highlight: "Buy <mark>milk</mark>",
+				},
+			},
+		},
+		{
+			name: "with the search in the notes",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i, texts := range [][2]string{
+					{"Buy milk", ""},
+					{"Go shopping", "Buy milk and bread"},
+					{"Cook", "Use the bread"},
+				} {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title: texts[0],
+						Notes: texts[1],
+						Order: i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{Search: "milk", SearchNotes: true},
+			wantTodos: []models.TodoRecord{
+				{
+					Date: time.Date(
+						2006, time.January, 3,
+						0, 0, 0, 0,
+						time.UTC,
+					),
+					Title: "Go shopping",
+					Notes: "Buy milk and bread",
+					Order: 1,
+				},
+				{
+					Date: time.Date(
+						2006, time.January, 2,
+						0, 0, 0, 0,
+						time.UTC,
+					),
+					Title: "Buy milk",
+					Order: 0,
+				},
+			},
+		},
+		{
+			name: "with the search in the notes and highlighting",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i, texts := range [][2]string{
+					{"Buy milk", ""},
+					{"Go shopping", "Buy milk and bread"},
+					{"Cook", "Use the bread"},
+				} {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title: texts[0],
+						Notes: texts[1],
+						Order: i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{Search: "milk", SearchNotes: true, Highlight: true},
+			wantTodos: []models.TodoRecord{
+				{
+					Date: time.Date(
+						2006, time.January, 3,
+						0, 0, 0, 0,
+						time.UTC,
+					),
+					Title:          "Go shopping",
+					Notes:          "Buy milk and bread",
+					Order:          1,
+					Highlight:      "Go shopping",
+					NotesHighlight: "Buy <mark>milk</mark> and bread",
+				},
+				{
+					Date: time.Date(
+						2006, time.January, 2,
+						0, 0, 0, 0,
+						time.UTC,
+					),
+					Title:     "Buy milk",
+					Order:     0,
+					Highlight: "Buy <mark>milk</mark>",
+				},
+			},
+		},
+		{
+			name: "with search by the fragment in the notes",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i, texts := range [][2]string{
+					{"Buy milk", ""},
+					{"Go shopping", "Buy milk and bread"},
+					{"Bread", ""},
+				} {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title: texts[0],
+						Notes: texts[1],
+						Order: i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{TitleFragment: "BREAD", SearchNotes: true},
+			wantTodos: []models.TodoRecord{
+				{
+					Date: time.Date(
+						2006, time.January, 4,
+						0, 0, 0, 0,
+						time.UTC,
+					),
+					Title: "Bread",
+					Order: 2,
+				},
+				{
+					Date: time.Date(
+						2006, time.January, 3,
+						0, 0, 0, 0,
+						time.UTC,
+					),
+					Title: "Go shopping",
+					Notes: "Buy milk and bread",
+					Order: 1,
+				},
+			},
+		},
+		{
+			name: "with sorting",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 5; i++ {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: i%2 == 0,
+						Order:     i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{
+				Sort: []models.SortField{
+					{Name: models.SortByCompleted},
+					{Name: models.SortByTitle, Descending: true},
+				},
+			},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for _, i := range []int{5, 3, 1, 4, 2, 0} {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: i%2 == 0,
+						Order:     i,
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with pagination",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 10; i++ {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{Pagination: models.Pagination{PageSize: 2, Page: 3}},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for i := 6; i >= 5; i-- {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with cursor pagination",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 10; i++ {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{
+				Pagination: models.Pagination{
+					PageSize: 2,
+					// the cursor points after all the records of its date and order
+					Cursor: models.Cursor{
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 8,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Order: 6,
+						ID:    math.MaxInt32,
+					},
+				},
+			},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for i := 5; i >= 4; i-- {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with filtration by the minimal priority and the default order",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 5; i++ {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:    "test" + strconv.Itoa(i),
+						Order:    i,
+						Priority: i % 3,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{
+				MinimalPriority: func() *int {
+					minimalPriority := models.PriorityLow
+					return &minimalPriority
+				}(),
+			},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for _, i := range []int{5, 4, 2, 1} {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:    "test" + strconv.Itoa(i),
+						Order:    i,
+						Priority: i % 3,
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with filtration by the minimal priority and the priority sort",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 5; i++ {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:    "test" + strconv.Itoa(i),
+						Order:    i,
+						Priority: i % 3,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{
+				MinimalPriority: func() *int {
+					minimalPriority := models.PriorityLow
+					return &minimalPriority
+				}(),
+				Sort: []models.SortField{
+					{Name: models.SortByPriority, Descending: true},
+				},
+			},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for _, i := range []int{5, 2, 4, 1} {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:    "test" + strconv.Itoa(i),
+						Order:    i,
+						Priority: i % 3,
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with cursor pagination and priorities",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 5; i++ {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:    "test" + strconv.Itoa(i),
+						Order:    i,
+						Priority: i % 3,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{
+				// the configured default sort is set by the use case
+				Sort: []models.SortField{
+					{Name: models.SortByPriority, Descending: true},
+				},
+				Pagination: models.Pagination{
+					PageSize: 3,
+					// the cursor points after all the records of the highest priority
+					Cursor: models.Cursor{
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 4,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Order:    2,
+						ID:       math.MaxInt32,
+						Priority: models.PriorityMedium,
+					},
+				},
+			},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for _, i := range []int{4, 1, 3} {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:    "test" + strconv.Itoa(i),
+						Order:    i,
+						Priority: i % 3,
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with filtration by any of the tags",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 6; i++ {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+						Tags:      makeTags(i),
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{Tags: []string{"triple", "missed"}},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for i := 6; i >= 0; i -= 3 {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+						Tags:      makeTags(i),
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with filtration by all of the tags",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 6; i++ {
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+						Tags:      makeTags(i),
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{AllTags: []string{"even", "triple", "even"}},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for i := 6; i >= 0; i -= 6 {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: true,
+						Order:     i,
+						Tags:      makeTags(i),
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with all parameters",
+			originalTodos: func() []models.TodoRecord {
+				var originalTodos []models.TodoRecord
+				for i := 0; i <= 20; i++ {
+					var mark string
+					if i%2 == 0 {
+						mark = "even"
+					} else {
+						mark = "odd"
+					}
+
+					originalTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     fmt.Sprintf("test%d (%s)", i, mark),
+						Completed: true,
+						Order:     i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			query: models.Query{
+				MinimalDate: utilmodels.Date(time.Date(
+					2006, time.January, 5,
+					0, 0, 0, 0,
+					time.UTC,
+				)),
+				MaximalDate: utilmodels.Date(time.Date(
+					2006, time.January, 19,
+					0, 0, 0, 0,
+					time.UTC,
+				)),
+				TitleFragment: "even",
+				Pagination:    models.Pagination{PageSize: 2, Page: 3},
+			},
+			wantTodos: func() []models.TodoRecord {
+				var wantTodos []models.TodoRecord
+				for i := 8; i >= 6; i -= 2 {
+					wantTodo := models.TodoRecord{
+						Date: time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						),
+						Title:     fmt.Sprintf("test%d (even)", i),
+						Completed: true,
+						Order:     i,
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newStorages(t).TodoRecord
+
+			_, err := storage.DeleteAll(models.Query{})
+			require.NoError(t, err)
+
+			for _, originalTodo := range tt.originalTodos {
+				_, err2 := storage.Create(originalTodo)
+				require.NoError(t, err2)
+			}
+
+			gotTodos, err := storage.GetAll(tt.query)
+			require.NoError(t, err)
+			for index := range gotTodos {
+				gotTodos[index].ID = 0
+				gotTodos[index].Version = 0
+				resetTimestamps(&gotTodos[index])
+				gotTodos[index].Date = gotTodos[index].Date.In(time.UTC)
+			}
+
+			assert.Equal(t, tt.wantTodos, gotTodos)
+		})
+	}
+}
+
+func testTodoRecordWithQueryingByIDs(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	_, err := storage.DeleteAll(models.Query{})
+	require.NoError(t, err)
+
+	var createdTodos []models.TodoRecord
+	for i := 0; i <= 10; i++ {
+		createdTodo, err := storage.Create(models.TodoRecord{
+			Date:  time.Date(2006, time.January, 2+i, 0, 0, 0, 0, time.UTC),
+			Title: "test" + strconv.Itoa(i),
+			Order: i,
+		})
+		require.NoError(t, err)
+
+		createdTodos = append(createdTodos, createdTodo)
+	}
+
+	gotTodos, err := storage.GetAll(models.Query{
+		IDs: []int{createdTodos[2].ID, createdTodos[5].ID, createdTodos[7].ID},
+	})
+	require.NoError(t, err)
+
+	var gotIDs []int
+	for _, todo := range gotTodos {
+		gotIDs = append(gotIDs, todo.ID)
+	}
+
+	wantIDs := []int{createdTodos[7].ID, createdTodos[5].ID, createdTodos[2].ID}
+	assert.Equal(t, wantIDs, gotIDs)
+}
+
+func testTodoRecordWithCounting(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	_, err := storage.DeleteAll(models.Query{})
+	require.NoError(t, err)
+
+	for i := 0; i <= 10; i++ {
+		_, err := storage.Create(models.TodoRecord{
+			Date:  time.Date(2006, time.January, 2+i, 0, 0, 0, 0, time.UTC),
+			Title: "test" + strconv.Itoa(i),
+			Order: i,
+		})
+		require.NoError(t, err)
+	}
+
+	// the pagination should be ignored
+	gotCount, err := storage.Count(models.Query{
+		MinimalDate: utilmodels.Date(
+			time.Date(2006, time.January, 7, 0, 0, 0, 0, time.UTC),
+		),
+		Pagination: models.Pagination{PageSize: 2, Page: 3},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, gotCount)
+}
+
+func testTodoRecordWithTransaction(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	_, err := storage.DeleteAll(models.Query{})
+	require.NoError(t, err)
+
+	err = storage.InTransaction(func(txStorage usecases.TodoRecordStorage) error {
+		_, err := txStorage.Create(models.TodoRecord{
+			Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			Title: "test1",
+		})
+		require.NoError(t, err)
+
+		// the failed nested transaction should roll back only its own changes
+		err = txStorage.InTransaction(
+			func(nestedStorage usecases.TodoRecordStorage) error {
+				_, err := nestedStorage.Create(models.TodoRecord{
+					Date:  time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+					Title: "test2",
+				})
+				require.NoError(t, err)
+
+				return iotest.ErrTimeout
+			},
+		)
+		require.ErrorIs(t, err, iotest.ErrTimeout)
+
+		// the failed call shouldn't break the transaction
+		_, err = txStorage.Create(models.TodoRecord{
+			Date:   time.Date(2006, time.January, 4, 0, 0, 0, 0, time.UTC),
+			Title:  "test3",
+			ListID: math.MaxInt32,
+		})
+		require.ErrorIs(t, err, models.ErrConflict)
+
+		_, err = txStorage.Create(models.TodoRecord{
+			Date:  time.Date(2006, time.January, 5, 0, 0, 0, 0, time.UTC),
+			Title: "test4",
+		})
+		require.NoError(t, err)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = storage.InTransaction(func(txStorage usecases.TodoRecordStorage) error {
+		_, err := txStorage.Create(models.TodoRecord{
+			Date:  time.Date(2006, time.January, 6, 0, 0, 0, 0, time.UTC),
+			Title: "test5",
+		})
+		require.NoError(t, err)
+
+		return iotest.ErrTimeout
+	})
+	require.ErrorIs(t, err, iotest.ErrTimeout)
+
+	gotTodos, err := storage.GetAll(models.Query{})
+	require.NoError(t, err)
+
+	var gotTitles []string
+	for _, todo := range gotTodos {
+		gotTitles = append(gotTitles, todo.Title)
+	}
+	assert.Equal(t, []string{"test4", "test1"}, gotTitles)
+}
+
+func testTodoRecordWithModifying(t *testing.T, newStorages NewStorages) {
+	tests := []struct {
+		name         string
+		originalTodo models.TodoRecord
+		action       func(t *testing.T, storage usecases.TodoRecordStorage, todoID int)
+		wantTodo     models.TodoRecord
+	}{
+		{
+			name: "creation",
+			originalTodo: models.TodoRecord{
+				Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:     "test",
+				Completed: true,
+				Order:     23,
+			},
+			action: func(t *testing.T, storage usecases.TodoRecordStorage, todoID int) {},
+			wantTodo: models.TodoRecord{
+				Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:     "test",
+				Completed: true,
+				Order:     23,
+				Version:   1,
+			},
+		},
+		{
+			name: "updating",
+			originalTodo: models.TodoRecord{
+				Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:     "test",
+				Completed: true,
+				Order:     23,
+			},
+			action: func(t *testing.T, storage usecases.TodoRecordStorage, todoID int) {
+				newTodo := models.TodoRecord{
+					Date:      time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+					Title:     "test2",
+					Completed: false,
+					Order:     42,
+				}
+
+				_, err := storage.Update(todoID, newTodo, 1)
+				require.NoError(t, err)
+			},
+			wantTodo: models.TodoRecord{
+				Date:      time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+				Title:     "test2",
+				Completed: false,
+				Order:     42,
+				Version:   2,
+			},
+		},
+		{
+			name: "patching",
+			originalTodo: models.TodoRecord{
+				Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:     "test",
+				Completed: true,
+				Order:     23,
+			},
+			action: func(t *testing.T, storage usecases.TodoRecordStorage, todoID int) {
+				title := "test2"
+				completed := false
+				_, err := storage.Patch(
+					todoID,
+					models.TodoRecordPatch{Title: &title, Completed: &completed},
+					0,
+				)
+				require.NoError(t, err)
+			},
+			wantTodo: models.TodoRecord{
+				Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:     "test2",
+				Completed: false,
+				Order:     23,
+				Version:   2,
+			},
+		},
+		{
+			name: "creation with tags",
+			originalTodo: models.TodoRecord{
+				Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:     "test",
+				Completed: true,
+				Order:     23,
+				Tags:      []string{"two", "one", "two"},
+			},
+			action: func(t *testing.T, storage usecases.TodoRecordStorage, todoID int) {},
+			wantTodo: models.TodoRecord{
+				Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:     "test",
+				Completed: true,
+				Order:     23,
+				Tags:      []string{"one", "two"},
+				Version:   1,
+			},
+		},
+		{
+			name: "patching of tags",
+			originalTodo: models.TodoRecord{
+				Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:     "test",
+				Completed: true,
+				Order:     23,
+				Tags:      []string{"one", "two"},
+			},
+			action: func(t *testing.T, storage usecases.TodoRecordStorage, todoID int) {
+				tags := []string{"two", "three"}
+				_, err := storage.Patch(todoID, models.TodoRecordPatch{Tags: &tags}, 0)
+				require.NoError(t, err)
+			},
+			wantTodo: models.TodoRecord{
+				Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:     "test",
+				Completed: true,
+				Order:     23,
+				Tags:      []string{"three", "two"},
+				Version:   2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newStorages(t).TodoRecord
+
+			createdTodo, err := storage.Create(tt.originalTodo)
+			require.NoError(t, err)
+
+			tt.action(t, storage, createdTodo.ID)
+
+			gotTodo, err := storage.GetSingle(createdTodo.ID)
+			require.NoError(t, err)
+			gotTodo.Date = gotTodo.Date.In(time.UTC)
+
+			resetTimestamps(&gotTodo)
+
+			tt.wantTodo.ID = createdTodo.ID
+			assert.Equal(t, tt.wantTodo, gotTodo)
+		})
+	}
+}
+
+func testTodoRecordWithDeleting(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	originalTodo := models.TodoRecord{
+		Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title:     "test",
+		Completed: true,
+		Order:     23,
+	}
+
+	createdTodo, err := storage.Create(originalTodo)
+	require.NoError(t, err)
+
+	err = storage.DeleteSingle(createdTodo.ID, 0)
+	require.NoError(t, err)
+
+	todo, err := storage.GetSingle(createdTodo.ID)
+
+	assert.Equal(t, models.TodoRecord{}, todo)
+	assert.Equal(t, models.ErrNotFound, err)
+}
+
+func testTodoRecordWithDeletingByQuery(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	_, err := storage.DeleteAll(models.Query{})
+	require.NoError(t, err)
+
+	for i := 0; i <= 10; i++ {
+		_, err := storage.Create(models.TodoRecord{
+			Date:      time.Date(2006, time.January, 2+i, 0, 0, 0, 0, time.UTC),
+			Title:     "test" + strconv.Itoa(i),
+			Completed: i%2 == 0,
+			Order:     i,
+			Tags:      []string{"tag" + strconv.Itoa(i%3)},
+		})
+		require.NoError(t, err)
+	}
+
+	// the pagination should be ignored
+	completed := true
+	gotCount, err := storage.DeleteAll(models.Query{
+		MinimalDate: utilmodels.Date(
+			time.Date(2006, time.January, 4, 0, 0, 0, 0, time.UTC),
+		),
+		Tags:       []string{"tag0", "tag1"},
+		Completed:  &completed,
+		Pagination: models.Pagination{PageSize: 2, Page: 1},
+	})
+	require.NoError(t, err)
+
+	gotTodos, err := storage.GetAll(models.Query{})
+	require.NoError(t, err)
+
+	var gotTitles []string
+	for _, todo := range gotTodos {
+		gotTitles = append(gotTitles, todo.Title)
+	}
+
+	assert.Equal(t, 3, gotCount)
+	assert.Equal(t, []string{
+		"test9",
+		"test8",
+		"test7",
+		"test5",
+		"test3",
+		"test2",
+		"test1",
+		"test0",
+	}, gotTitles)
+}
+
+func testTodoRecordWithTrash(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	_, err := storage.DeleteAll(models.Query{})
+	require.NoError(t, err)
+	_, err = storage.Purge(0)
+	require.NoError(t, err)
+
+	createdTodo, err := storage.Create(models.TodoRecord{
+		Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title: "test",
+	})
+	require.NoError(t, err)
+
+	err = storage.DeleteSingle(createdTodo.ID, 0)
+	require.NoError(t, err)
+
+	gotTodos, err := storage.GetAll(models.Query{})
+	require.NoError(t, err)
+	assert.Empty(t, gotTodos)
+
+	gotTodos, err = storage.GetAll(models.Query{Deleted: true})
+	require.NoError(t, err)
+	require.Len(t, gotTodos, 1)
+	assert.Equal(t, createdTodo.ID, gotTodos[0].ID)
+	assert.NotNil(t, gotTodos[0].DeletedAt)
+
+	restoredTodo, err := storage.Restore(createdTodo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, createdTodo.ID, restoredTodo.ID)
+	assert.Nil(t, restoredTodo.DeletedAt)
+
+	_, err = storage.Restore(createdTodo.ID)
+	assert.Equal(t, models.ErrNotFound, err)
+
+	err = storage.DeleteSingle(createdTodo.ID, 0)
+	require.NoError(t, err)
+
+	gotCount, err := storage.Purge(time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 0, gotCount)
+
+	gotCount, err = storage.Purge(0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gotCount)
+
+	_, err = storage.Restore(createdTodo.ID)
+	assert.Equal(t, models.ErrNotFound, err)
+}
+
+func testTodoRecordWithHistory(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	createdTodo, err := storage.Create(models.TodoRecord{
+		Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title: "test",
+	})
+	require.NoError(t, err)
+
+	startedAt := time.Now().Add(-time.Minute)
+	err = storage.AddHistoryRecord(models.HistoryRecord{
+		TodoRecordID: createdTodo.ID,
+		Action:       models.HistoryActionCreate,
+		NewValue:     &createdTodo,
+		Actor:        "admin",
+	})
+	require.NoError(t, err)
+
+	err = storage.InTransaction(func(txStorage usecases.TodoRecordStorage) error {
+		err := txStorage.AddHistoryRecord(models.HistoryRecord{
+			TodoRecordID: createdTodo.ID,
+			Action:       models.HistoryActionDelete,
+			OldValue:     &createdTodo,
+		})
+		require.NoError(t, err)
+
+		return iotest.ErrTimeout
+	})
+	require.Equal(t, iotest.ErrTimeout, err)
+
+	gotRecords, err := storage.GetHistory(models.HistoryQuery{
+		TodoRecordID: createdTodo.ID,
+		Since:        startedAt,
+	})
+	require.NoError(t, err)
+	require.Len(t, gotRecords, 1)
+	assert.Equal(t, createdTodo.ID, gotRecords[0].TodoRecordID)
+	assert.Equal(t, models.HistoryActionCreate, gotRecords[0].Action)
+	assert.Nil(t, gotRecords[0].OldValue)
+	require.NotNil(t, gotRecords[0].NewValue)
+	assert.Equal(t, createdTodo.ID, gotRecords[0].NewValue.ID)
+	assert.Equal(t, createdTodo.Title, gotRecords[0].NewValue.Title)
+	require.NotNil(t, gotRecords[0].NewValuePatch)
+	assert.Equal(
+		t,
+		models.NewHistoryPatch(*gotRecords[0].NewValue),
+		*gotRecords[0].NewValuePatch,
+	)
+	assert.Equal(t, "admin", gotRecords[0].Actor)
+	assert.False(t, gotRecords[0].ChangedAt.Before(startedAt))
+
+	gotRecord, err := storage.GetHistoryRecord(gotRecords[0].Revision)
+	require.NoError(t, err)
+	assert.Equal(t, gotRecords[0], gotRecord)
+
+	gotRecords, err = storage.GetHistory(models.HistoryQuery{
+		TodoRecordID: createdTodo.ID,
+		Since:        time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, gotRecords)
+
+	_, err = storage.GetHistoryRecord(math.MaxInt32)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
+
+func testTodoRecordWithHistoryPages(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	createdTodo, err := storage.Create(models.TodoRecord{
+		Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title: "test",
+	})
+	require.NoError(t, err)
+
+	for _, action := range []string{
+		models.HistoryActionCreate,
+		models.HistoryActionPatch,
+		models.HistoryActionDelete,
+	} {
+		err := storage.AddHistoryRecord(models.HistoryRecord{
+			TodoRecordID: createdTodo.ID,
+			Action:       action,
+			OldValue:     &createdTodo,
+		})
+		require.NoError(t, err)
+	}
+
+	allRecords, err := storage.GetHistory(models.HistoryQuery{
+		TodoRecordID: createdTodo.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, allRecords, 3)
+
+	gotRecords, err := storage.GetHistory(models.HistoryQuery{
+		TodoRecordID: createdTodo.ID,
+		PageSize:     2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, allRecords[:2], gotRecords)
+
+	gotRecords, err = storage.GetHistory(models.HistoryQuery{
+		TodoRecordID: createdTodo.ID,
+		PageSize:     2,
+		Cursor:       models.HistoryCursor{Revision: allRecords[1].Revision},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, allRecords[2:], gotRecords)
+
+	gotRecords, err = storage.GetHistory(models.HistoryQuery{
+		TodoRecordID: createdTodo.ID,
+		PageSize:     2,
+		Cursor:       models.HistoryCursor{Revision: allRecords[2].Revision},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, gotRecords)
+}
+
+func testTodoRecordWithTimestamps(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	createdTodo, err := storage.Create(models.TodoRecord{
+		Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title: "test",
+	})
+	require.NoError(t, err)
+	assert.False(t, createdTodo.CreatedAt.IsZero())
+	assert.Equal(t, createdTodo.CreatedAt, createdTodo.UpdatedAt)
+	assert.Nil(t, createdTodo.CompletedAt)
+
+	// make the timestamps distinct even with the millisecond precision
+	time.Sleep(10 * time.Millisecond)
+
+	completedTodo, err := storage.Create(models.TodoRecord{
+		Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title:     "test",
+		Completed: true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, completedTodo.CompletedAt)
+	assert.Equal(t, completedTodo.CreatedAt, *completedTodo.CompletedAt)
+
+	completed := true
+	patchedTodo, err := storage.Patch(
+		createdTodo.ID,
+		models.TodoRecordPatch{Completed: &completed},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, createdTodo.CreatedAt, patchedTodo.CreatedAt)
+	assert.False(t, patchedTodo.UpdatedAt.Before(createdTodo.UpdatedAt))
+	require.NotNil(t, patchedTodo.CompletedAt)
+	assert.Equal(t, patchedTodo.UpdatedAt, *patchedTodo.CompletedAt)
+
+	time.Sleep(10 * time.Millisecond)
+
+	title := "test2"
+	repatchedTodo, err := storage.Patch(
+		createdTodo.ID,
+		models.TodoRecordPatch{Title: &title, Completed: &completed},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, patchedTodo.CompletedAt, repatchedTodo.CompletedAt)
+
+	updatedTodo, err := storage.Update(
+		createdTodo.ID,
+		models.TodoRecord{
+			Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			Title: "test",
+		},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, createdTodo.CreatedAt, updatedTodo.CreatedAt)
+	assert.Nil(t, updatedTodo.CompletedAt)
+
+	ids := []int{createdTodo.ID, completedTodo.ID}
+	gotTodos, err := storage.GetAll(models.Query{
+		IDs:          ids,
+		CreatedAfter: completedTodo.CreatedAt,
+	})
+	require.NoError(t, err)
+	require.Len(t, gotTodos, 1)
+	assert.Equal(t, completedTodo.ID, gotTodos[0].ID)
+
+	gotTodos, err = storage.GetAll(models.Query{
+		IDs:           ids,
+		CreatedBefore: completedTodo.CreatedAt,
+	})
+	require.NoError(t, err)
+	require.Len(t, gotTodos, 1)
+	assert.Equal(t, createdTodo.ID, gotTodos[0].ID)
+
+	gotTodos, err = storage.GetAll(models.Query{
+		IDs:          ids,
+		UpdatedAfter: updatedTodo.UpdatedAt,
+	})
+	require.NoError(t, err)
+	require.Len(t, gotTodos, 1)
+	assert.Equal(t, createdTodo.ID, gotTodos[0].ID)
+
+	gotTodos, err = storage.GetAll(models.Query{
+		IDs:             ids,
+		CompletedBefore: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, gotTodos, 1)
+	assert.Equal(t, completedTodo.ID, gotTodos[0].ID)
+
+	for _, descending := range []bool{false, true} {
+		gotTodos, err = storage.GetAll(models.Query{
+			IDs: ids,
+			Sort: []models.SortField{
+				{Name: models.SortByCompletedAt, Descending: descending},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, gotTodos, 2)
+		assert.Equal(t, completedTodo.ID, gotTodos[0].ID)
+		assert.Equal(t, createdTodo.ID, gotTodos[1].ID)
+	}
+}
+
+func testTodoRecordWithDueTime(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	timeZone := time.FixedZone("UTC+9", 9*60*60)
+	dueAt := time.Date(2006, time.January, 2, 23, 30, 0, 0, time.UTC)
+	dueTodo, err := storage.Create(models.TodoRecord{
+		Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		DueAt: &dueAt,
+		Title: "test",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, dueTodo.DueAt)
+	assert.Equal(t, dueAt, *dueTodo.DueAt)
+
+	dateTodo, err := storage.Create(models.TodoRecord{
+		Date:  time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+		Title: "test2",
+	})
+	require.NoError(t, err)
+	assert.Nil(t, dateTodo.DueAt)
+
+	ids := []int{dueTodo.ID, dateTodo.ID}
+	getIDs := func(query models.Query) []int {
+		query.IDs = ids
+
+		todos, err := storage.GetAll(query)
+		require.NoError(t, err)
+
+		var gotIDs []int
+		for _, todo := range todos {
+			gotIDs = append(gotIDs, todo.ID)
+		}
+
+		return gotIDs
+	}
+
+	assert.ElementsMatch(t, []int{dueTodo.ID, dateTodo.ID}, getIDs(models.Query{
+		Date: utilmodels.Date(
+			time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+		),
+		TimeZone: timeZone,
+	}))
+	assert.Equal(t, []int{dueTodo.ID}, getIDs(models.Query{
+		Date: utilmodels.Date(
+			time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		),
+	}))
+	assert.Equal(t, []int{dueTodo.ID}, getIDs(models.Query{
+		DueAfter: dueAt.Add(-time.Hour),
+	}))
+	assert.Empty(t, getIDs(models.Query{DueBefore: dueAt}))
+
+	patchedTodo, err := storage.Patch(
+		dueTodo.ID,
+		models.TodoRecordPatch{DueAt: models.NullableTimestamp{IsSet: true}},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Nil(t, patchedTodo.DueAt)
+
+	assert.Equal(t, []int{dateTodo.ID}, getIDs(models.Query{
+		Date: utilmodels.Date(
+			time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+		),
+		TimeZone: timeZone,
+	}))
+}
+
+func testTodoRecordWithRecurrence(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	createdTodo, err := storage.Create(models.TodoRecord{
+		Date:       time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title:      "test",
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO", createdTodo.Recurrence)
+
+	title := "test2"
+	patchedTodo, err := storage.Patch(
+		createdTodo.ID,
+		models.TodoRecordPatch{Title: &title},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO", patchedTodo.Recurrence)
+
+	recurrence := "FREQ=DAILY;COUNT=2"
+	patchedTodo, err = storage.Patch(
+		createdTodo.ID,
+		models.TodoRecordPatch{Recurrence: &recurrence},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=DAILY;COUNT=2", patchedTodo.Recurrence)
+
+	updatedTodo, err := storage.Update(
+		createdTodo.ID,
+		models.TodoRecord{
+			Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			Title: "test",
+		},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Empty(t, updatedTodo.Recurrence)
+
+	gotTodo, err := storage.GetSingle(createdTodo.ID)
+	require.NoError(t, err)
+	assert.Empty(t, gotTodo.Recurrence)
+}
+
+func testTodoRecordWithParent(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	parentTodo, err := storage.Create(models.TodoRecord{
+		Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title: "parent",
+	})
+	require.NoError(t, err)
+
+	childTodo, err := storage.Create(models.TodoRecord{
+		Date:     time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title:    "child",
+		ParentID: parentTodo.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, parentTodo.ID, childTodo.ParentID)
+
+	completedChildTodo, err := storage.Create(models.TodoRecord{
+		Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title:     "child2",
+		Completed: true,
+		ParentID:  parentTodo.ID,
+	})
+	require.NoError(t, err)
+
+	gotTodo, err := storage.GetSingle(parentTodo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, gotTodo.ChildCount)
+	assert.Equal(t, 1, gotTodo.CompletedChildCount)
+
+	gotChildren, err := storage.GetAll(models.Query{
+		ParentID: parentTodo.ID,
+		Sort:     []models.SortField{{Name: models.SortByID}},
+	})
+	require.NoError(t, err)
+	require.Len(t, gotChildren, 2)
+	assert.Equal(t, childTodo.ID, gotChildren[0].ID)
+	assert.Equal(t, completedChildTodo.ID, gotChildren[1].ID)
+
+	// the trashed children aren't counted
+	err = storage.DeleteSingle(childTodo.ID, 0)
+	require.NoError(t, err)
+
+	gotTodo, err = storage.GetSingle(parentTodo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gotTodo.ChildCount)
+	assert.Equal(t, 1, gotTodo.CompletedChildCount)
+
+	noParentID := 0
+	patchedTodo, err := storage.Patch(
+		completedChildTodo.ID,
+		models.TodoRecordPatch{ParentID: &noParentID},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Zero(t, patchedTodo.ParentID)
+
+	_, err = storage.Create(models.TodoRecord{
+		Date:     time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title:    "test",
+		ParentID: 1000000,
+	})
+	require.ErrorIs(t, err, models.ErrConflict)
+}
+
+func testTodoRecordWithDependencies(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	blockedTodo, err := storage.Create(models.TodoRecord{
+		Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title: "blocked",
+	})
+	require.NoError(t, err)
+
+	openBlocker, err := storage.Create(models.TodoRecord{
+		Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title: "blocker",
+	})
+	require.NoError(t, err)
+
+	completedBlocker, err := storage.Create(models.TodoRecord{
+		Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title:     "blocker2",
+		Completed: true,
+	})
+	require.NoError(t, err)
+
+	err = storage.AddDependency(blockedTodo.ID, completedBlocker.ID)
+	require.NoError(t, err)
+
+	gotTodo, err := storage.GetSingle(blockedTodo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{completedBlocker.ID}, gotTodo.BlockerIDs)
+	assert.False(t, gotTodo.Blocked)
+
+	err = storage.AddDependency(blockedTodo.ID, openBlocker.ID)
+	require.NoError(t, err)
+
+	// the existing dependency is ignored
+	err = storage.AddDependency(blockedTodo.ID, openBlocker.ID)
+	require.NoError(t, err)
+
+	ids := []int{blockedTodo.ID, openBlocker.ID, completedBlocker.ID}
+	blocked := true
+	gotTodos, err := storage.GetAll(models.Query{IDs: ids, Blocked: &blocked})
+	require.NoError(t, err)
+	require.Len(t, gotTodos, 1)
+	assert.Equal(t, blockedTodo.ID, gotTodos[0].ID)
+	assert.Equal(
+		t,
+		[]int{openBlocker.ID, completedBlocker.ID},
+		gotTodos[0].BlockerIDs,
+	)
+	assert.True(t, gotTodos[0].Blocked)
+
+	notBlocked := false
+	gotCount, err := storage.Count(models.Query{IDs: ids, Blocked: &notBlocked})
+	require.NoError(t, err)
+	assert.Equal(t, 2, gotCount)
+
+	// the trashed blockers aren't open, but they're still listed
+	err = storage.DeleteSingle(openBlocker.ID, 0)
+	require.NoError(t, err)
+
+	gotTodo, err = storage.GetSingle(blockedTodo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{openBlocker.ID, completedBlocker.ID}, gotTodo.BlockerIDs)
+	assert.False(t, gotTodo.Blocked)
+
+	gotBlockerIDs, err := storage.GetBlockerIDs(blockedTodo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{openBlocker.ID, completedBlocker.ID}, gotBlockerIDs)
+
+	err = storage.DeleteDependency(blockedTodo.ID, openBlocker.ID)
+	require.NoError(t, err)
+
+	err = storage.DeleteDependency(blockedTodo.ID, openBlocker.ID)
+	require.ErrorIs(t, err, models.ErrNotFound)
+
+	err = storage.AddDependency(blockedTodo.ID, 1000000)
+	require.ErrorIs(t, err, models.ErrConflict)
+}
+
+func testTodoRecordWithNotes(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	createdTodo, err := storage.Create(models.TodoRecord{
+		Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title: "test",
+		Notes: "*notes*",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "*notes*", createdTodo.Notes)
+
+	title := "test2"
+	patchedTodo, err := storage.Patch(
+		createdTodo.ID,
+		models.TodoRecordPatch{Title: &title},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "*notes*", patchedTodo.Notes)
+
+	notes := "**notes**"
+	patchedTodo, err = storage.Patch(
+		createdTodo.ID,
+		models.TodoRecordPatch{Notes: &notes},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "**notes**", patchedTodo.Notes)
+
+	updatedTodo, err := storage.Update(
+		createdTodo.ID,
+		models.TodoRecord{
+			Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			Title: "test",
+		},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Empty(t, updatedTodo.Notes)
+
+	gotTodo, err := storage.GetSingle(createdTodo.ID)
+	require.NoError(t, err)
+	assert.Empty(t, gotTodo.Notes)
+}
+
+func testTodoRecordWithPriority(t *testing.T, newStorages NewStorages) {
+	storage := newStorages(t).TodoRecord
+
+	createdTodo, err := storage.Create(models.TodoRecord{
+		Date:     time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title:    "test",
+		Priority: models.PriorityHigh,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.PriorityHigh, createdTodo.Priority)
+
+	title := "test2"
+	patchedTodo, err := storage.Patch(
+		createdTodo.ID,
+		models.TodoRecordPatch{Title: &title},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, models.PriorityHigh, patchedTodo.Priority)
+
+	priority := models.PriorityUrgent
+	patchedTodo, err = storage.Patch(
+		createdTodo.ID,
+		models.TodoRecordPatch{Priority: &priority},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, models.PriorityUrgent, patchedTodo.Priority)
+
+	updatedTodo, err := storage.Update(
+		createdTodo.ID,
+		models.TodoRecord{
+			Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			Title: "test",
+		},
+		0,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, models.PriorityNone, updatedTodo.Priority)
+
+	gotTodo, err := storage.GetSingle(createdTodo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PriorityNone, gotTodo.Priority)
+}
+
+func testTodoRecordWithMissingRecord(t *testing.T, newStorages NewStorages) {
+	tests := []struct {
+		name   string
+		action func(storage usecases.TodoRecordStorage, todoID int) error
+	}{
+		{
+			name: "getting",
+			action: func(storage usecases.TodoRecordStorage, todoID int) error {
+				_, err := storage.GetSingle(todoID)
+				return err
+			},
+		},
+		{
+			name: "updating",
+			action: func(storage usecases.TodoRecordStorage, todoID int) error {
+				todo := models.TodoRecord{
+					Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					Title:     "test",
+					Completed: true,
+					Order:     23,
+				}
+				_, err := storage.Update(todoID, todo, 0)
+				return err
+			},
+		},
+		{
+			name: "patching",
+			action: func(storage usecases.TodoRecordStorage, todoID int) error {
+				title := "test2"
+				_, err := storage.Patch(todoID, models.TodoRecordPatch{Title: &title}, 0)
+				return err
+			},
+		},
+		{
+			name: "deleting",
+			action: func(storage usecases.TodoRecordStorage, todoID int) error {
+				return storage.DeleteSingle(todoID, 0)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newStorages(t).TodoRecord
+
+			createdTodo, err := storage.Create(models.TodoRecord{
+				Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:     "test",
+				Completed: true,
+				Order:     23,
+			})
+			require.NoError(t, err)
+
+			err = storage.DeleteSingle(createdTodo.ID, 0)
+			require.NoError(t, err)
+
+			err = tt.action(storage, createdTodo.ID)
+
+			assert.ErrorIs(t, err, models.ErrNotFound)
+		})
+	}
+}
+
+func testTodoRecordWithVersionMismatch(t *testing.T, newStorages NewStorages) {
+	tests := []struct {
+		name   string
+		action func(storage usecases.TodoRecordStorage, todoID int) error
+	}{
+		{
+			name: "updating",
+			action: func(storage usecases.TodoRecordStorage, todoID int) error {
+				todo := models.TodoRecord{
+					Date:      time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+					Title:     "test2",
+					Completed: false,
+					Order:     42,
+				}
+				_, err := storage.Update(todoID, todo, 2)
+				return err
+			},
+		},
+		{
+			name: "patching",
+			action: func(storage usecases.TodoRecordStorage, todoID int) error {
+				title := "test2"
+				_, err := storage.Patch(todoID, models.TodoRecordPatch{Title: &title}, 2)
+				return err
+			},
+		},
+		{
+			name: "deleting",
+			action: func(storage usecases.TodoRecordStorage, todoID int) error {
+				return storage.DeleteSingle(todoID, 2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newStorages(t).TodoRecord
+
+			originalTodo := models.TodoRecord{
+				Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:     "test",
+				Completed: true,
+				Order:     23,
+			}
+			createdTodo, err := storage.Create(originalTodo)
+			require.NoError(t, err)
+
+			err = tt.action(storage, createdTodo.ID)
+			assert.ErrorIs(t, err, models.ErrVersionMismatch)
+
+			gotTodo, err := storage.GetSingle(createdTodo.ID)
+			require.NoError(t, err)
+			gotTodo.Date = gotTodo.Date.In(time.UTC)
+
+			resetTimestamps(&gotTodo)
+
+			originalTodo.ID = createdTodo.ID
+			originalTodo.Version = 1
+			assert.Equal(t, originalTodo, gotTodo)
+		})
+	}
+}
+
+// resetTimestamps clears the timestamps maintained by the storage;
+// they are checked by the testTodoRecordWithTimestamps() test.
+func resetTimestamps(todo *models.TodoRecord) {
+	todo.CreatedAt = time.Time{}
+	todo.UpdatedAt = time.Time{}
+	todo.CompletedAt = nil
+}
+
+func makeTags(number int) []string {
+	tags := []string{"odd"}
+	if number%2 == 0 {
+		tags[0] = "even"
+	}
+	if number%3 == 0 {
+		tags = append(tags, "triple")
+	}
+
+	return tags
+}
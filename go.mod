@@ -1,9 +1,11 @@
 module github.com/irenicaa/go-todo-backend/v2
 
-go 1.15
+go 1.16
 
 require (
 	github.com/irenicaa/go-http-utils v1.0.0
 	github.com/lib/pq v1.10.0
+	github.com/russross/blackfriday v1.6.0
 	github.com/stretchr/testify v1.7.1
+	modernc.org/sqlite v1.14.8
 )
@@ -0,0 +1,2 @@
+ALTER TABLE todo_records
+DROP COLUMN "date";
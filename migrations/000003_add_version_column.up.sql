@@ -0,0 +1,2 @@
+ALTER TABLE todo_records
+ADD COLUMN version integer NOT NULL DEFAULT 1;
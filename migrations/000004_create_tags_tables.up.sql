@@ -0,0 +1,13 @@
+CREATE TABLE tags (
+	id SERIAL PRIMARY KEY,
+	name text NOT NULL UNIQUE
+);
+
+CREATE TABLE todo_record_tags (
+	todo_record_id integer NOT NULL
+		REFERENCES todo_records (id) ON DELETE CASCADE,
+	tag_id integer NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+	PRIMARY KEY (todo_record_id, tag_id)
+);
+
+CREATE INDEX todo_record_tags_tag_id_index ON todo_record_tags (tag_id);
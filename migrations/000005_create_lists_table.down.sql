@@ -0,0 +1,4 @@
+ALTER TABLE todo_records
+DROP COLUMN list_id;
+
+DROP TABLE lists;
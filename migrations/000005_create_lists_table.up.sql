@@ -0,0 +1,9 @@
+CREATE TABLE lists (
+	id SERIAL PRIMARY KEY,
+	title text NOT NULL
+);
+
+ALTER TABLE todo_records
+ADD COLUMN list_id integer REFERENCES lists (id) ON DELETE SET NULL;
+
+CREATE INDEX todo_records_list_id_index ON todo_records (list_id);
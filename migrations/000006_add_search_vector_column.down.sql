@@ -0,0 +1,3 @@
+DROP INDEX todo_records_search_vector_index;
+
+ALTER TABLE todo_records DROP COLUMN search_vector;
@@ -0,0 +1,5 @@
+ALTER TABLE todo_records ADD COLUMN search_vector tsvector
+	GENERATED ALWAYS AS (to_tsvector('english', title)) STORED;
+
+CREATE INDEX todo_records_search_vector_index
+	ON todo_records USING GIN (search_vector);
@@ -0,0 +1,3 @@
+ALTER TABLE todo_records ADD COLUMN deleted_at timestamptz;
+
+CREATE INDEX todo_records_deleted_at_index ON todo_records (deleted_at);
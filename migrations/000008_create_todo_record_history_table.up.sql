@@ -0,0 +1,14 @@
+CREATE TABLE todo_record_history (
+	revision SERIAL PRIMARY KEY,
+	todo_record_id integer NOT NULL,
+	action text NOT NULL,
+	old_value jsonb,
+	new_value jsonb,
+	actor text NOT NULL,
+	changed_at timestamptz NOT NULL
+);
+
+CREATE INDEX todo_record_history_todo_record_id_index
+ON todo_record_history (todo_record_id);
+CREATE INDEX todo_record_history_changed_at_index
+ON todo_record_history (changed_at);
@@ -0,0 +1,8 @@
+DROP INDEX todo_records_completed_at_index;
+DROP INDEX todo_records_updated_at_index;
+DROP INDEX todo_records_created_at_index;
+
+ALTER TABLE todo_records
+DROP COLUMN completed_at,
+DROP COLUMN updated_at,
+DROP COLUMN created_at;
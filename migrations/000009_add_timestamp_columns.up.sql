@@ -0,0 +1,10 @@
+ALTER TABLE todo_records
+ADD COLUMN created_at timestamptz NOT NULL DEFAULT now(),
+ADD COLUMN updated_at timestamptz NOT NULL DEFAULT now(),
+ADD COLUMN completed_at timestamptz;
+
+UPDATE todo_records SET completed_at = now() WHERE completed;
+
+CREATE INDEX todo_records_created_at_index ON todo_records (created_at);
+CREATE INDEX todo_records_updated_at_index ON todo_records (updated_at);
+CREATE INDEX todo_records_completed_at_index ON todo_records (completed_at);
@@ -0,0 +1,3 @@
+DROP INDEX todo_records_due_at_index;
+
+ALTER TABLE todo_records DROP COLUMN due_at;
@@ -0,0 +1,3 @@
+ALTER TABLE todo_records ADD COLUMN due_at timestamptz;
+
+CREATE INDEX todo_records_due_at_index ON todo_records (due_at);
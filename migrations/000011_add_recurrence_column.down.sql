@@ -0,0 +1 @@
+ALTER TABLE todo_records DROP COLUMN recurrence;
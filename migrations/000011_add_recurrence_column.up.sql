@@ -0,0 +1 @@
+ALTER TABLE todo_records ADD COLUMN recurrence text NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+DROP INDEX todo_records_notes_search_vector_index;
+
+ALTER TABLE todo_records DROP COLUMN notes_search_vector;
+
+ALTER TABLE todo_records DROP COLUMN notes;
@@ -0,0 +1,7 @@
+ALTER TABLE todo_records ADD COLUMN notes text NOT NULL DEFAULT '';
+
+ALTER TABLE todo_records ADD COLUMN notes_search_vector tsvector
+	GENERATED ALWAYS AS (to_tsvector('english', title || ' ' || notes)) STORED;
+
+CREATE INDEX todo_records_notes_search_vector_index
+	ON todo_records USING GIN (notes_search_vector);
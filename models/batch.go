@@ -0,0 +1,58 @@
+package models
+
+// ...
+const (
+	BatchModeAllOrNothing = "all_or_nothing"
+	BatchModeBestEffort   = "best_effort"
+)
+
+// ...
+const (
+	BatchActionCreate = "create"
+	BatchActionUpdate = "update"
+	BatchActionPatch  = "patch"
+	BatchActionDelete = "delete"
+)
+
+// Batch ...
+//
+// The empty mode means the all-or-nothing one.
+type Batch struct {
+	Mode       string           `json:"mode"`
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchOperation ...
+//
+// The zero version disables the check, like the missed If-Match header.
+// The cascade and force flags are the same as the parameters
+// of the to-do record endpoints.
+type BatchOperation struct {
+	Action  string                  `json:"action"`
+	ID      int                     `json:"id,omitempty"`
+	Version int                     `json:"version,omitempty"`
+	Cascade bool                    `json:"cascade,omitempty"`
+	Force   bool                    `json:"force,omitempty"`
+	Todo    *PresentationTodoRecord `json:"todo,omitempty"`
+	Patch   *TodoRecordPatch        `json:"patch,omitempty"`
+}
+
+// BatchResult ...
+type BatchResult struct {
+	// Todo is nil for the deletion and the failed operations.
+	Todo *PresentationTodoRecord
+	Err  error
+}
+
+// PresentationBatchResults ...
+type PresentationBatchResults struct {
+	Committed bool                      `json:"committed"`
+	Results   []PresentationBatchResult `json:"results"`
+}
+
+// PresentationBatchResult ...
+type PresentationBatchResult struct {
+	Status  int                     `json:"status"`
+	Todo    *PresentationTodoRecord `json:"todo,omitempty"`
+	Problem *Problem                `json:"problem,omitempty"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+)
+
+// Cursor ...
+type Cursor struct {
+	Date  utilmodels.Date `json:"date"`
+	Order int             `json:"order"`
+	ID    int             `json:"id"`
+	// Priority is omitted for the zero value, so the cursors
+	// of the sort without the priority keep their format.
+	Priority int `json:"priority,omitempty"`
+}
+
+// NewCursor sets only the keys used by the sort
+// completed with the DefaultSort variable.
+func NewCursor(todo TodoRecord, sort []SortField) Cursor {
+	var cursor Cursor
+	for _, field := range CompleteSort(sort, DefaultSort) {
+		switch field.Name {
+		case SortByDate:
+			cursor.Date = utilmodels.Date(todo.Date)
+		case SortByOrder:
+			cursor.Order = todo.Order
+		case SortByID:
+			cursor.ID = todo.ID
+		case SortByPriority:
+			cursor.Priority = todo.Priority
+		}
+	}
+
+	return cursor
+}
+
+// ParseCursor ...
+func ParseCursor(text string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(text)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("unable to decode the cursor: %w", err)
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return Cursor{}, fmt.Errorf("unable to unmarshal the cursor: %w", err)
+	}
+	if cursor.ID <= 0 {
+		return Cursor{}, errors.New("the cursor ID is not positive")
+	}
+
+	return cursor, nil
+}
+
+// String ...
+func (cursor Cursor) String() string {
+	// the error is impossible, because the cursor contains only simple types
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCursor(t *testing.T) {
+	type args struct {
+		sort []SortField
+	}
+
+	date := time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)
+	todo := TodoRecord{
+		ID:        23,
+		Date:      date,
+		Title:     "test",
+		Completed: true,
+		Order:     42,
+		Priority:  PriorityHigh,
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want Cursor
+	}{
+		{
+			name: "with the default sort",
+			args: args{sort: nil},
+			want: Cursor{
+				Date:  utilmodels.Date(date),
+				Order: 42,
+				ID:    23,
+			},
+		},
+		{
+			name: "with the priority",
+			args: args{sort: []SortField{{Name: SortByPriority, Descending: true}}},
+			want: Cursor{
+				Date:     utilmodels.Date(date),
+				Order:    42,
+				ID:       23,
+				Priority: PriorityHigh,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCursor(todo, tt.args.sort)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	type args struct {
+		text string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    Cursor
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				text: Cursor{
+					Date: utilmodels.Date(
+						time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					),
+					Order: 42,
+					ID:    23,
+				}.String(),
+			},
+			want: Cursor{
+				Date: utilmodels.Date(
+					time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				),
+				Order: 42,
+				ID:    23,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the priority",
+			args: args{
+				text: Cursor{
+					Date: utilmodels.Date(
+						time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					),
+					Order:    42,
+					ID:       23,
+					Priority: PriorityUrgent,
+				}.String(),
+			},
+			want: Cursor{
+				Date: utilmodels.Date(
+					time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				),
+				Order:    42,
+				ID:       23,
+				Priority: PriorityUrgent,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with decoding",
+			args: args{text: "!!!"},
+			want: Cursor{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "unable to decode the cursor: illegal base64 data at input byte 0", msgAndArgs...)
+			},
+		},
+		{
+			name: "error with unmarshalling",
+			// "{}}" in the base64 encoding
+			args: args{text: "e319"},
+			want: Cursor{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "unable to unmarshal the cursor: invalid character '}' after top-level value", msgAndArgs...)
+			},
+		},
+		{
+			name: "error with the ID",
+			// "{}" in the base64 encoding
+			args: args{text: "e30"},
+			want: Cursor{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "the cursor ID is not positive", msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCursor(tt.args.text)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
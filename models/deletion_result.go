@@ -0,0 +1,6 @@
+package models
+
+// DeletionResult ...
+type DeletionResult struct {
+	DeletedCount int `json:"deleted_count"`
+}
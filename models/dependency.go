@@ -0,0 +1,7 @@
+package models
+
+// Dependency means the to-do record is blocked by the blocker one,
+// i.e. it shouldn't be completed while the blocker is open.
+type Dependency struct {
+	BlockerID int `json:"blocker_id"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "errors"
+
+// ...
+var (
+	ErrNotFound = errors.New("not found")
+	ErrConflict = errors.New("conflict")
+	ErrInvalid  = errors.New("invalid")
+	ErrBlocked  = errors.New("blocked")
+
+	ErrVersionMismatch = errors.New("version mismatch")
+	ErrRolledBack      = errors.New("rolled back")
+)
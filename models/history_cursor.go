@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// HistoryCursor ...
+//
+// The history records are ordered by the revision,
+// so it's the only key of the cursor.
+type HistoryCursor struct {
+	Revision int `json:"revision"`
+}
+
+// ParseHistoryCursor ...
+func ParseHistoryCursor(text string) (HistoryCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(text)
+	if err != nil {
+		return HistoryCursor{}, fmt.Errorf("unable to decode the cursor: %w", err)
+	}
+
+	var cursor HistoryCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return HistoryCursor{},
+			fmt.Errorf("unable to unmarshal the cursor: %w", err)
+	}
+	if cursor.Revision <= 0 {
+		return HistoryCursor{}, errors.New("the cursor revision is not positive")
+	}
+
+	return cursor, nil
+}
+
+// String ...
+func (cursor HistoryCursor) String() string {
+	// the error is impossible, because the cursor contains only simple types
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
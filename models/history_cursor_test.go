@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHistoryCursor(t *testing.T) {
+	type args struct {
+		text string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    HistoryCursor
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			args:    args{text: HistoryCursor{Revision: 23}.String()},
+			want:    HistoryCursor{Revision: 23},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with decoding",
+			args: args{text: "!!!"},
+			want: HistoryCursor{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "unable to decode the cursor: illegal base64 data at input byte 0", msgAndArgs...)
+			},
+		},
+		{
+			name: "error with unmarshalling",
+			// "{}}" in the base64 encoding
+			args: args{text: "e319"},
+			want: HistoryCursor{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "unable to unmarshal the cursor: invalid character '}' after top-level value", msgAndArgs...)
+			},
+		},
+		{
+			name: "error with the revision",
+			// "{}" in the base64 encoding
+			args: args{text: "e30"},
+			want: HistoryCursor{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "the cursor revision is not positive", msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHistoryCursor(tt.args.text)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// ...
+const (
+	HistoryActionCreate  = "create"
+	HistoryActionUpdate  = "update"
+	HistoryActionPatch   = "patch"
+	HistoryActionDelete  = "delete"
+	HistoryActionRestore = "restore"
+	HistoryActionRevert  = "revert"
+)
+
+// HistoryRecord ...
+//
+// The old value is nil for the creation and the restoring, and the new value
+// is nil for the deletion.
+type HistoryRecord struct {
+	Revision     int
+	TodoRecordID int
+	Action       string
+	OldValue     *TodoRecord
+	NewValue     *TodoRecord
+	Actor        string
+	ChangedAt    time.Time
+	// NewValuePatch contains only the fields present in the stored new value,
+	// so the ones introduced after its storing are missed; it's nil along
+	// with the new value.
+	NewValuePatch *TodoRecordPatch
+}
+
+// HistoryQuery ...
+//
+// The zero fields don't filter the history records. The records are returned
+// after the Cursor position (or from the start if it's empty) and limited
+// by the PageSize field, if it's set.
+type HistoryQuery struct {
+	TodoRecordID int
+	Since        time.Time
+	PageSize     int
+	Cursor       HistoryCursor
+}
@@ -0,0 +1,162 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+)
+
+// HistoryValue is the stored form of the to-do record in the history,
+// so the stored history records don't depend on the TodoRecord structure.
+//
+// The computed fields of the to-do record aren't stored except BlockerIDs,
+// because they depend on the other to-do records. The zero values are stored
+// too, so the missed fields can be distinguished from them,
+// see the ParseHistoryValue() function.
+type HistoryValue struct {
+	ID          int        `json:"id"`
+	Date        time.Time  `json:"date"`
+	Title       string     `json:"title"`
+	Notes       string     `json:"notes"`
+	Completed   bool       `json:"completed"`
+	Order       int        `json:"order"`
+	Priority    int        `json:"priority"`
+	Tags        []string   `json:"tags"`
+	ListID      int        `json:"list_id"`
+	Version     int        `json:"version"`
+	ParentID    int        `json:"parent_id"`
+	BlockerIDs  []int      `json:"blocker_ids"`
+	Recurrence  string     `json:"recurrence"`
+	DueAt       *time.Time `json:"due_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+}
+
+// NewHistoryValue ...
+func NewHistoryValue(todo TodoRecord) HistoryValue {
+	return HistoryValue{
+		ID:          todo.ID,
+		Date:        todo.Date,
+		Title:       todo.Title,
+		Notes:       todo.Notes,
+		Completed:   todo.Completed,
+		Order:       todo.Order,
+		Priority:    todo.Priority,
+		Tags:        todo.Tags,
+		ListID:      todo.ListID,
+		Version:     todo.Version,
+		ParentID:    todo.ParentID,
+		BlockerIDs:  todo.BlockerIDs,
+		Recurrence:  todo.Recurrence,
+		DueAt:       todo.DueAt,
+		CreatedAt:   todo.CreatedAt,
+		UpdatedAt:   todo.UpdatedAt,
+		CompletedAt: todo.CompletedAt,
+		DeletedAt:   todo.DeletedAt,
+	}
+}
+
+// TodoRecord ...
+func (value HistoryValue) TodoRecord() TodoRecord {
+	return TodoRecord{
+		ID:          value.ID,
+		Date:        value.Date,
+		Title:       value.Title,
+		Notes:       value.Notes,
+		Completed:   value.Completed,
+		Order:       value.Order,
+		Priority:    value.Priority,
+		Tags:        value.Tags,
+		ListID:      value.ListID,
+		Version:     value.Version,
+		ParentID:    value.ParentID,
+		BlockerIDs:  value.BlockerIDs,
+		Recurrence:  value.Recurrence,
+		DueAt:       value.DueAt,
+		CreatedAt:   value.CreatedAt,
+		UpdatedAt:   value.UpdatedAt,
+		CompletedAt: value.CompletedAt,
+		DeletedAt:   value.DeletedAt,
+	}
+}
+
+// ParseHistoryValue returns the to-do record and the patch containing
+// only the fields present in the stored value.
+func ParseHistoryValue(data []byte) (TodoRecord, TodoRecordPatch, error) {
+	var value HistoryValue
+	if err := json.Unmarshal(data, &value); err != nil {
+		return TodoRecord{}, TodoRecordPatch{},
+			fmt.Errorf("unable to unmarshal the history value: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return TodoRecord{}, TodoRecordPatch{},
+			fmt.Errorf("unable to unmarshal the history value fields: %w", err)
+	}
+
+	todo := value.TodoRecord()
+	patch := NewHistoryPatch(todo)
+	isPresent := func(key string) bool {
+		_, ok := fields[key]
+		return ok
+	}
+	if !isPresent("date") {
+		patch.Date = nil
+	}
+	if !isPresent("due_at") {
+		patch.DueAt = NullableTimestamp{}
+	}
+	if !isPresent("title") {
+		patch.Title = nil
+	}
+	if !isPresent("notes") {
+		patch.Notes = nil
+	}
+	if !isPresent("completed") {
+		patch.Completed = nil
+	}
+	if !isPresent("order") {
+		patch.Order = nil
+	}
+	if !isPresent("priority") {
+		patch.Priority = nil
+	}
+	if !isPresent("tags") {
+		patch.Tags = nil
+	}
+	if !isPresent("list_id") {
+		patch.ListID = nil
+	}
+	if !isPresent("parent_id") {
+		patch.ParentID = nil
+	}
+	if !isPresent("recurrence") {
+		patch.Recurrence = nil
+	}
+
+	return todo, patch, nil
+}
+
+// NewHistoryPatch sets all the fields of the patch
+// from the to-do record, see the HistoryRecord.NewValuePatch field.
+func NewHistoryPatch(todo TodoRecord) TodoRecordPatch {
+	date := utilmodels.Date(todo.Date)
+	return TodoRecordPatch{
+		Date:       &date,
+		DueAt:      NullableTimestamp{IsSet: true, Value: todo.DueAt},
+		Title:      &todo.Title,
+		Notes:      &todo.Notes,
+		Completed:  &todo.Completed,
+		Order:      &todo.Order,
+		Priority:   &todo.Priority,
+		Tags:       &todo.Tags,
+		ListID:     &todo.ListID,
+		ParentID:   &todo.ParentID,
+		Recurrence: &todo.Recurrence,
+	}
+}
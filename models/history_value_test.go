@@ -0,0 +1,106 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryValue(t *testing.T) {
+	dueAt := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	todo := TodoRecord{
+		ID:                  23,
+		Date:                time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title:               "test",
+		Completed:           true,
+		Order:               42,
+		Tags:                []string{"one"},
+		ListID:              12,
+		Version:             5,
+		ParentID:            7,
+		ChildCount:          2,
+		CompletedChildCount: 1,
+		BlockerIDs:          []int{8},
+		Blocked:             true,
+		DueAt:               &dueAt,
+		CreatedAt:           time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:           time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Highlight:           "<mark>test</mark>",
+	}
+
+	data, err := json.Marshal(NewHistoryValue(todo))
+	require.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{"id":23,`+
+			`"date":"2006-01-02T00:00:00Z",`+
+			`"title":"test",`+
+			`"notes":"",`+
+			`"completed":true,`+
+			`"order":42,`+
+			`"priority":0,`+
+			`"tags":["one"],`+
+			`"list_id":12,`+
+			`"version":5,`+
+			`"parent_id":7,`+
+			`"blocker_ids":[8],`+
+			`"recurrence":"",`+
+			`"due_at":"2006-01-02T15:04:05Z",`+
+			`"created_at":"2006-01-01T00:00:00Z",`+
+			`"updated_at":"2006-01-01T00:00:00Z",`+
+			`"completed_at":null,`+
+			`"deleted_at":null}`,
+		string(data),
+	)
+
+	var value HistoryValue
+	err = json.Unmarshal(data, &value)
+	require.NoError(t, err)
+
+	wantTodo := todo
+	wantTodo.ChildCount = 0
+	wantTodo.CompletedChildCount = 0
+	wantTodo.Blocked = false
+	wantTodo.Highlight = ""
+	assert.Equal(t, wantTodo, value.TodoRecord())
+}
+
+func TestParseHistoryValue(t *testing.T) {
+	dueAt := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	todo := TodoRecord{
+		ID:    23,
+		Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title: "test",
+		DueAt: &dueAt,
+	}
+	data, err := json.Marshal(NewHistoryValue(todo))
+	require.NoError(t, err)
+
+	gotTodo, gotPatch, err := ParseHistoryValue(data)
+	require.NoError(t, err)
+	assert.Equal(t, todo, gotTodo)
+	assert.Equal(t, NewHistoryPatch(todo), gotPatch)
+
+	gotTodo, gotPatch, err = ParseHistoryValue([]byte(
+		`{"id":23,"date":"2006-01-02T00:00:00Z","title":"test","list_id":0}`,
+	))
+	require.NoError(t, err)
+
+	wantTodo := todo
+	wantTodo.DueAt = nil
+	assert.Equal(t, wantTodo, gotTodo)
+
+	date := utilmodels.Date(todo.Date)
+	title := "test"
+	listID := 0
+	wantPatch := TodoRecordPatch{Date: &date, Title: &title, ListID: &listID}
+	assert.Equal(t, wantPatch, gotPatch)
+
+	_, _, err = ParseHistoryValue([]byte("incorrect"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,12 @@
+package models
+
+// List ...
+type List struct {
+	ID    int
+	Title string
+}
+
+// NewList ...
+func NewList(presentationList PresentationList) List {
+	return List{Title: presentationList.Title}
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewList(t *testing.T) {
+	type args struct {
+		presentationList PresentationList
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want List
+	}{
+		{
+			name: "success",
+			args: args{
+				presentationList: PresentationList{
+					URL:      "https://example.com/api/v1/lists/23",
+					Title:    "test",
+					TodosURL: "https://example.com/api/v1/lists/23/todos",
+				},
+			},
+			want: List{Title: "test"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewList(tt.args.presentationList)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
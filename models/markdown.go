@@ -0,0 +1,29 @@
+package models
+
+import "github.com/russross/blackfriday"
+
+const (
+	markdownExtensions = blackfriday.EXTENSION_NO_INTRA_EMPHASIS |
+		blackfriday.EXTENSION_TABLES |
+		blackfriday.EXTENSION_FENCED_CODE |
+		blackfriday.EXTENSION_AUTOLINK |
+		blackfriday.EXTENSION_STRIKETHROUGH |
+		blackfriday.EXTENSION_SPACE_HEADERS
+	// markdownHTMLFlags sanitize the HTML, see the RenderMarkdown() function
+	markdownHTMLFlags = blackfriday.HTML_SKIP_HTML |
+		blackfriday.HTML_SKIP_STYLE |
+		blackfriday.HTML_SKIP_IMAGES |
+		blackfriday.HTML_SAFELINK |
+		blackfriday.HTML_NOFOLLOW_LINKS |
+		blackfriday.HTML_NOREFERRER_LINKS |
+		blackfriday.HTML_NOOPENER_LINKS
+)
+
+// RenderMarkdown returns the sanitized HTML: the raw HTML and images
+// of the text are skipped, and only the links with the trusted protocols
+// (e.g. http, https or mailto) are kept.
+func RenderMarkdown(text string) string {
+	renderer := blackfriday.HtmlRenderer(markdownHTMLFlags, "", "")
+	html := blackfriday.Markdown([]byte(text), renderer, markdownExtensions)
+	return string(html)
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	type args struct {
+		text string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "with the formatting",
+			args: args{text: "# Title\n\n**bold** `code`\n\n- one\n- two"},
+			want: "<h1>Title</h1>\n\n" +
+				"<p><strong>bold</strong> <code>code</code></p>\n\n" +
+				"<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n",
+		},
+		{
+			name: "with the safe link",
+			args: args{text: "[test](https://example.com)"},
+			want: `<p><a href="https://example.com" rel="nofollow noreferrer noopener">` +
+				"test</a></p>\n",
+		},
+		{
+			name: "with the unsafe link",
+			args: args{text: "[test](javascript:alert(1))"},
+			want: "<p><tt>test</tt></p>\n",
+		},
+		{
+			name: "with the raw HTML",
+			args: args{text: "one <img src=x onerror=alert(1)> two\n\n<script>alert(1)</script>"},
+			want: "<p>one  two</p>\n",
+		},
+		{
+			name: "with the HTML in the code",
+			args: args{text: "```\n<script>\n```"},
+			want: "<pre><code>&lt;script&gt;\n</code></pre>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderMarkdown(tt.args.text)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// PresentationHistoryRecord ...
+type PresentationHistoryRecord struct {
+	Revision  int                     `json:"revision"`
+	TodoURL   string                  `json:"todo_url"`
+	Action    string                  `json:"action"`
+	OldValue  *PresentationTodoRecord `json:"old_value,omitempty"`
+	NewValue  *PresentationTodoRecord `json:"new_value,omitempty"`
+	Actor     string                  `json:"actor,omitempty"`
+	ChangedAt time.Time               `json:"changed_at"`
+}
+
+// NewPresentationHistoryRecord ...
+func NewPresentationHistoryRecord(
+	baseURL *url.URL,
+	record HistoryRecord,
+) PresentationHistoryRecord {
+	todoURL := fmt.Sprintf(
+		"%s://%s/api/v1/todos/%d",
+		baseURL.Scheme,
+		baseURL.Host,
+		record.TodoRecordID,
+	)
+
+	return PresentationHistoryRecord{
+		Revision:  record.Revision,
+		TodoURL:   todoURL,
+		Action:    record.Action,
+		OldValue:  newOptionalPresentationTodoRecord(baseURL, record.OldValue),
+		NewValue:  newOptionalPresentationTodoRecord(baseURL, record.NewValue),
+		Actor:     record.Actor,
+		ChangedAt: record.ChangedAt,
+	}
+}
+
+func newOptionalPresentationTodoRecord(
+	baseURL *url.URL,
+	todo *TodoRecord,
+) *PresentationTodoRecord {
+	if todo == nil {
+		return nil
+	}
+
+	presentationTodo := NewPresentationTodoRecord(baseURL, *todo)
+	return &presentationTodo
+}
@@ -0,0 +1,8 @@
+package models
+
+// PresentationHistoryRecordPage is returned with the page size instead of
+// the array of the history records; NextCursor is missed on the last page.
+type PresentationHistoryRecordPage struct {
+	History    []PresentationHistoryRecord `json:"history"`
+	NextCursor string                      `json:"next_cursor,omitempty"`
+}
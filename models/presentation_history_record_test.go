@@ -0,0 +1,107 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPresentationHistoryRecord(t *testing.T) {
+	type args struct {
+		baseURL *url.URL
+		record  HistoryRecord
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want PresentationHistoryRecord
+	}{
+		{
+			name: "success with both values",
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				record: HistoryRecord{
+					Revision:     12,
+					TodoRecordID: 23,
+					Action:       HistoryActionUpdate,
+					OldValue: &TodoRecord{
+						ID:      23,
+						Date:    time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title:   "test",
+						Version: 1,
+					},
+					NewValue: &TodoRecord{
+						ID:      23,
+						Date:    time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title:   "test2",
+						Version: 2,
+					},
+					Actor:     "admin",
+					ChangedAt: time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC),
+				},
+			},
+			want: PresentationHistoryRecord{
+				Revision: 12,
+				TodoURL:  "https://example.com/api/v1/todos/23",
+				Action:   HistoryActionUpdate,
+				OldValue: &PresentationTodoRecord{
+					URL:     "https://example.com/api/v1/todos/23",
+					Date:    utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					Title:   "test",
+					Tags:    []string{},
+					Version: 1,
+				},
+				NewValue: &PresentationTodoRecord{
+					URL:     "https://example.com/api/v1/todos/23",
+					Date:    utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					Title:   "test2",
+					Tags:    []string{},
+					Version: 2,
+				},
+				Actor:     "admin",
+				ChangedAt: time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC),
+			},
+		},
+		{
+			name: "success without the new value",
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				record: HistoryRecord{
+					Revision:     12,
+					TodoRecordID: 23,
+					Action:       HistoryActionDelete,
+					OldValue: &TodoRecord{
+						ID:      23,
+						Date:    time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title:   "test",
+						Version: 1,
+					},
+					ChangedAt: time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC),
+				},
+			},
+			want: PresentationHistoryRecord{
+				Revision: 12,
+				TodoURL:  "https://example.com/api/v1/todos/23",
+				Action:   HistoryActionDelete,
+				OldValue: &PresentationTodoRecord{
+					URL:     "https://example.com/api/v1/todos/23",
+					Date:    utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					Title:   "test",
+					Tags:    []string{},
+					Version: 1,
+				},
+				ChangedAt: time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPresentationHistoryRecord(tt.args.baseURL, tt.args.record)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// PresentationList ...
+type PresentationList struct {
+	URL      string `json:"url"`
+	Title    string `json:"title"`
+	TodosURL string `json:"todos_url"`
+}
+
+// NewPresentationList ...
+func NewPresentationList(baseURL *url.URL, list List) PresentationList {
+	url :=
+		fmt.Sprintf("%s://%s/api/v1/lists/%d", baseURL.Scheme, baseURL.Host, list.ID)
+	return PresentationList{URL: url, Title: list.Title, TodosURL: url + "/todos"}
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPresentationList(t *testing.T) {
+	type args struct {
+		baseURL *url.URL
+		list    List
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want PresentationList
+	}{
+		{
+			name: "success",
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				list:    List{ID: 23, Title: "test"},
+			},
+			want: PresentationList{
+				URL:      "https://example.com/api/v1/lists/23",
+				Title:    "test",
+				TodosURL: "https://example.com/api/v1/lists/23/todos",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPresentationList(tt.args.baseURL, tt.args.list)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
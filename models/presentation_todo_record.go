@@ -3,17 +3,44 @@ package models
 import (
 	"fmt"
 	"net/url"
+	"time"
 
 	utilmodels "github.com/irenicaa/go-http-utils/models"
 )
 
 // PresentationTodoRecord ...
 type PresentationTodoRecord struct {
-	URL       string          `json:"url"`
-	Date      utilmodels.Date `json:"date"`
-	Title     string          `json:"title"`
-	Completed bool            `json:"completed"`
-	Order     int             `json:"order"`
+	URL         string          `json:"url"`
+	Date        utilmodels.Date `json:"date"`
+	DueAt       *time.Time      `json:"due_at,omitempty"`
+	Title       string          `json:"title"`
+	Notes       string          `json:"notes,omitempty"`
+	Completed   bool            `json:"completed"`
+	Order       int             `json:"order"`
+	Priority    int             `json:"priority,omitempty"`
+	Tags        []string        `json:"tags"`
+	ListID      int             `json:"list_id,omitempty"`
+	ParentID    int             `json:"parent_id,omitempty"`
+	BlockedBy   []int           `json:"blocked_by,omitempty"`
+	Blocked     bool            `json:"blocked,omitempty"`
+	Recurrence  string          `json:"recurrence,omitempty"`
+	CreatedAt   *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time      `json:"updated_at,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`
+	Highlight   string          `json:"highlight,omitempty"`
+	Version     int             `json:"-"`
+	// ChildCount and ChildrenCompletion are set only for the parents;
+	// ChildrenCompletion is the completed part of the direct children.
+	ChildCount         int      `json:"child_count,omitempty"`
+	ChildrenCompletion *float64 `json:"children_completion,omitempty"`
+	// NotesHTML is set only on request, see the RenderNotes() method.
+	NotesHTML string `json:"notes_html,omitempty"`
+	// NotesHighlight is set only if the notes are searched and matched.
+	NotesHighlight string `json:"notes_highlight,omitempty"`
+	// Children are set only on request, see the TodoRecord.GetTree() method
+	// of the use cases.
+	Children []PresentationTodoRecord `json:"children,omitempty"`
 }
 
 // NewPresentationTodoRecord ...
@@ -23,11 +50,67 @@ func NewPresentationTodoRecord(
 ) PresentationTodoRecord {
 	url :=
 		fmt.Sprintf("%s://%s/api/v1/todos/%d", baseURL.Scheme, baseURL.Host, todo.ID)
+
+	// force the empty array instead of the nil one
+	tags := []string{}
+	tags = append(tags, todo.Tags...)
+
 	return PresentationTodoRecord{
-		URL:       url,
-		Date:      utilmodels.Date(todo.Date),
-		Title:     todo.Title,
-		Completed: todo.Completed,
-		Order:     todo.Order,
+		URL:         url,
+		Date:        utilmodels.Date(todo.Date),
+		DueAt:       todo.DueAt,
+		Title:       todo.Title,
+		Notes:       todo.Notes,
+		Completed:   todo.Completed,
+		Order:       todo.Order,
+		Priority:    todo.Priority,
+		Tags:        tags,
+		ListID:      todo.ListID,
+		ParentID:    todo.ParentID,
+		BlockedBy:   todo.BlockerIDs,
+		Blocked:     todo.Blocked,
+		Recurrence:  todo.Recurrence,
+		CreatedAt:   newOptionalTimestamp(todo.CreatedAt),
+		UpdatedAt:   newOptionalTimestamp(todo.UpdatedAt),
+		CompletedAt: todo.CompletedAt,
+		DeletedAt:   todo.DeletedAt,
+		Highlight:   todo.Highlight,
+		Version:     todo.Version,
+		ChildCount:  todo.ChildCount,
+		ChildrenCompletion: newChildrenCompletion(
+			todo.ChildCount,
+			todo.CompletedChildCount,
+		),
+		NotesHighlight: todo.NotesHighlight,
+	}
+}
+
+// RenderNotes sets the NotesHTML field of the to-do record
+// and its children, see the RenderMarkdown() function.
+func (presentationTodo *PresentationTodoRecord) RenderNotes() {
+	if presentationTodo.Notes != "" {
+		presentationTodo.NotesHTML = RenderMarkdown(presentationTodo.Notes)
+	}
+
+	for index := range presentationTodo.Children {
+		presentationTodo.Children[index].RenderNotes()
 	}
 }
+
+// newOptionalTimestamp omits the zero timestamp in the JSON.
+func newOptionalTimestamp(timestamp time.Time) *time.Time {
+	if timestamp.IsZero() {
+		return nil
+	}
+
+	return &timestamp
+}
+
+func newChildrenCompletion(childCount int, completedChildCount int) *float64 {
+	if childCount == 0 {
+		return nil
+	}
+
+	completion := float64(completedChildCount) / float64(childCount)
+	return &completion
+}
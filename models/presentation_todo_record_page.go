@@ -0,0 +1,8 @@
+package models
+
+// PresentationTodoRecordPage is returned in the cursor mode instead of
+// the array of the to-do records; NextCursor is missed on the last page.
+type PresentationTodoRecordPage struct {
+	Todos      []PresentationTodoRecord `json:"todos"`
+	NextCursor string                   `json:"next_cursor,omitempty"`
+}
@@ -30,6 +30,8 @@ func TestNewPresentationTodoRecord(t *testing.T) {
 					Title:     "test",
 					Completed: true,
 					Order:     42,
+					ListID:    12,
+					Version:   5,
 				},
 			},
 			want: PresentationTodoRecord{
@@ -38,6 +40,126 @@ func TestNewPresentationTodoRecord(t *testing.T) {
 				Title:     "test",
 				Completed: true,
 				Order:     42,
+				Tags:      []string{},
+				ListID:    12,
+				Version:   5,
+			},
+		},
+		{
+			name: "success with timestamps",
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				todo: TodoRecord{
+					ID:        23,
+					Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					Title:     "test",
+					Completed: true,
+					CreatedAt: time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC),
+					UpdatedAt: time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC),
+					CompletedAt: func() *time.Time {
+						completedAt := time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC)
+						return &completedAt
+					}(),
+					Version: 5,
+				},
+			},
+			want: PresentationTodoRecord{
+				URL:       "https://example.com/api/v1/todos/23",
+				Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				Title:     "test",
+				Completed: true,
+				Tags:      []string{},
+				CreatedAt: func() *time.Time {
+					createdAt := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+					return &createdAt
+				}(),
+				UpdatedAt: func() *time.Time {
+					updatedAt := time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC)
+					return &updatedAt
+				}(),
+				CompletedAt: func() *time.Time {
+					completedAt := time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC)
+					return &completedAt
+				}(),
+				Version: 5,
+			},
+		},
+		{
+			name: "success with children",
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				todo: TodoRecord{
+					ID:                  23,
+					Date:                time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					Title:               "test",
+					ParentID:            12,
+					Version:             5,
+					ChildCount:          4,
+					CompletedChildCount: 1,
+				},
+			},
+			want: PresentationTodoRecord{
+				URL:        "https://example.com/api/v1/todos/23",
+				Date:       utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				Title:      "test",
+				Tags:       []string{},
+				ParentID:   12,
+				Version:    5,
+				ChildCount: 4,
+				ChildrenCompletion: func() *float64 {
+					childrenCompletion := 0.25
+					return &childrenCompletion
+				}(),
+			},
+		},
+		{
+			name: "success with blockers",
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				todo: TodoRecord{
+					ID:         23,
+					Date:       time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					Title:      "test",
+					Version:    5,
+					BlockerIDs: []int{12, 42},
+					Blocked:    true,
+				},
+			},
+			want: PresentationTodoRecord{
+				URL:       "https://example.com/api/v1/todos/23",
+				Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				Title:     "test",
+				Tags:      []string{},
+				BlockedBy: []int{12, 42},
+				Blocked:   true,
+				Version:   5,
+			},
+		},
+		{
+			name: "success with a trashed to-do record",
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				todo: TodoRecord{
+					ID:    23,
+					Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					Title: "test",
+					DeletedAt: func() *time.Time {
+						deletedAt := time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC)
+						return &deletedAt
+					}(),
+					Version: 5,
+				},
+			},
+			want: PresentationTodoRecord{
+				URL:   "https://example.com/api/v1/todos/23",
+				Date:  utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				Title: "test",
+				Tags:  []string{},
+				DeletedAt: func() *time.Time {
+					deletedAt := time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC)
+					return &deletedAt
+				}(),
+				Version: 5,
 			},
 		},
 	}
@@ -49,3 +171,29 @@ func TestNewPresentationTodoRecord(t *testing.T) {
 		})
 	}
 }
+
+func TestPresentationTodoRecord_RenderNotes(t *testing.T) {
+	presentationTodo := PresentationTodoRecord{
+		Title: "test",
+		Notes: "*one*",
+		Children: []PresentationTodoRecord{
+			{Title: "test2"},
+			{Title: "test3", Notes: "**two**"},
+		},
+	}
+	presentationTodo.RenderNotes()
+
+	assert.Equal(t, PresentationTodoRecord{
+		Title:     "test",
+		Notes:     "*one*",
+		NotesHTML: "<p><em>one</em></p>\n",
+		Children: []PresentationTodoRecord{
+			{Title: "test2"},
+			{
+				Title:     "test3",
+				Notes:     "**two**",
+				NotesHTML: "<p><strong>two</strong></p>\n",
+			},
+		},
+	}, presentationTodo)
+}
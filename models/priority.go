@@ -0,0 +1,10 @@
+package models
+
+// Priorities of the to-do records; the larger, the more urgent.
+const (
+	PriorityNone = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
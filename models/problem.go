@@ -0,0 +1,13 @@
+package models
+
+// Problem ...
+type Problem struct {
+	Type          string       `json:"type"`
+	Title         string       `json:"title"`
+	Status        int          `json:"status"`
+	Detail        string       `json:"detail"`
+	Instance      string       `json:"instance"`
+	Code          string       `json:"code"`
+	Parameter     string       `json:"parameter,omitempty"`
+	InvalidParams []FieldError `json:"invalid_params,omitempty"`
+}
@@ -1,17 +1,114 @@
 package models
 
-import utilmodels "github.com/irenicaa/go-http-utils/models"
+import (
+	"time"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+)
 
 // Query ...
 type Query struct {
 	MinimalDate   utilmodels.Date
 	MaximalDate   utilmodels.Date
 	TitleFragment string
-	Pagination    Pagination
+	Search        string
+	Highlight     bool
+	Tags          []string
+	AllTags       []string
+	ListID        int
+	ParentID      int
+	Completed     *bool
+	Blocked       *bool
+	MinimalOrder  *int
+	MaximalOrder  *int
+	IDs           []int
+	// SearchNotes extends the title fragment and search filters to the notes.
+	SearchNotes bool
+	// MinimalPriority is inclusive.
+	MinimalPriority *int
+	// Date selects the to-do records of the date in the time zone
+	// (UTC if it's nil): by the due time if it's set, otherwise, by the date.
+	Date     utilmodels.Date
+	TimeZone *time.Location
+	// the After timestamps are inclusive, the Before ones are exclusive;
+	// the completion ones select only the completed to-do records
+	CreatedAfter    time.Time
+	CreatedBefore   time.Time
+	UpdatedAfter    time.Time
+	UpdatedBefore   time.Time
+	CompletedAfter  time.Time
+	CompletedBefore time.Time
+	// the due time ones select only the to-do records with it
+	DueAfter  time.Time
+	DueBefore time.Time
+	// Deleted selects the trashed to-do records instead of the active ones.
+	Deleted    bool
+	Sort       []SortField
+	Pagination Pagination
+}
+
+// HasFilters ignores the notes searching, highlighting, sorting
+// and pagination.
+func (query Query) HasFilters() bool {
+	return query.MinimalDate != (utilmodels.Date{}) ||
+		query.MaximalDate != (utilmodels.Date{}) ||
+		query.TitleFragment != "" ||
+		query.Search != "" ||
+		len(query.Tags) != 0 ||
+		len(query.AllTags) != 0 ||
+		query.ListID != 0 ||
+		query.ParentID != 0 ||
+		query.Completed != nil ||
+		query.Blocked != nil ||
+		query.MinimalOrder != nil ||
+		query.MaximalOrder != nil ||
+		query.MinimalPriority != nil ||
+		len(query.IDs) != 0 ||
+		!query.CreatedAfter.IsZero() ||
+		!query.CreatedBefore.IsZero() ||
+		!query.UpdatedAfter.IsZero() ||
+		!query.UpdatedBefore.IsZero() ||
+		!query.CompletedAfter.IsZero() ||
+		!query.CompletedBefore.IsZero() ||
+		query.Date != (utilmodels.Date{}) ||
+		!query.DueAfter.IsZero() ||
+		!query.DueBefore.IsZero()
+}
+
+// DateRange returns the half-open time range of the Date field
+// in the time zone.
+func (query Query) DateRange() (time.Time, time.Time) {
+	timeZone := query.TimeZone
+	if timeZone == nil {
+		timeZone = time.UTC
+	}
+
+	year, month, day := time.Time(query.Date).Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, timeZone)
+	return start, start.AddDate(0, 0, 1)
 }
 
 // Pagination ...
+//
+// The page mode is used if the Page field is set, otherwise the cursor mode
+// is used: records are returned after the Cursor position (or from the start
+// if it's empty) and limited by the PageSize field. The cursor mode supports
+// only the default sort, either the DefaultSort variable or the one
+// configured by the ParseDefaultSort() function; the configured one should
+// be set in the Query.Sort field.
 type Pagination struct {
 	PageSize int
 	Page     int
+	Cursor   Cursor
+}
+
+// IsCursorMode ...
+func (pagination Pagination) IsCursorMode() bool {
+	return pagination.PageSize != 0 && pagination.Page == 0
+}
+
+// PageInfo ...
+type PageInfo struct {
+	TotalCount int
+	NextCursor string
 }
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery_HasFilters(t *testing.T) {
+	completed, blocked := false, true
+	minimalPriority := PriorityHigh
+
+	tests := []struct {
+		name  string
+		query Query
+		want  bool
+	}{
+		{
+			name:  "without filters",
+			query: Query{},
+			want:  false,
+		},
+		{
+			name: "with the sorting and pagination only",
+			query: Query{
+				Highlight:  true,
+				Sort:       []SortField{{Name: SortByTitle}},
+				Pagination: Pagination{PageSize: 2, Page: 3},
+			},
+			want: false,
+		},
+		{
+			name:  "with the minimal priority",
+			query: Query{MinimalPriority: &minimalPriority},
+			want:  true,
+		},
+		{
+			name:  "with the notes searching only",
+			query: Query{SearchNotes: true},
+			want:  false,
+		},
+		{
+			name: "with the date",
+			query: Query{
+				MinimalDate: utilmodels.Date(
+					time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				),
+			},
+			want: true,
+		},
+		{
+			name:  "with the tags",
+			query: Query{Tags: []string{"one"}},
+			want:  true,
+		},
+		{
+			name:  "with the false completion status",
+			query: Query{Completed: &completed},
+			want:  true,
+		},
+		{
+			name:  "with the completion time",
+			query: Query{CompletedAfter: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)},
+			want:  true,
+		},
+		{
+			name: "with the date in the time zone",
+			query: Query{
+				Date: utilmodels.Date(
+					time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				),
+			},
+			want: true,
+		},
+		{
+			name:  "with the parent",
+			query: Query{ParentID: 23},
+			want:  true,
+		},
+		{
+			name:  "with the blocking status",
+			query: Query{Blocked: &blocked},
+			want:  true,
+		},
+		{
+			name:  "with the due time",
+			query: Query{DueBefore: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)},
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.query.HasFilters()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQuery_DateRange(t *testing.T) {
+	timeZone := time.FixedZone("UTC+9", 9*60*60)
+
+	tests := []struct {
+		name      string
+		query     Query
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name: "without the time zone",
+			query: Query{
+				Date: utilmodels.Date(
+					time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				),
+			},
+			wantStart: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "with the time zone",
+			query: Query{
+				Date: utilmodels.Date(
+					time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				),
+				TimeZone: timeZone,
+			},
+			wantStart: time.Date(2006, time.January, 2, 0, 0, 0, 0, timeZone),
+			wantEnd:   time.Date(2006, time.January, 3, 0, 0, 0, 0, timeZone),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStart, gotEnd := tt.query.DateRange()
+
+			assert.Equal(t, tt.wantStart, gotStart)
+			assert.Equal(t, tt.wantEnd, gotEnd)
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequencies of the recurrence rules.
+const (
+	FrequencyDaily   = "DAILY"
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+)
+
+// MaximalOccurrenceCount limits the expansion of a recurrence rule.
+const MaximalOccurrenceCount = 1000
+
+const (
+	recurrencePrefix        = "RRULE:"
+	recurrenceDateFormat    = "20060102"
+	recurrenceTimeFormat    = "20060102T150405Z"
+	maximalMonthSearchCount = 1000
+)
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Recurrence is a subset of the RFC 5545 recurrence rule,
+// e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=10".
+//
+// The supported parts are FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL,
+// BYDAY (only weekdays without numbers, only for WEEKLY), BYMONTHDAY
+// (only for MONTHLY, the negative days are counted from the month end),
+// and either UNTIL (a date, inclusive) or COUNT. The weeks start on Monday.
+// The months without the day are skipped.
+type Recurrence struct {
+	Frequency string
+	// Interval is 1 by default.
+	Interval  int
+	Weekdays  []time.Weekday
+	MonthDays []int
+	// Until is zero if it's missed.
+	Until time.Time
+	// Count is zero if it's missed; it includes the first occurrence.
+	Count int
+}
+
+// ParseRecurrence accepts the rule with or without the "RRULE:" prefix.
+func ParseRecurrence(text string) (Recurrence, error) {
+	recurrence := Recurrence{Interval: 1}
+	usedParts := map[string]struct{}{}
+	text = strings.TrimPrefix(text, recurrencePrefix)
+	for _, part := range strings.Split(text, ";") {
+		items := strings.SplitN(part, "=", 2)
+		if len(items) != 2 {
+			return Recurrence{}, fmt.Errorf("part %q without a value", part)
+		}
+
+		name, value := items[0], items[1]
+		if _, ok := usedParts[name]; ok {
+			return Recurrence{}, fmt.Errorf("duplicate part %q", name)
+		}
+		usedParts[name] = struct{}{}
+
+		var err error
+		switch name {
+		case "FREQ":
+			switch value {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+				recurrence.Frequency = value
+			default:
+				err = errors.New("unsupported value")
+			}
+		case "INTERVAL":
+			recurrence.Interval, err = parsePositiveInt(value)
+		case "BYDAY":
+			recurrence.Weekdays, err = parseRecurrenceWeekdays(value)
+		case "BYMONTHDAY":
+			recurrence.MonthDays, err = parseRecurrenceMonthDays(value)
+		case "UNTIL":
+			recurrence.Until, err = parseRecurrenceUntil(value)
+		case "COUNT":
+			recurrence.Count, err = parsePositiveInt(value)
+		default:
+			return Recurrence{}, fmt.Errorf("unsupported part %q", name)
+		}
+		if err != nil {
+			return Recurrence{}, fmt.Errorf("incorrect part %q: %v", name, err)
+		}
+	}
+
+	switch {
+	case recurrence.Frequency == "":
+		return Recurrence{}, errors.New("the FREQ part is required")
+	case len(recurrence.Weekdays) != 0 &&
+		recurrence.Frequency != FrequencyWeekly:
+		return Recurrence{},
+			errors.New("the BYDAY part is supported only for the weekly frequency")
+	case len(recurrence.MonthDays) != 0 &&
+		recurrence.Frequency != FrequencyMonthly:
+		return Recurrence{}, errors.New(
+			"the BYMONTHDAY part is supported only for the monthly frequency",
+		)
+	case !recurrence.Until.IsZero() && recurrence.Count != 0:
+		return Recurrence{},
+			errors.New("the UNTIL and COUNT parts are mutually exclusive")
+	}
+
+	return recurrence, nil
+}
+
+// String returns the rule without the "RRULE:" prefix.
+func (recurrence Recurrence) String() string {
+	parts := []string{"FREQ=" + recurrence.Frequency}
+	if recurrence.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(recurrence.Interval))
+	}
+	if len(recurrence.Weekdays) != 0 {
+		var weekdays []string
+		for _, weekday := range recurrence.Weekdays {
+			weekdays = append(weekdays, formatRecurrenceWeekday(weekday))
+		}
+
+		parts = append(parts, "BYDAY="+strings.Join(weekdays, ","))
+	}
+	if len(recurrence.MonthDays) != 0 {
+		var monthDays []string
+		for _, monthDay := range recurrence.MonthDays {
+			monthDays = append(monthDays, strconv.Itoa(monthDay))
+		}
+
+		parts = append(parts, "BYMONTHDAY="+strings.Join(monthDays, ","))
+	}
+	if !recurrence.Until.IsZero() {
+		parts = append(parts, "UNTIL="+recurrence.Until.Format(recurrenceDateFormat))
+	}
+	if recurrence.Count != 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(recurrence.Count))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the occurrence following the date and the rule for the rest
+// of the occurrences starting from it, i.e. with the decreased count;
+// false means the end of the recurrence.
+func (recurrence Recurrence) Next(date time.Time) (time.Time, Recurrence, bool) {
+	if recurrence.Count == 1 {
+		return time.Time{}, Recurrence{}, false
+	}
+
+	nextDate, ok := recurrence.nextDate(date)
+	if !ok {
+		return time.Time{}, Recurrence{}, false
+	}
+
+	rest := recurrence
+	if rest.Count != 0 {
+		rest.Count--
+	}
+
+	return nextDate, rest, true
+}
+
+// Expand returns the occurrences in the inclusive date range,
+// which start from the start date; their count is limited
+// by the MaximalOccurrenceCount constant.
+func (recurrence Recurrence) Expand(
+	start time.Time,
+	minimalDate time.Time,
+	maximalDate time.Time,
+) []time.Time {
+	var dates []time.Time
+	date, number := start, 1
+	for !date.After(maximalDate) && len(dates) < MaximalOccurrenceCount {
+		if !date.Before(minimalDate) {
+			dates = append(dates, date)
+		}
+		if recurrence.Count != 0 && number >= recurrence.Count {
+			break
+		}
+
+		var ok bool
+		if date, ok = recurrence.nextDate(date); !ok {
+			break
+		}
+
+		number++
+	}
+
+	return dates
+}
+
+func (recurrence Recurrence) nextDate(date time.Time) (time.Time, bool) {
+	var nextDate time.Time
+	switch recurrence.Frequency {
+	case FrequencyDaily:
+		nextDate = date.AddDate(0, 0, recurrence.Interval)
+	case FrequencyWeekly:
+		nextDate = recurrence.nextWeeklyDate(date)
+	case FrequencyMonthly:
+		var ok bool
+		if nextDate, ok = recurrence.nextMonthlyDate(date); !ok {
+			return time.Time{}, false
+		}
+	default:
+		return time.Time{}, false
+	}
+
+	if !recurrence.Until.IsZero() && nextDate.After(recurrence.Until) {
+		return time.Time{}, false
+	}
+
+	return nextDate, true
+}
+
+func (recurrence Recurrence) nextWeeklyDate(date time.Time) time.Time {
+	if len(recurrence.Weekdays) == 0 {
+		return date.AddDate(0, 0, 7*recurrence.Interval)
+	}
+
+	// the weekday offsets from Monday
+	offsets := map[int]struct{}{}
+	for _, weekday := range recurrence.Weekdays {
+		offsets[weekdayOffset(weekday)] = struct{}{}
+	}
+
+	offset := weekdayOffset(date.Weekday())
+	for nextOffset := offset + 1; nextOffset < 7; nextOffset++ {
+		if _, ok := offsets[nextOffset]; ok {
+			return date.AddDate(0, 0, nextOffset-offset)
+		}
+	}
+
+	nextWeek := date.AddDate(0, 0, 7*recurrence.Interval-offset)
+	for nextOffset := 0; ; nextOffset++ {
+		if _, ok := offsets[nextOffset]; ok {
+			return nextWeek.AddDate(0, 0, nextOffset)
+		}
+	}
+}
+
+func (recurrence Recurrence) nextMonthlyDate(date time.Time) (time.Time, bool) {
+	monthDays := recurrence.MonthDays
+	if len(monthDays) == 0 {
+		monthDays = []int{date.Day()}
+	}
+
+	year, month, _ := date.Date()
+	for index := 0; index < maximalMonthSearchCount; index++ {
+		monthStart := time.Date(
+			year, month+time.Month(index*recurrence.Interval), 1,
+			0, 0, 0, 0,
+			date.Location(),
+		)
+		dayCount := monthStart.AddDate(0, 1, -1).Day()
+
+		var days []int
+		for _, day := range monthDays {
+			if day < 0 {
+				day += dayCount + 1
+			}
+			if day >= 1 && day <= dayCount {
+				days = append(days, day)
+			}
+		}
+		sort.Ints(days)
+
+		for _, day := range days {
+			nextDate := monthStart.AddDate(0, 0, day-1)
+			if nextDate.After(date) {
+				return nextDate, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func parsePositiveInt(text string) (int, error) {
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, err
+	}
+	if value <= 0 {
+		return 0, errors.New("value is not positive")
+	}
+
+	return value, nil
+}
+
+func parseRecurrenceWeekdays(text string) ([]time.Weekday, error) {
+	var weekdays []time.Weekday
+	for _, item := range strings.Split(text, ",") {
+		weekday, ok := recurrenceWeekdays[item]
+		if !ok {
+			return nil, fmt.Errorf("unsupported weekday %q", item)
+		}
+
+		weekdays = append(weekdays, weekday)
+	}
+
+	return weekdays, nil
+}
+
+func parseRecurrenceMonthDays(text string) ([]int, error) {
+	var monthDays []int
+	for _, item := range strings.Split(text, ",") {
+		monthDay, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, err
+		}
+		if monthDay == 0 || monthDay < -31 || monthDay > 31 {
+			return nil, fmt.Errorf("month day %d is out of the range", monthDay)
+		}
+
+		monthDays = append(monthDays, monthDay)
+	}
+
+	return monthDays, nil
+}
+
+// parseRecurrenceUntil truncates the time of the UNTIL part to the date.
+func parseRecurrenceUntil(text string) (time.Time, error) {
+	if len(text) == len(recurrenceTimeFormat) {
+		until, err := time.Parse(recurrenceTimeFormat, text)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		year, month, day := until.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	return time.Parse(recurrenceDateFormat, text)
+}
+
+func formatRecurrenceWeekday(weekday time.Weekday) string {
+	for name, value := range recurrenceWeekdays {
+		if value == weekday {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func weekdayOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
@@ -0,0 +1,440 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	type args struct {
+		text string
+	}
+
+	tests := []struct {
+		name           string
+		args           args
+		wantRecurrence Recurrence
+		wantErr        string
+	}{
+		{
+			name:           "success with the frequency only",
+			args:           args{text: "FREQ=DAILY"},
+			wantRecurrence: Recurrence{Frequency: FrequencyDaily, Interval: 1},
+		},
+		{
+			name: "success with the weekdays",
+			args: args{text: "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=10"},
+			wantRecurrence: Recurrence{
+				Frequency: FrequencyWeekly,
+				Interval:  2,
+				Weekdays:  []time.Weekday{time.Monday, time.Friday},
+				Count:     10,
+			},
+		},
+		{
+			name: "success with the month days",
+			args: args{text: "FREQ=MONTHLY;BYMONTHDAY=1,-1;UNTIL=20061231"},
+			wantRecurrence: Recurrence{
+				Frequency: FrequencyMonthly,
+				Interval:  1,
+				MonthDays: []int{1, -1},
+				Until:     time.Date(2006, time.December, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "success with the until time",
+			args: args{text: "FREQ=DAILY;UNTIL=20061231T150405Z"},
+			wantRecurrence: Recurrence{
+				Frequency: FrequencyDaily,
+				Interval:  1,
+				Until:     time.Date(2006, time.December, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "error with the part without a value",
+			args:    args{text: "FREQ"},
+			wantErr: `part "FREQ" without a value`,
+		},
+		{
+			name:    "error with the duplicate part",
+			args:    args{text: "FREQ=DAILY;FREQ=WEEKLY"},
+			wantErr: `duplicate part "FREQ"`,
+		},
+		{
+			name:    "error with the unsupported part",
+			args:    args{text: "FREQ=DAILY;BYHOUR=12"},
+			wantErr: `unsupported part "BYHOUR"`,
+		},
+		{
+			name:    "error with the unsupported frequency",
+			args:    args{text: "FREQ=YEARLY"},
+			wantErr: `incorrect part "FREQ": unsupported value`,
+		},
+		{
+			name:    "error with the interval",
+			args:    args{text: "FREQ=DAILY;INTERVAL=0"},
+			wantErr: `incorrect part "INTERVAL": value is not positive`,
+		},
+		{
+			name:    "error with the weekday",
+			args:    args{text: "FREQ=WEEKLY;BYDAY=1MO"},
+			wantErr: `incorrect part "BYDAY": unsupported weekday "1MO"`,
+		},
+		{
+			name:    "error with the month day",
+			args:    args{text: "FREQ=MONTHLY;BYMONTHDAY=32"},
+			wantErr: `incorrect part "BYMONTHDAY": month day 32 is out of the range`,
+		},
+		{
+			name:    "error without the frequency",
+			args:    args{text: "COUNT=2"},
+			wantErr: "the FREQ part is required",
+		},
+		{
+			name:    "error with the weekdays of the non-weekly frequency",
+			args:    args{text: "FREQ=DAILY;BYDAY=MO"},
+			wantErr: "the BYDAY part is supported only for the weekly frequency",
+		},
+		{
+			name: "error with the month days of the non-monthly frequency",
+			args: args{text: "FREQ=WEEKLY;BYMONTHDAY=1"},
+			wantErr: "the BYMONTHDAY part is supported only " +
+				"for the monthly frequency",
+		},
+		{
+			name:    "error with the until date and the count",
+			args:    args{text: "FREQ=DAILY;UNTIL=20061231;COUNT=2"},
+			wantErr: "the UNTIL and COUNT parts are mutually exclusive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRecurrence, gotErr := ParseRecurrence(tt.args.text)
+
+			assert.Equal(t, tt.wantRecurrence, gotRecurrence)
+			if tt.wantErr == "" {
+				assert.NoError(t, gotErr)
+			} else {
+				assert.EqualError(t, gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecurrence_String(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence Recurrence
+		want       string
+	}{
+		{
+			name:       "with the frequency only",
+			recurrence: Recurrence{Frequency: FrequencyDaily, Interval: 1},
+			want:       "FREQ=DAILY",
+		},
+		{
+			name: "with all the parts",
+			recurrence: Recurrence{
+				Frequency: FrequencyWeekly,
+				Interval:  2,
+				Weekdays:  []time.Weekday{time.Monday, time.Friday},
+				Until:     time.Date(2006, time.December, 31, 0, 0, 0, 0, time.UTC),
+			},
+			want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;UNTIL=20061231",
+		},
+		{
+			name: "with the month days and the count",
+			recurrence: Recurrence{
+				Frequency: FrequencyMonthly,
+				Interval:  1,
+				MonthDays: []int{1, -1},
+				Count:     3,
+			},
+			want: "FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.recurrence.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRecurrence_Next(t *testing.T) {
+	type args struct {
+		date time.Time
+	}
+
+	tests := []struct {
+		name           string
+		recurrence     Recurrence
+		args           args
+		wantDate       time.Time
+		wantRecurrence Recurrence
+		wantOk         bool
+	}{
+		{
+			name:       "daily with the interval",
+			recurrence: Recurrence{Frequency: FrequencyDaily, Interval: 3},
+			args: args{
+				date: time.Date(2006, time.January, 30, 0, 0, 0, 0, time.UTC),
+			},
+			wantDate:       time.Date(2006, time.February, 2, 0, 0, 0, 0, time.UTC),
+			wantRecurrence: Recurrence{Frequency: FrequencyDaily, Interval: 3},
+			wantOk:         true,
+		},
+		{
+			name:       "weekly without the weekdays",
+			recurrence: Recurrence{Frequency: FrequencyWeekly, Interval: 2},
+			args: args{
+				date: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			},
+			wantDate:       time.Date(2006, time.January, 16, 0, 0, 0, 0, time.UTC),
+			wantRecurrence: Recurrence{Frequency: FrequencyWeekly, Interval: 2},
+			wantOk:         true,
+		},
+		{
+			name: "weekly with the weekday in the same week",
+			recurrence: Recurrence{
+				Frequency: FrequencyWeekly,
+				Interval:  2,
+				Weekdays:  []time.Weekday{time.Friday, time.Monday},
+			},
+			args: args{
+				// Monday
+				date: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			},
+			wantDate: time.Date(2006, time.January, 6, 0, 0, 0, 0, time.UTC),
+			wantRecurrence: Recurrence{
+				Frequency: FrequencyWeekly,
+				Interval:  2,
+				Weekdays:  []time.Weekday{time.Friday, time.Monday},
+			},
+			wantOk: true,
+		},
+		{
+			name: "weekly with the weekday in the next week",
+			recurrence: Recurrence{
+				Frequency: FrequencyWeekly,
+				Interval:  2,
+				Weekdays:  []time.Weekday{time.Friday, time.Monday},
+			},
+			args: args{
+				// Friday
+				date: time.Date(2006, time.January, 6, 0, 0, 0, 0, time.UTC),
+			},
+			wantDate: time.Date(2006, time.January, 16, 0, 0, 0, 0, time.UTC),
+			wantRecurrence: Recurrence{
+				Frequency: FrequencyWeekly,
+				Interval:  2,
+				Weekdays:  []time.Weekday{time.Friday, time.Monday},
+			},
+			wantOk: true,
+		},
+		{
+			name:       "monthly without the month days",
+			recurrence: Recurrence{Frequency: FrequencyMonthly, Interval: 1},
+			args: args{
+				date: time.Date(2006, time.January, 31, 0, 0, 0, 0, time.UTC),
+			},
+			// the months without the 31st day are skipped
+			wantDate:       time.Date(2006, time.March, 31, 0, 0, 0, 0, time.UTC),
+			wantRecurrence: Recurrence{Frequency: FrequencyMonthly, Interval: 1},
+			wantOk:         true,
+		},
+		{
+			name: "monthly with the month days",
+			recurrence: Recurrence{
+				Frequency: FrequencyMonthly,
+				Interval:  1,
+				MonthDays: []int{-1, 15},
+			},
+			args: args{
+				date: time.Date(2006, time.January, 31, 0, 0, 0, 0, time.UTC),
+			},
+			wantDate: time.Date(2006, time.February, 15, 0, 0, 0, 0, time.UTC),
+			wantRecurrence: Recurrence{
+				Frequency: FrequencyMonthly,
+				Interval:  1,
+				MonthDays: []int{-1, 15},
+			},
+			wantOk: true,
+		},
+		{
+			name: "monthly with the last day",
+			recurrence: Recurrence{
+				Frequency: FrequencyMonthly,
+				Interval:  1,
+				MonthDays: []int{-1, 15},
+			},
+			args: args{
+				date: time.Date(2006, time.February, 15, 0, 0, 0, 0, time.UTC),
+			},
+			wantDate: time.Date(2006, time.February, 28, 0, 0, 0, 0, time.UTC),
+			wantRecurrence: Recurrence{
+				Frequency: FrequencyMonthly,
+				Interval:  1,
+				MonthDays: []int{-1, 15},
+			},
+			wantOk: true,
+		},
+		{
+			name: "with the count",
+			recurrence: Recurrence{
+				Frequency: FrequencyDaily,
+				Interval:  1,
+				Count:     3,
+			},
+			args: args{
+				date: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			},
+			wantDate: time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+			wantRecurrence: Recurrence{
+				Frequency: FrequencyDaily,
+				Interval:  1,
+				Count:     2,
+			},
+			wantOk: true,
+		},
+		{
+			name: "with the last count",
+			recurrence: Recurrence{
+				Frequency: FrequencyDaily,
+				Interval:  1,
+				Count:     1,
+			},
+			args: args{
+				date: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			},
+			wantOk: false,
+		},
+		{
+			name: "with the until date",
+			recurrence: Recurrence{
+				Frequency: FrequencyDaily,
+				Interval:  1,
+				Until:     time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			},
+			args: args{
+				date: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDate, gotRecurrence, gotOk := tt.recurrence.Next(tt.args.date)
+
+			assert.Equal(t, tt.wantDate, gotDate)
+			assert.Equal(t, tt.wantRecurrence, gotRecurrence)
+			assert.Equal(t, tt.wantOk, gotOk)
+		})
+	}
+}
+
+func TestRecurrence_Expand(t *testing.T) {
+	type args struct {
+		start       time.Time
+		minimalDate time.Time
+		maximalDate time.Time
+	}
+
+	tests := []struct {
+		name       string
+		recurrence Recurrence
+		args       args
+		want       []time.Time
+	}{
+		{
+			name:       "with the range",
+			recurrence: Recurrence{Frequency: FrequencyWeekly, Interval: 1},
+			args: args{
+				start:       time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				minimalDate: time.Date(2006, time.January, 9, 0, 0, 0, 0, time.UTC),
+				maximalDate: time.Date(2006, time.January, 23, 0, 0, 0, 0, time.UTC),
+			},
+			want: []time.Time{
+				time.Date(2006, time.January, 9, 0, 0, 0, 0, time.UTC),
+				time.Date(2006, time.January, 16, 0, 0, 0, 0, time.UTC),
+				time.Date(2006, time.January, 23, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "with the count",
+			recurrence: Recurrence{
+				Frequency: FrequencyDaily,
+				Interval:  1,
+				Count:     3,
+			},
+			args: args{
+				start:       time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				minimalDate: time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+				maximalDate: time.Date(2006, time.January, 31, 0, 0, 0, 0, time.UTC),
+			},
+			want: []time.Time{
+				time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+				time.Date(2006, time.January, 4, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "with the until date",
+			recurrence: Recurrence{
+				Frequency: FrequencyMonthly,
+				Interval:  1,
+				Until:     time.Date(2006, time.March, 1, 0, 0, 0, 0, time.UTC),
+			},
+			args: args{
+				start:       time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				minimalDate: time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+				maximalDate: time.Date(2006, time.December, 31, 0, 0, 0, 0, time.UTC),
+			},
+			want: []time.Time{
+				time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(2006, time.February, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "with the range before the start",
+			recurrence: Recurrence{Frequency: FrequencyDaily, Interval: 1},
+			args: args{
+				start:       time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				minimalDate: time.Date(2005, time.January, 1, 0, 0, 0, 0, time.UTC),
+				maximalDate: time.Date(2005, time.December, 31, 0, 0, 0, 0, time.UTC),
+			},
+			want: nil,
+		},
+		{
+			name:       "with the occurrence limit",
+			recurrence: Recurrence{Frequency: FrequencyDaily, Interval: 1},
+			args: args{
+				start:       time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				minimalDate: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				maximalDate: time.Date(2106, time.January, 2, 0, 0, 0, 0, time.UTC),
+			},
+			want: func() []time.Time {
+				var dates []time.Time
+				for index := 0; index < MaximalOccurrenceCount; index++ {
+					date := time.Date(2006, time.January, 2+index, 0, 0, 0, 0, time.UTC)
+					dates = append(dates, date)
+				}
+
+				return dates
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.recurrence.Expand(
+				tt.args.start,
+				tt.args.minimalDate,
+				tt.args.maximalDate,
+			)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
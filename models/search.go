@@ -0,0 +1,97 @@
+package models
+
+import "strings"
+
+// Highlight marks of the matched words in search results.
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// SplitSearchTerms returns the lower-cased words of the search query.
+func SplitSearchTerms(search string) []string {
+	return strings.Fields(strings.ToLower(search))
+}
+
+// MatchSearchTerms is a simplified full-text search for the storages
+// without the native one: the text should contain all the terms ignoring case.
+func MatchSearchTerms(text string, terms []string) bool {
+	lowerText := strings.ToLower(text)
+	for _, term := range terms {
+		if !strings.Contains(lowerText, term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HighlightTodoRecord highlights the search terms of the query in the title
+// and, if the query covers the notes, in the matched notes; it's used by
+// the storages without the native full-text search.
+func HighlightTodoRecord(todo TodoRecord, query Query) TodoRecord {
+	if !query.Highlight || query.Search == "" {
+		return todo
+	}
+
+	terms := SplitSearchTerms(query.Search)
+	todo.Highlight = HighlightSearchTerms(todo.Title, terms)
+	if query.SearchNotes {
+		// the highlighting changes the text only if it matches
+		notesHighlight := HighlightSearchTerms(todo.Notes, terms)
+		if notesHighlight != todo.Notes {
+			todo.NotesHighlight = notesHighlight
+		}
+	}
+
+	return todo
+}
+
+// HighlightSearchTerms wraps the occurrences of the terms
+// with the highlight marks ignoring case.
+func HighlightSearchTerms(text string, terms []string) string {
+	// lowering can change the byte length of some characters,
+	// so positions are found in the original text
+	lowerText := strings.ToLower(text)
+	if len(lowerText) != len(text) {
+		return text
+	}
+
+	isMatched := make([]bool, len(text))
+	for _, term := range terms {
+		if term == "" {
+			continue
+		}
+
+		for offset := 0; ; {
+			index := strings.Index(lowerText[offset:], term)
+			if index == -1 {
+				break
+			}
+
+			start := offset + index
+			for position := start; position < start+len(term); position++ {
+				isMatched[position] = true
+			}
+
+			offset = start + len(term)
+		}
+	}
+
+	var highlightedText strings.Builder
+	for position := 0; position < len(text); position++ {
+		if isMatched[position] && (position == 0 || !isMatched[position-1]) {
+			highlightedText.WriteString(HighlightStart)
+		}
+		if !isMatched[position] && position > 0 && isMatched[position-1] {
+			highlightedText.WriteString(HighlightStop)
+		}
+
+		highlightedText.WriteByte(text[position])
+	}
+	if len(text) > 0 && isMatched[len(text)-1] {
+		highlightedText.WriteString(HighlightStop)
+	}
+
+	return highlightedText.String()
+}
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitSearchTerms(t *testing.T) {
+	got := SplitSearchTerms("  Buy  MILK\ttoday ")
+
+	assert.Equal(t, []string{"buy", "milk", "today"}, got)
+}
+
+func TestMatchSearchTerms(t *testing.T) {
+	type args struct {
+		text  string
+		terms []string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "with all the terms",
+			args: args{text: "Buy some Milk", terms: []string{"milk", "buy"}},
+			want: true,
+		},
+		{
+			name: "with a part of the terms",
+			args: args{text: "Buy some Milk", terms: []string{"milk", "bread"}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MatchSearchTerms(tt.args.text, tt.args.terms)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHighlightTodoRecord(t *testing.T) {
+	type args struct {
+		todo  TodoRecord
+		query Query
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want TodoRecord
+	}{
+		{
+			name: "without highlighting",
+			args: args{
+				todo:  TodoRecord{Title: "Buy milk"},
+				query: Query{Search: "milk"},
+			},
+			want: TodoRecord{Title: "Buy milk"},
+		},
+		{
+			name: "with the title",
+			args: args{
+				todo:  TodoRecord{Title: "Buy milk", Notes: "Some milk"},
+				query: Query{Search: "milk", Highlight: true},
+			},
+			want: TodoRecord{
+				Title:     "Buy milk",
+				Notes:     "Some milk",
+				Highlight: "Buy <mark>milk</mark>",
+			},
+		},
+		{
+			name: "with the matched notes",
+			args: args{
+				todo:  TodoRecord{Title: "Go shopping", Notes: "Buy milk"},
+				query: Query{Search: "milk", Highlight: true, SearchNotes: true},
+			},
+			want: TodoRecord{
+				Title:          "Go shopping",
+				Notes:          "Buy milk",
+				Highlight:      "Go shopping",
+				NotesHighlight: "Buy <mark>milk</mark>",
+			},
+		},
+		{
+			name: "with the unmatched notes",
+			args: args{
+				todo:  TodoRecord{Title: "Buy milk", Notes: "Some bread"},
+				query: Query{Search: "milk", Highlight: true, SearchNotes: true},
+			},
+			want: TodoRecord{
+				Title:     "Buy milk",
+				Notes:     "Some bread",
+				Highlight: "Buy <mark>milk</mark>",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HighlightTodoRecord(tt.args.todo, tt.args.query)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHighlightSearchTerms(t *testing.T) {
+	type args struct {
+		text  string
+		terms []string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "without matches",
+			args: args{text: "Buy some milk", terms: []string{"bread"}},
+			want: "Buy some milk",
+		},
+		{
+			name: "with several matches",
+			args: args{text: "Buy milk, more MILK", terms: []string{"milk", "buy"}},
+			want: "<mark>Buy</mark> <mark>milk</mark>, more <mark>MILK</mark>",
+		},
+		{
+			name: "with overlapping matches",
+			args: args{text: "milkshake", terms: []string{"milk", "kshake"}},
+			want: "<mark>milkshake</mark>",
+		},
+		{
+			name: "with multibyte characters",
+			args: args{text: "Купить молоко", terms: []string{"молок"}},
+			want: "Купить <mark>молок</mark>о",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HighlightSearchTerms(tt.args.text, tt.args.terms)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
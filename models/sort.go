@@ -0,0 +1,127 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sortable fields of to-do records.
+const (
+	SortByID        = "id"
+	SortByDate      = "date"
+	SortByTitle     = "title"
+	SortByCompleted = "completed"
+	SortByOrder     = "order"
+	SortByPriority  = "priority"
+	SortByRelevance = "relevance"
+	// the uncompleted to-do records are the last ones
+	// in both directions of the completion time sort
+	SortByCreatedAt   = "created_at"
+	SortByUpdatedAt   = "updated_at"
+	SortByCompletedAt = "completed_at"
+)
+
+// DefaultSort is used if no other default sort is configured,
+// see the ParseDefaultSort() function.
+var DefaultSort = []SortField{
+	{Name: SortByDate, Descending: true},
+	{Name: SortByOrder},
+	{Name: SortByID},
+}
+
+// the cursor mode supports only the default sort,
+// so it's limited to the fields of the models.Cursor structure
+var cursorFields = map[string]struct{}{
+	SortByID:       {},
+	SortByDate:     {},
+	SortByOrder:    {},
+	SortByPriority: {},
+}
+
+var sortableFields = map[string]struct{}{
+	SortByID:          {},
+	SortByDate:        {},
+	SortByTitle:       {},
+	SortByCompleted:   {},
+	SortByOrder:       {},
+	SortByPriority:    {},
+	SortByRelevance:   {},
+	SortByCreatedAt:   {},
+	SortByUpdatedAt:   {},
+	SortByCompletedAt: {},
+}
+
+// SortField ...
+type SortField struct {
+	Name       string
+	Descending bool
+}
+
+// ParseSort parses a comma-separated list of the sortable fields,
+// each one with an optional "-" prefix for the descending order,
+// e.g. "completed,-date,title".
+func ParseSort(text string) ([]SortField, error) {
+	var sort []SortField
+	usedFields := map[string]struct{}{}
+	for _, item := range strings.Split(text, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			return nil, errors.New("empty field")
+		}
+
+		field := SortField{Name: strings.TrimPrefix(item, "-")}
+		field.Descending = field.Name != item
+		if _, ok := sortableFields[field.Name]; !ok {
+			return nil, fmt.Errorf("unknown field %q", field.Name)
+		}
+		if _, ok := usedFields[field.Name]; ok {
+			return nil, fmt.Errorf("duplicate field %q", field.Name)
+		}
+
+		sort = append(sort, field)
+		usedFields[field.Name] = struct{}{}
+	}
+
+	return sort, nil
+}
+
+// ParseDefaultSort parses the configured default sort in the same format
+// as the ParseSort() function, e.g. "-priority" puts the higher priorities
+// first. Only the fields supported by the cursor are allowed, and the unused
+// fields of the DefaultSort variable are appended.
+func ParseDefaultSort(text string) ([]SortField, error) {
+	sort, err := ParseSort(text)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range sort {
+		if _, ok := cursorFields[field.Name]; !ok {
+			return nil,
+				fmt.Errorf("field %q isn't supported by the cursor", field.Name)
+		}
+	}
+
+	return CompleteSort(sort, DefaultSort), nil
+}
+
+// CompleteSort appends the unused fields of the default sort
+// to make the order of records stable.
+func CompleteSort(sort []SortField, defaultSort []SortField) []SortField {
+	completeSort := append([]SortField(nil), sort...)
+	for _, defaultField := range defaultSort {
+		isUsed := false
+		for _, field := range sort {
+			if field.Name == defaultField.Name {
+				isUsed = true
+				break
+			}
+		}
+		if !isUsed {
+			completeSort = append(completeSort, defaultField)
+		}
+	}
+
+	return completeSort
+}
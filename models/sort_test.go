@@ -0,0 +1,191 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	type args struct {
+		text string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []SortField
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success with a single field",
+			args:    args{text: "title"},
+			want:    []SortField{{Name: SortByTitle}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with several fields",
+			args: args{text: "completed, -date,title,-priority"},
+			want: []SortField{
+				{Name: SortByCompleted},
+				{Name: SortByDate, Descending: true},
+				{Name: SortByTitle},
+				{Name: SortByPriority, Descending: true},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error with an empty field",
+			args:    args{text: "title,,date"},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with an unknown field",
+			args: args{text: "title,-unknown"},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, `unknown field "unknown"`, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a duplicate field",
+			args: args{text: "title,-title"},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, `duplicate field "title"`, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSort(tt.args.text)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestParseDefaultSort(t *testing.T) {
+	type args struct {
+		text string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []SortField
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the priority",
+			args: args{text: "-priority"},
+			want: []SortField{
+				{Name: SortByPriority, Descending: true},
+				{Name: SortByDate, Descending: true},
+				{Name: SortByOrder},
+				{Name: SortByID},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the default fields",
+			args:    args{text: "-date,order,id"},
+			want:    DefaultSort,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with parsing",
+			args: args{text: "unknown"},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, `unknown field "unknown"`, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with the field unsupported by the cursor",
+			args: args{text: "-priority,title"},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, `field "title" isn't supported by the cursor`, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDefaultSort(tt.args.text)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCompleteSort(t *testing.T) {
+	type args struct {
+		sort        []SortField
+		defaultSort []SortField
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want []SortField
+	}{
+		{
+			name: "without fields",
+			args: args{sort: nil, defaultSort: DefaultSort},
+			want: DefaultSort,
+		},
+		{
+			name: "with fields",
+			args: args{
+				sort: []SortField{
+					{Name: SortByCompleted},
+					{Name: SortByDate},
+				},
+				defaultSort: DefaultSort,
+			},
+			want: []SortField{
+				{Name: SortByCompleted},
+				{Name: SortByDate},
+				{Name: SortByOrder},
+				{Name: SortByID},
+			},
+		},
+		{
+			name: "with the configured default sort",
+			args: args{
+				sort: []SortField{{Name: SortByPriority}},
+				defaultSort: []SortField{
+					{Name: SortByPriority, Descending: true},
+					{Name: SortByDate, Descending: true},
+					{Name: SortByOrder},
+					{Name: SortByID},
+				},
+			},
+			want: []SortField{
+				{Name: SortByPriority},
+				{Name: SortByDate, Descending: true},
+				{Name: SortByOrder},
+				{Name: SortByID},
+			},
+		},
+		{
+			name: "without the default sort",
+			args: args{
+				sort:        []SortField{{Name: SortByTitle}},
+				defaultSort: nil,
+			},
+			want: []SortField{{Name: SortByTitle}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompleteSort(tt.args.sort, tt.args.defaultSort)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package models
+
+// Tag ...
+type Tag struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
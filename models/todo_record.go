@@ -7,17 +7,57 @@ type TodoRecord struct {
 	ID        int
 	Date      time.Time
 	Title     string
+	Notes     string
 	Completed bool
 	Order     int
+	Priority  int
+	Tags      []string
+	ListID    int
+	Version   int
+	// ParentID is zero for the top-level to-do records.
+	ParentID int
+	// ChildCount and CompletedChildCount are computed by the storage
+	// over the direct children, except the trashed ones.
+	ChildCount          int
+	CompletedChildCount int
+	// BlockerIDs and Blocked are computed by the storage too; BlockerIDs
+	// include the trashed blockers, but Blocked means only the open ones,
+	// i.e. the non-completed and non-trashed ones.
+	BlockerIDs []int
+	Blocked    bool
+	// Recurrence is the rule in the RFC 5545 format, see the Recurrence type;
+	// it's empty for the non-recurring to-do records.
+	Recurrence string
+	// DueAt is optional and kept in UTC.
+	DueAt *time.Time
+	// CreatedAt, UpdatedAt and CompletedAt are maintained by the storage;
+	// CompletedAt is set only for the completed to-do records.
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+	// DeletedAt is set only for the trashed to-do records.
+	DeletedAt *time.Time
+	// Highlight is set only by the search with highlighting.
+	Highlight string
+	// NotesHighlight is set only if the search with highlighting
+	// covers the notes and matches them.
+	NotesHighlight string
 }
 
 // NewTodoRecord ...
 func NewTodoRecord(presentationTodo PresentationTodoRecord) TodoRecord {
 	return TodoRecord{
-		Date:      time.Time(presentationTodo.Date),
-		Title:     presentationTodo.Title,
-		Completed: presentationTodo.Completed,
-		Order:     presentationTodo.Order,
+		Date:       time.Time(presentationTodo.Date),
+		DueAt:      inUTC(presentationTodo.DueAt),
+		Title:      presentationTodo.Title,
+		Notes:      presentationTodo.Notes,
+		Completed:  presentationTodo.Completed,
+		Order:      presentationTodo.Order,
+		Priority:   presentationTodo.Priority,
+		Tags:       presentationTodo.Tags,
+		ListID:     presentationTodo.ListID,
+		ParentID:   presentationTodo.ParentID,
+		Recurrence: presentationTodo.Recurrence,
 	}
 }
 
@@ -26,13 +66,43 @@ func (todo *TodoRecord) Patch(patch TodoRecordPatch) {
 	if patch.Date != nil {
 		todo.Date = time.Time(*patch.Date)
 	}
+	if patch.DueAt.IsSet {
+		todo.DueAt = inUTC(patch.DueAt.Value)
+	}
 	if patch.Title != nil {
 		todo.Title = *patch.Title
 	}
+	if patch.Notes != nil {
+		todo.Notes = *patch.Notes
+	}
 	if patch.Completed != nil {
 		todo.Completed = *patch.Completed
 	}
 	if patch.Order != nil {
 		todo.Order = *patch.Order
 	}
+	if patch.Priority != nil {
+		todo.Priority = *patch.Priority
+	}
+	if patch.Tags != nil {
+		todo.Tags = *patch.Tags
+	}
+	if patch.ListID != nil {
+		todo.ListID = *patch.ListID
+	}
+	if patch.ParentID != nil {
+		todo.ParentID = *patch.ParentID
+	}
+	if patch.Recurrence != nil {
+		todo.Recurrence = *patch.Recurrence
+	}
+}
+
+func inUTC(timestamp *time.Time) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+
+	timestampInUTC := timestamp.UTC()
+	return &timestampInUTC
 }
@@ -1,11 +1,50 @@
 package models
 
-import utilmodels "github.com/irenicaa/go-http-utils/models"
+import (
+	"encoding/json"
+	"time"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+)
 
 // TodoRecordPatch ...
 type TodoRecordPatch struct {
-	Date      *utilmodels.Date `json:"date"`
-	Title     *string          `json:"title"`
-	Completed *bool            `json:"completed"`
-	Order     *int             `json:"order"`
+	Date      *utilmodels.Date  `json:"date"`
+	DueAt     NullableTimestamp `json:"due_at" swaggertype:"string" format:"date-time"`
+	Title     *string           `json:"title"`
+	Notes     *string           `json:"notes"`
+	Completed *bool             `json:"completed"`
+	Order     *int              `json:"order"`
+	Priority  *int              `json:"priority"`
+	Tags      *[]string         `json:"tags"`
+	ListID    *int              `json:"list_id"`
+	// the zero parent ID makes the to-do record top-level
+	ParentID *int `json:"parent_id"`
+	// the empty recurrence makes the to-do record non-recurring
+	Recurrence *string `json:"recurrence"`
+}
+
+// NullableTimestamp distinguishes the null value, which clears the field,
+// from the missed one in the JSON.
+type NullableTimestamp struct {
+	IsSet bool
+	Value *time.Time
+}
+
+// MarshalJSON ...
+func (timestamp NullableTimestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(timestamp.Value)
+}
+
+// UnmarshalJSON ...
+func (timestamp *NullableTimestamp) UnmarshalJSON(data []byte) error {
+	var value *time.Time
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	timestamp.IsSet = true
+	timestamp.Value = value
+
+	return nil
 }
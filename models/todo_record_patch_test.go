@@ -0,0 +1,64 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullableTimestamp_UnmarshalJSON(t *testing.T) {
+	type args struct {
+		data string
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		wantPatch TodoRecordPatch
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "with the missed value",
+			args:      args{data: `{}`},
+			wantPatch: TodoRecordPatch{},
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "with the null value",
+			args:      args{data: `{"due_at":null}`},
+			wantPatch: TodoRecordPatch{DueAt: NullableTimestamp{IsSet: true}},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "with the timestamp",
+			args: args{data: `{"due_at":"2006-01-02T15:04:05+09:00"}`},
+			wantPatch: TodoRecordPatch{
+				DueAt: NullableTimestamp{
+					IsSet: true,
+					Value: func() *time.Time {
+						dueAt := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.FixedZone("", 9*60*60))
+						return &dueAt
+					}(),
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "error",
+			args:      args{data: `{"due_at":"incorrect"}`},
+			wantPatch: TodoRecordPatch{DueAt: NullableTimestamp{}},
+			wantErr:   assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPatch TodoRecordPatch
+			err := json.Unmarshal([]byte(tt.args.data), &gotPatch)
+
+			assert.Equal(t, tt.wantPatch, gotPatch)
+			tt.wantErr(t, err)
+		})
+	}
+}
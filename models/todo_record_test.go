@@ -27,6 +27,7 @@ func TestNewTodoRecord(t *testing.T) {
 					Title:     "test",
 					Completed: true,
 					Order:     42,
+					ListID:    12,
 				},
 			},
 			want: TodoRecord{
@@ -34,6 +35,29 @@ func TestNewTodoRecord(t *testing.T) {
 				Title:     "test",
 				Completed: true,
 				Order:     42,
+				ListID:    12,
+			},
+		},
+		{
+			name: "success with the due time",
+			args: args{
+				presentationTodo: PresentationTodoRecord{
+					URL:  "https://example.com/api/v1/todos/23",
+					Date: utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					DueAt: func() *time.Time {
+						dueAt := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.FixedZone("UTC+9", 9*60*60))
+						return &dueAt
+					}(),
+					Title: "test",
+				},
+			},
+			want: TodoRecord{
+				Date: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				DueAt: func() *time.Time {
+					dueAt := time.Date(2006, time.January, 2, 6, 4, 5, 0, time.UTC)
+					return &dueAt
+				}(),
+				Title: "test",
 			},
 		},
 	}
@@ -50,7 +74,9 @@ func TestTodoRecord_Patch(t *testing.T) {
 	type fields struct {
 		ID        int
 		Date      time.Time
+		DueAt     *time.Time
 		Title     string
+		Notes     string
 		Completed bool
 		Order     int
 	}
@@ -237,13 +263,107 @@ func TestTodoRecord_Patch(t *testing.T) {
 				Order:     42,
 			},
 		},
+		{
+			name: "setting of a due time",
+			fields: fields{
+				ID:    23,
+				Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title: "test",
+			},
+			args: args{
+				patch: TodoRecordPatch{
+					DueAt: NullableTimestamp{
+						IsSet: true,
+						Value: func() *time.Time {
+							dueAt := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.FixedZone("UTC+9", 9*60*60))
+							return &dueAt
+						}(),
+					},
+				},
+			},
+			wantTodo: &TodoRecord{
+				ID:   23,
+				Date: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				DueAt: func() *time.Time {
+					dueAt := time.Date(2006, time.January, 2, 6, 4, 5, 0, time.UTC)
+					return &dueAt
+				}(),
+				Title: "test",
+			},
+		},
+		{
+			name: "clearing of a due time",
+			fields: fields{
+				ID:   23,
+				Date: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				DueAt: func() *time.Time {
+					dueAt := time.Date(2006, time.January, 2, 6, 4, 5, 0, time.UTC)
+					return &dueAt
+				}(),
+				Title: "test",
+			},
+			args: args{
+				patch: TodoRecordPatch{DueAt: NullableTimestamp{IsSet: true}},
+			},
+			wantTodo: &TodoRecord{
+				ID:    23,
+				Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title: "test",
+			},
+		},
+		{
+			name: "clearing of notes",
+			fields: fields{
+				ID:    23,
+				Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title: "test",
+				Notes: "*notes*",
+			},
+			args: args{
+				patch: TodoRecordPatch{
+					Notes: func() *string {
+						notes := ""
+						return &notes
+					}(),
+				},
+			},
+			wantTodo: &TodoRecord{
+				ID:    23,
+				Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title: "test",
+			},
+		},
+		{
+			name: "setting of a priority",
+			fields: fields{
+				ID:    23,
+				Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title: "test",
+			},
+			args: args{
+				patch: TodoRecordPatch{
+					Priority: func() *int {
+						priority := PriorityHigh
+						return &priority
+					}(),
+				},
+			},
+			wantTodo: &TodoRecord{
+				ID:       23,
+				Date:     time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Title:    "test",
+				Priority: PriorityHigh,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			todo := &TodoRecord{
 				ID:        tt.fields.ID,
 				Date:      tt.fields.Date,
+				DueAt:     tt.fields.DueAt,
 				Title:     tt.fields.Title,
+				Notes:     tt.fields.Notes,
 				Completed: tt.fields.Completed,
 				Order:     tt.fields.Order,
 			}
@@ -0,0 +1,380 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+)
+
+// ValidationLimits ...
+type ValidationLimits struct {
+	MaximalTitleLength int
+	MaximalNotesLength int
+	MinimalOrder       int
+	MaximalOrder       int
+	MaximalTagLength   int
+	MaximalTagCount    int
+	MaximalBatchSize   int
+}
+
+// DefaultValidationLimits ...
+var DefaultValidationLimits = ValidationLimits{
+	MaximalTitleLength: 1000,
+	MaximalNotesLength: 10000,
+	MinimalOrder:       0,
+	MaximalOrder:       math.MaxInt32,
+	MaximalTagLength:   100,
+	MaximalTagCount:    20,
+	MaximalBatchSize:   100,
+}
+
+// FieldError ...
+type FieldError struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ValidationError ...
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error ...
+func (err ValidationError) Error() string {
+	var fieldMessages []string
+	for _, field := range err.Fields {
+		fieldMessages = append(
+			fieldMessages,
+			fmt.Sprintf("%s: %s", field.Name, field.Reason),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s fields: %s",
+		ErrInvalid,
+		strings.Join(fieldMessages, "; "),
+	)
+}
+
+// Unwrap ...
+func (err ValidationError) Unwrap() error {
+	return ErrInvalid
+}
+
+// ValidatePresentationTodoRecord ...
+func (limits ValidationLimits) ValidatePresentationTodoRecord(
+	presentationTodo PresentationTodoRecord,
+) error {
+	var fields []FieldError
+	fields = limits.validateDate(fields, presentationTodo.Date)
+	fields = limits.validateTitle(fields, presentationTodo.Title)
+	fields = limits.validateNotes(fields, presentationTodo.Notes)
+	fields = limits.validateOrder(fields, presentationTodo.Order)
+	fields = limits.validatePriority(fields, presentationTodo.Priority)
+	fields = limits.validateTags(fields, presentationTodo.Tags)
+	fields = limits.validateListID(fields, presentationTodo.ListID)
+	fields = limits.validateParentID(fields, presentationTodo.ParentID)
+	fields = limits.validateRecurrence(fields, presentationTodo.Recurrence)
+
+	return newValidationError(fields)
+}
+
+// ValidateTodoRecordPatch ...
+func (limits ValidationLimits) ValidateTodoRecordPatch(
+	todoPatch TodoRecordPatch,
+) error {
+	var fields []FieldError
+	if todoPatch.Date != nil {
+		fields = limits.validateDate(fields, *todoPatch.Date)
+	}
+	if todoPatch.Title != nil {
+		fields = limits.validateTitle(fields, *todoPatch.Title)
+	}
+	if todoPatch.Notes != nil {
+		fields = limits.validateNotes(fields, *todoPatch.Notes)
+	}
+	if todoPatch.Order != nil {
+		fields = limits.validateOrder(fields, *todoPatch.Order)
+	}
+	if todoPatch.Priority != nil {
+		fields = limits.validatePriority(fields, *todoPatch.Priority)
+	}
+	if todoPatch.Tags != nil {
+		fields = limits.validateTags(fields, *todoPatch.Tags)
+	}
+	if todoPatch.ListID != nil {
+		fields = limits.validateListID(fields, *todoPatch.ListID)
+	}
+	if todoPatch.ParentID != nil {
+		fields = limits.validateParentID(fields, *todoPatch.ParentID)
+	}
+	if todoPatch.Recurrence != nil {
+		fields = limits.validateRecurrence(fields, *todoPatch.Recurrence)
+	}
+
+	return newValidationError(fields)
+}
+
+// ValidateBatch checks only the batch structure; the to-do records
+// and patches of the operations are validated on their execution.
+func (limits ValidationLimits) ValidateBatch(batch Batch) error {
+	var fields []FieldError
+	switch batch.Mode {
+	case "", BatchModeAllOrNothing, BatchModeBestEffort:
+	default:
+		fields = append(fields, FieldError{Name: "mode", Reason: "is unknown"})
+	}
+
+	switch {
+	case len(batch.Operations) == 0:
+		fields = append(
+			fields,
+			FieldError{Name: "operations", Reason: "is required"},
+		)
+	case len(batch.Operations) > limits.MaximalBatchSize:
+		reason := fmt.Sprintf("has more than %d items", limits.MaximalBatchSize)
+		fields = append(fields, FieldError{Name: "operations", Reason: reason})
+	}
+
+	for index, operation := range batch.Operations {
+		prefix := fmt.Sprintf("operations[%d].", index)
+		fields = validateBatchOperation(fields, prefix, operation)
+	}
+
+	return newValidationError(fields)
+}
+
+// ValidateDependency ...
+func (limits ValidationLimits) ValidateDependency(dependency Dependency) error {
+	var fields []FieldError
+	if dependency.BlockerID <= 0 {
+		fields = append(
+			fields,
+			FieldError{Name: "blocker_id", Reason: "is not positive"},
+		)
+	}
+
+	return newValidationError(fields)
+}
+
+// ValidatePresentationList ...
+func (limits ValidationLimits) ValidatePresentationList(
+	presentationList PresentationList,
+) error {
+	var fields []FieldError
+	fields = limits.validateTitle(fields, presentationList.Title)
+
+	return newValidationError(fields)
+}
+
+func (limits ValidationLimits) validateDate(
+	fields []FieldError,
+	date utilmodels.Date,
+) []FieldError {
+	if time.Time(date).IsZero() {
+		fields = append(fields, FieldError{Name: "date", Reason: "is required"})
+	}
+
+	return fields
+}
+
+func (limits ValidationLimits) validateTitle(
+	fields []FieldError,
+	title string,
+) []FieldError {
+	if strings.TrimSpace(title) == "" {
+		return append(fields, FieldError{Name: "title", Reason: "is required"})
+	}
+
+	if utf8.RuneCountInString(title) > limits.MaximalTitleLength {
+		reason := fmt.Sprintf(
+			"is longer than %d characters",
+			limits.MaximalTitleLength,
+		)
+		fields = append(fields, FieldError{Name: "title", Reason: reason})
+	}
+
+	return fields
+}
+
+func (limits ValidationLimits) validateNotes(
+	fields []FieldError,
+	notes string,
+) []FieldError {
+	if utf8.RuneCountInString(notes) > limits.MaximalNotesLength {
+		reason := fmt.Sprintf(
+			"is longer than %d characters",
+			limits.MaximalNotesLength,
+		)
+		fields = append(fields, FieldError{Name: "notes", Reason: reason})
+	}
+
+	return fields
+}
+
+func (limits ValidationLimits) validateOrder(
+	fields []FieldError,
+	order int,
+) []FieldError {
+	if order < limits.MinimalOrder || order > limits.MaximalOrder {
+		reason := fmt.Sprintf(
+			"is out of the range [%d, %d]",
+			limits.MinimalOrder,
+			limits.MaximalOrder,
+		)
+		fields = append(fields, FieldError{Name: "order", Reason: reason})
+	}
+
+	return fields
+}
+
+func (limits ValidationLimits) validatePriority(
+	fields []FieldError,
+	priority int,
+) []FieldError {
+	if priority < PriorityNone || priority > PriorityUrgent {
+		reason := fmt.Sprintf(
+			"is out of the range [%d, %d]",
+			PriorityNone,
+			PriorityUrgent,
+		)
+		fields = append(fields, FieldError{Name: "priority", Reason: reason})
+	}
+
+	return fields
+}
+
+func (limits ValidationLimits) validateTags(
+	fields []FieldError,
+	tags []string,
+) []FieldError {
+	if len(tags) > limits.MaximalTagCount {
+		reason := fmt.Sprintf("has more than %d items", limits.MaximalTagCount)
+		fields = append(fields, FieldError{Name: "tags", Reason: reason})
+	}
+
+	for index, tag := range tags {
+		var reason string
+		switch {
+		case tag == "":
+			reason = "is required"
+		case strings.TrimSpace(tag) != tag:
+			reason = "has surrounding whitespace"
+		case strings.Contains(tag, ","):
+			reason = "contains a comma"
+		case utf8.RuneCountInString(tag) > limits.MaximalTagLength:
+			reason =
+				fmt.Sprintf("is longer than %d characters", limits.MaximalTagLength)
+		default:
+			continue
+		}
+
+		name := fmt.Sprintf("tags[%d]", index)
+		fields = append(fields, FieldError{Name: name, Reason: reason})
+	}
+
+	return fields
+}
+
+func (limits ValidationLimits) validateListID(
+	fields []FieldError,
+	listID int,
+) []FieldError {
+	if listID < 0 {
+		fields = append(fields, FieldError{Name: "list_id", Reason: "is negative"})
+	}
+
+	return fields
+}
+
+func (limits ValidationLimits) validateParentID(
+	fields []FieldError,
+	parentID int,
+) []FieldError {
+	if parentID < 0 {
+		fields = append(
+			fields,
+			FieldError{Name: "parent_id", Reason: "is negative"},
+		)
+	}
+
+	return fields
+}
+
+func (limits ValidationLimits) validateRecurrence(
+	fields []FieldError,
+	recurrence string,
+) []FieldError {
+	if recurrence == "" {
+		return fields
+	}
+
+	if _, err := ParseRecurrence(recurrence); err != nil {
+		reason := fmt.Sprintf("is incorrect: %v", err)
+		fields = append(fields, FieldError{Name: "recurrence", Reason: reason})
+	}
+
+	return fields
+}
+
+func newValidationError(fields []FieldError) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return ValidationError{Fields: fields}
+}
+
+func validateBatchOperation(
+	fields []FieldError,
+	prefix string,
+	operation BatchOperation,
+) []FieldError {
+	var needsID, needsTodo, needsPatch bool
+	switch operation.Action {
+	case BatchActionCreate:
+		needsTodo = true
+	case BatchActionUpdate:
+		needsID, needsTodo = true, true
+	case BatchActionPatch:
+		needsID, needsPatch = true, true
+	case BatchActionDelete:
+		needsID = true
+	default:
+		return append(
+			fields,
+			FieldError{Name: prefix + "action", Reason: "is unknown"},
+		)
+	}
+
+	if needsID && operation.ID <= 0 {
+		fields = append(
+			fields,
+			FieldError{Name: prefix + "id", Reason: "is not positive"},
+		)
+	}
+	if operation.Version < 0 {
+		fields = append(
+			fields,
+			FieldError{Name: prefix + "version", Reason: "is negative"},
+		)
+	}
+	if needsTodo && operation.Todo == nil {
+		fields = append(
+			fields,
+			FieldError{Name: prefix + "todo", Reason: "is required"},
+		)
+	}
+	if needsPatch && operation.Patch == nil {
+		fields = append(
+			fields,
+			FieldError{Name: prefix + "patch", Reason: "is required"},
+		)
+	}
+
+	return fields
+}
@@ -0,0 +1,454 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationLimits_ValidatePresentationTodoRecord(t *testing.T) {
+	type args struct {
+		presentationTodo PresentationTodoRecord
+	}
+
+	tests := []struct {
+		name    string
+		limits  ValidationLimits
+		args    args
+		wantErr error
+	}{
+		{
+			name:   "success",
+			limits: ValidationLimits{MaximalTitleLength: 4, MaximalOrder: 42},
+			args: args{
+				presentationTodo: PresentationTodoRecord{
+					Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					Title:     "test",
+					Completed: true,
+					Order:     42,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "error with all fields",
+			limits: ValidationLimits{MaximalTitleLength: 4, MaximalOrder: 42},
+			args: args{
+				presentationTodo: PresentationTodoRecord{
+					Title:     " \t",
+					Completed: true,
+					Order:     -1,
+					ListID:    -1,
+					ParentID:  -1,
+				},
+			},
+			wantErr: ValidationError{
+				Fields: []FieldError{
+					{Name: "date", Reason: "is required"},
+					{Name: "title", Reason: "is required"},
+					{Name: "order", Reason: "is out of the range [0, 42]"},
+					{Name: "list_id", Reason: "is negative"},
+					{Name: "parent_id", Reason: "is negative"},
+				},
+			},
+		},
+		{
+			name:   "error with a long title",
+			limits: ValidationLimits{MaximalTitleLength: 4, MaximalOrder: 42},
+			args: args{
+				presentationTodo: PresentationTodoRecord{
+					Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					Title:     strings.Repeat("ы", 5),
+					Completed: true,
+					Order:     42,
+				},
+			},
+			wantErr: ValidationError{
+				Fields: []FieldError{
+					{Name: "title", Reason: "is longer than 4 characters"},
+				},
+			},
+		},
+		{
+			name:   "error with an incorrect priority",
+			limits: ValidationLimits{MaximalTitleLength: 4, MaximalOrder: 42},
+			args: args{
+				presentationTodo: PresentationTodoRecord{
+					Date:     utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					Title:    "test",
+					Priority: PriorityUrgent + 1,
+				},
+			},
+			wantErr: ValidationError{
+				Fields: []FieldError{
+					{Name: "priority", Reason: "is out of the range [0, 4]"},
+				},
+			},
+		},
+		{
+			name: "error with long notes",
+			limits: ValidationLimits{
+				MaximalTitleLength: 4,
+				MaximalNotesLength: 4,
+				MaximalOrder:       42,
+			},
+			args: args{
+				presentationTodo: PresentationTodoRecord{
+					Date:  utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					Title: "test",
+					Notes: strings.Repeat("ы", 5),
+				},
+			},
+			wantErr: ValidationError{
+				Fields: []FieldError{
+					{Name: "notes", Reason: "is longer than 4 characters"},
+				},
+			},
+		},
+		{
+			name:   "success with a recurrence",
+			limits: ValidationLimits{MaximalTitleLength: 4, MaximalOrder: 42},
+			args: args{
+				presentationTodo: PresentationTodoRecord{
+					Date:       utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					Title:      "test",
+					Recurrence: "FREQ=WEEKLY;BYDAY=MO",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "error with an incorrect recurrence",
+			limits: ValidationLimits{MaximalTitleLength: 4, MaximalOrder: 42},
+			args: args{
+				presentationTodo: PresentationTodoRecord{
+					Date:       utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					Title:      "test",
+					Recurrence: "FREQ=YEARLY",
+				},
+			},
+			wantErr: ValidationError{
+				Fields: []FieldError{
+					{
+						Name:   "recurrence",
+						Reason: `is incorrect: incorrect part "FREQ": unsupported value`,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.ValidatePresentationTodoRecord(tt.args.presentationTodo)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestValidationLimits_ValidateTodoRecordPatch(t *testing.T) {
+	type args struct {
+		todoPatch TodoRecordPatch
+	}
+
+	tests := []struct {
+		name    string
+		limits  ValidationLimits
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "success with an empty patch",
+			limits:  ValidationLimits{MaximalTitleLength: 4, MaximalOrder: 42},
+			args:    args{todoPatch: TodoRecordPatch{}},
+			wantErr: nil,
+		},
+		{
+			name:   "success with all fields",
+			limits: ValidationLimits{MaximalTitleLength: 4, MaximalOrder: 42},
+			args: args{
+				todoPatch: TodoRecordPatch{
+					Date: func() *utilmodels.Date {
+						date := utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC))
+						return &date
+					}(),
+					Title: func() *string {
+						title := "test"
+						return &title
+					}(),
+					Order: func() *int {
+						order := 42
+						return &order
+					}(),
+					Priority: func() *int {
+						priority := PriorityUrgent
+						return &priority
+					}(),
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "error with all fields",
+			limits: ValidationLimits{MaximalTitleLength: 4, MaximalOrder: 42},
+			args: args{
+				todoPatch: TodoRecordPatch{
+					Date: func() *utilmodels.Date {
+						date := utilmodels.Date{}
+						return &date
+					}(),
+					Title: func() *string {
+						title := "test2"
+						return &title
+					}(),
+					Order: func() *int {
+						order := 43
+						return &order
+					}(),
+					Priority: func() *int {
+						priority := -1
+						return &priority
+					}(),
+					ListID: func() *int {
+						listID := -1
+						return &listID
+					}(),
+					ParentID: func() *int {
+						parentID := -1
+						return &parentID
+					}(),
+					Recurrence: func() *string {
+						recurrence := "FREQ=DAILY;COUNT=0"
+						return &recurrence
+					}(),
+				},
+			},
+			wantErr: ValidationError{
+				Fields: []FieldError{
+					{Name: "date", Reason: "is required"},
+					{Name: "title", Reason: "is longer than 4 characters"},
+					{Name: "order", Reason: "is out of the range [0, 42]"},
+					{Name: "priority", Reason: "is out of the range [0, 4]"},
+					{Name: "list_id", Reason: "is negative"},
+					{Name: "parent_id", Reason: "is negative"},
+					{
+						Name:   "recurrence",
+						Reason: `is incorrect: incorrect part "COUNT": value is not positive`,
+					},
+				},
+			},
+		},
+		{
+			name: "error with long notes",
+			limits: ValidationLimits{
+				MaximalTitleLength: 4,
+				MaximalNotesLength: 4,
+				MaximalOrder:       42,
+			},
+			args: args{
+				todoPatch: TodoRecordPatch{
+					Notes: func() *string {
+						notes := strings.Repeat("ы", 5)
+						return &notes
+					}(),
+				},
+			},
+			wantErr: ValidationError{
+				Fields: []FieldError{
+					{Name: "notes", Reason: "is longer than 4 characters"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.ValidateTodoRecordPatch(tt.args.todoPatch)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestValidationLimits_ValidateBatch(t *testing.T) {
+	type args struct {
+		batch Batch
+	}
+
+	tests := []struct {
+		name    string
+		limits  ValidationLimits
+		args    args
+		wantErr error
+	}{
+		{
+			name:   "success",
+			limits: ValidationLimits{MaximalBatchSize: 4},
+			args: args{
+				batch: Batch{
+					Mode: BatchModeBestEffort,
+					Operations: []BatchOperation{
+						{Action: BatchActionCreate, Todo: &PresentationTodoRecord{}},
+						{
+							Action:  BatchActionUpdate,
+							ID:      23,
+							Version: 2,
+							Todo:    &PresentationTodoRecord{},
+						},
+						{Action: BatchActionPatch, ID: 42, Patch: &TodoRecordPatch{}},
+						{Action: BatchActionDelete, ID: 12},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "error with an unknown mode and without operations",
+			limits: ValidationLimits{MaximalBatchSize: 4},
+			args:   args{batch: Batch{Mode: "unknown"}},
+			wantErr: ValidationError{
+				Fields: []FieldError{
+					{Name: "mode", Reason: "is unknown"},
+					{Name: "operations", Reason: "is required"},
+				},
+			},
+		},
+		{
+			name:   "error with too many operations",
+			limits: ValidationLimits{MaximalBatchSize: 1},
+			args: args{
+				batch: Batch{
+					Operations: []BatchOperation{
+						{Action: BatchActionDelete, ID: 12},
+						{Action: BatchActionDelete, ID: 23},
+					},
+				},
+			},
+			wantErr: ValidationError{
+				Fields: []FieldError{
+					{Name: "operations", Reason: "has more than 1 items"},
+				},
+			},
+		},
+		{
+			name:   "error with incorrect operations",
+			limits: ValidationLimits{MaximalBatchSize: 4},
+			args: args{
+				batch: Batch{
+					Operations: []BatchOperation{
+						{Action: "unknown"},
+						{Action: BatchActionUpdate, Version: -1},
+						{Action: BatchActionPatch, ID: 42},
+					},
+				},
+			},
+			wantErr: ValidationError{
+				Fields: []FieldError{
+					{Name: "operations[0].action", Reason: "is unknown"},
+					{Name: "operations[1].id", Reason: "is not positive"},
+					{Name: "operations[1].version", Reason: "is negative"},
+					{Name: "operations[1].todo", Reason: "is required"},
+					{Name: "operations[2].patch", Reason: "is required"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.ValidateBatch(tt.args.batch)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestValidationLimits_ValidateDependency(t *testing.T) {
+	type args struct {
+		dependency Dependency
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "success",
+			args:    args{dependency: Dependency{BlockerID: 23}},
+			wantErr: nil,
+		},
+		{
+			name: "error with a non-positive blocker ID",
+			args: args{dependency: Dependency{BlockerID: 0}},
+			wantErr: ValidationError{
+				Fields: []FieldError{{Name: "blocker_id", Reason: "is not positive"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DefaultValidationLimits.ValidateDependency(tt.args.dependency)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestValidationLimits_ValidatePresentationList(t *testing.T) {
+	type args struct {
+		presentationList PresentationList
+	}
+
+	tests := []struct {
+		name    string
+		limits  ValidationLimits
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "success",
+			limits:  ValidationLimits{MaximalTitleLength: 4},
+			args:    args{presentationList: PresentationList{Title: "test"}},
+			wantErr: nil,
+		},
+		{
+			name:   "error with an empty title",
+			limits: ValidationLimits{MaximalTitleLength: 4},
+			args:   args{presentationList: PresentationList{Title: " \t"}},
+			wantErr: ValidationError{
+				Fields: []FieldError{{Name: "title", Reason: "is required"}},
+			},
+		},
+		{
+			name:   "error with a long title",
+			limits: ValidationLimits{MaximalTitleLength: 4},
+			args:   args{presentationList: PresentationList{Title: "test2"}},
+			wantErr: ValidationError{
+				Fields: []FieldError{
+					{Name: "title", Reason: "is longer than 4 characters"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.ValidatePresentationList(tt.args.presentationList)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	err := ValidationError{
+		Fields: []FieldError{
+			{Name: "date", Reason: "is required"},
+			{Name: "title", Reason: "is required"},
+		},
+	}
+
+	assert.Equal(t, "invalid fields: date: is required; title: is required", err.Error())
+	assert.ErrorIs(t, err, ErrInvalid)
+}
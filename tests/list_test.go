@@ -0,0 +1,238 @@
+// +build integration
+
+package tests
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	httputils "github.com/irenicaa/go-http-utils"
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList_withModifying(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/lists", *port)
+	response, err := sendRequest(
+		http.MethodPost,
+		url,
+		models.PresentationList{Title: "test"},
+	)
+	require.NoError(t, err)
+
+	createdList, err := unmarshalList(response.Body)
+	require.NoError(t, err)
+
+	response, err = sendRequest(
+		http.MethodPut,
+		createdList.URL,
+		models.PresentationList{Title: "test2"},
+	)
+	require.NoError(t, err)
+	response.Body.Close()
+
+	response, err = sendRequest(http.MethodGet, createdList.URL, nil)
+	require.NoError(t, err)
+
+	gotList, err := unmarshalList(response.Body)
+	require.NoError(t, err)
+
+	createdList.Title = "test2"
+	assert.Equal(t, createdList, gotList)
+}
+
+func TestList_withDeleting(t *testing.T) {
+	tests := []struct {
+		name              string
+		deletionSuffix    string
+		wantStatus        int
+		wantHistoryAction string
+	}{
+		{
+			name:              "with moving to the inbox",
+			deletionSuffix:    "",
+			wantStatus:        http.StatusOK,
+			wantHistoryAction: models.HistoryActionPatch,
+		},
+		{
+			name:              "with the cascade",
+			deletionSuffix:    "?cascade=true",
+			wantStatus:        http.StatusNotFound,
+			wantHistoryAction: models.HistoryActionDelete,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := fmt.Sprintf("http://localhost:%d/api/v1/lists", *port)
+			response, err := sendRequest(
+				http.MethodPost,
+				url,
+				models.PresentationList{Title: "test"},
+			)
+			require.NoError(t, err)
+
+			createdList, err := unmarshalList(response.Body)
+			require.NoError(t, err)
+
+			var listID int
+			_, err = fmt.Sscanf(
+				createdList.URL,
+				fmt.Sprintf("http://localhost:%d/api/v1/lists/%%d", *port),
+				&listID,
+			)
+			require.NoError(t, err)
+
+			url = fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+			response, err = sendRequest(
+				http.MethodPost,
+				url,
+				models.PresentationTodoRecord{
+					Date: utilmodels.Date(time.Date(
+						2006, time.January, 2,
+						0, 0, 0, 0,
+						time.UTC,
+					)),
+					Title:  "test",
+					Order:  42,
+					ListID: listID,
+				},
+			)
+			require.NoError(t, err)
+
+			createdTodo, err := unmarshalTodoRecord(response.Body)
+			require.NoError(t, err)
+
+			response, err = sendRequest(http.MethodGet, createdList.TodosURL, nil)
+			require.NoError(t, err)
+			defer response.Body.Close()
+
+			var gotTodos []models.PresentationTodoRecord
+			err = httputils.ReadJSONData(response.Body, &gotTodos)
+			require.NoError(t, err)
+			assert.Equal(t, []models.PresentationTodoRecord{createdTodo}, gotTodos)
+
+			response, err = sendRequestWithHeader(
+				http.MethodDelete,
+				createdList.URL+tt.deletionSuffix,
+				nil,
+				"X-Actor",
+				"admin",
+			)
+			require.NoError(t, err)
+			response.Body.Close()
+			assert.Equal(t, http.StatusNoContent, response.StatusCode)
+
+			response, err = sendRequest(http.MethodGet, createdTodo.URL, nil)
+			require.NoError(t, err)
+			defer response.Body.Close()
+			require.Equal(t, tt.wantStatus, response.StatusCode)
+
+			if tt.wantStatus == http.StatusOK {
+				gotTodo, err := unmarshalTodoRecord(response.Body)
+				require.NoError(t, err)
+				assert.Equal(t, 0, gotTodo.ListID)
+			}
+
+			response, err = sendRequest(
+				http.MethodGet,
+				createdTodo.URL+"/history",
+				nil,
+			)
+			require.NoError(t, err)
+			defer response.Body.Close()
+
+			var gotRecords []models.PresentationHistoryRecord
+			err = httputils.ReadJSONData(response.Body, &gotRecords)
+			require.NoError(t, err)
+			require.Len(t, gotRecords, 2)
+			assert.Equal(t, tt.wantHistoryAction, gotRecords[1].Action)
+			assert.Equal(t, "admin", gotRecords[1].Actor)
+		})
+	}
+}
+
+func TestList_withChildrenInAnotherList(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/lists", *port)
+	response, err := sendRequest(
+		http.MethodPost,
+		url,
+		models.PresentationList{Title: "test"},
+	)
+	require.NoError(t, err)
+
+	createdList, err := unmarshalList(response.Body)
+	require.NoError(t, err)
+
+	var listID int
+	_, err = fmt.Sscanf(
+		createdList.URL,
+		fmt.Sprintf("http://localhost:%d/api/v1/lists/%%d", *port),
+		&listID,
+	)
+	require.NoError(t, err)
+
+	var parentID int
+	for _, listID := range []int{listID, 0} {
+		url = fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+		response, err = sendRequest(
+			http.MethodPost,
+			url,
+			models.PresentationTodoRecord{
+				Date: utilmodels.Date(time.Date(
+					2006, time.January, 2,
+					0, 0, 0, 0,
+					time.UTC,
+				)),
+				Title:    "test",
+				ListID:   listID,
+				ParentID: parentID,
+			},
+		)
+		require.NoError(t, err)
+
+		createdTodo, err := unmarshalTodoRecord(response.Body)
+		require.NoError(t, err)
+
+		_, err = fmt.Sscanf(
+			createdTodo.URL,
+			fmt.Sprintf("http://localhost:%d/api/v1/todos/%%d", *port),
+			&parentID,
+		)
+		require.NoError(t, err)
+	}
+
+	response, err = sendRequest(
+		http.MethodDelete,
+		createdList.URL+"?cascade=true",
+		nil,
+	)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var problem models.Problem
+	err = httputils.ReadJSONData(response.Body, &problem)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+	assert.Equal(t, "record_conflict", problem.Code)
+
+	response, err = sendRequest(http.MethodGet, createdList.URL, nil)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func unmarshalList(reader io.ReadCloser) (models.PresentationList, error) {
+	defer reader.Close()
+
+	var list models.PresentationList
+	if err := httputils.ReadJSONData(reader, &list); err != nil {
+		return models.PresentationList{}, err
+	}
+
+	return list, nil
+}
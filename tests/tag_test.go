@@ -0,0 +1,73 @@
+// +build integration
+
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	httputils "github.com/irenicaa/go-http-utils"
+	utilmodels "github.com/irenicaa/go-http-utils/models"
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTag_GetAll(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
+	require.NoError(t, err)
+
+	var createdTodos []models.PresentationTodoRecord
+	for i := 0; i <= 2; i++ {
+		originalTodo := models.PresentationTodoRecord{
+			Date: utilmodels.Date(time.Date(
+				2006, time.January, 2+i,
+				0, 0, 0, 0,
+				time.UTC,
+			)),
+			Title: "test" + strconv.Itoa(i),
+			Order: i,
+			Tags:  []string{"common", "tag" + strconv.Itoa(i)},
+		}
+
+		response, err := sendRequest(http.MethodPost, url, originalTodo)
+		require.NoError(t, err)
+
+		createdTodo, err := unmarshalTodoRecord(response.Body)
+		require.NoError(t, err)
+
+		createdTodos = append(createdTodos, createdTodo)
+	}
+
+	response, err := sendRequest(http.MethodGet, url+"?tags=tag0,tag2", nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var gotTodos []models.PresentationTodoRecord
+	err = httputils.ReadJSONData(response.Body, &gotTodos)
+	require.NoError(t, err)
+
+	wantTodos := []models.PresentationTodoRecord{createdTodos[2], createdTodos[0]}
+	assert.Equal(t, wantTodos, gotTodos)
+
+	tagsURL := fmt.Sprintf("http://localhost:%d/api/v1/tags", *port)
+	response, err = sendRequest(http.MethodGet, tagsURL, nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var gotTags []models.Tag
+	err = httputils.ReadJSONData(response.Body, &gotTags)
+	require.NoError(t, err)
+
+	wantTags := []models.Tag{
+		{Name: "common", Count: 3},
+		{Name: "tag0", Count: 1},
+		{Name: "tag1", Count: 1},
+		{Name: "tag2", Count: 1},
+	}
+	assert.Equal(t, wantTags, gotTags)
+}
@@ -8,10 +8,11 @@ import (
 	"flag"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,7 +27,7 @@ var port = flag.Int("port", 8080, "server port")
 
 func TestTodoRecord_withGetting(t *testing.T) {
 	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
-	_, err := sendRequest(http.MethodDelete, url, nil)
+	_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
 	require.NoError(t, err)
 
 	var createdTodos []models.PresentationTodoRecord
@@ -106,6 +107,7 @@ func TestTodoRecord_withQuerying(t *testing.T) {
 						Title:     "test" + strconv.Itoa(i),
 						Completed: true,
 						Order:     i,
+						Tags:      []string{},
 					}
 					wantTodos = append(wantTodos, wantTodo)
 				}
@@ -146,6 +148,7 @@ func TestTodoRecord_withQuerying(t *testing.T) {
 						Title:     "test" + strconv.Itoa(i),
 						Completed: true,
 						Order:     i,
+						Tags:      []string{},
 					}
 					wantTodos = append(wantTodos, wantTodo)
 				}
@@ -189,6 +192,7 @@ func TestTodoRecord_withQuerying(t *testing.T) {
 						Title:     "test" + strconv.Itoa(i),
 						Completed: true,
 						Order:     i,
+						Tags:      []string{},
 					}
 					wantTodos = append(wantTodos, wantTodo)
 				}
@@ -236,6 +240,82 @@ func TestTodoRecord_withQuerying(t *testing.T) {
 						Title:     fmt.Sprintf("test%d (even)", i),
 						Completed: true,
 						Order:     i,
+						Tags:      []string{},
+					}
+					wantTodos = append(wantTodos, wantTodo)
+				}
+
+				return wantTodos
+			}(),
+		},
+		{
+			name: "with the search",
+			originalTodos: func() []models.PresentationTodoRecord {
+				var originalTodos []models.PresentationTodoRecord
+				for i, title := range []string{"Buy milk", "Buy bread"} {
+					originalTodo := models.PresentationTodoRecord{
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title: title,
+						Order: i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			queryParameters: url.Values{"q": {"milk"}, "highlight": {"true"}},
+			wantTodos: []models.PresentationTodoRecord{
+				{
+					Date: utilmodels.Date(time.Date(
+						2006, time.January, 2,
+						0, 0, 0, 0,
+						time.UTC,
+					)),
+					Title:     "Buy milk",
+					Order:     0,
+					Tags:      []string{},
+					Highlight: "Buy <mark>milk</mark>",
+				},
+			},
+		},
+		{
+			name: "with sorting",
+			originalTodos: func() []models.PresentationTodoRecord {
+				var originalTodos []models.PresentationTodoRecord
+				for i := 0; i <= 5; i++ {
+					originalTodo := models.PresentationTodoRecord{
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: i%2 == 0,
+						Order:     i,
+					}
+					originalTodos = append(originalTodos, originalTodo)
+				}
+
+				return originalTodos
+			}(),
+			queryParameters: url.Values{"sort": {"completed,-title"}},
+			wantTodos: func() []models.PresentationTodoRecord {
+				var wantTodos []models.PresentationTodoRecord
+				for _, i := range []int{5, 3, 1, 4, 2, 0} {
+					wantTodo := models.PresentationTodoRecord{
+						Date: utilmodels.Date(time.Date(
+							2006, time.January, 2+i,
+							0, 0, 0, 0,
+							time.UTC,
+						)),
+						Title:     "test" + strconv.Itoa(i),
+						Completed: i%2 == 0,
+						Order:     i,
+						Tags:      []string{},
 					}
 					wantTodos = append(wantTodos, wantTodo)
 				}
@@ -276,6 +356,7 @@ func TestTodoRecord_withQuerying(t *testing.T) {
 						Title:     "test" + strconv.Itoa(i),
 						Completed: true,
 						Order:     i,
+						Tags:      []string{},
 					}
 					wantTodos = append(wantTodos, wantTodo)
 				}
@@ -329,6 +410,7 @@ func TestTodoRecord_withQuerying(t *testing.T) {
 						Title:     fmt.Sprintf("test%d (even)", i),
 						Completed: true,
 						Order:     i,
+						Tags:      []string{},
 					}
 					wantTodos = append(wantTodos, wantTodo)
 				}
@@ -340,7 +422,7 @@ func TestTodoRecord_withQuerying(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
-			_, err := sendRequest(http.MethodDelete, url, nil)
+			_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
 			require.NoError(t, err)
 
 			for _, originalTodo := range tt.originalTodos {
@@ -358,6 +440,7 @@ func TestTodoRecord_withQuerying(t *testing.T) {
 			require.NoError(t, err)
 			for index := range gotTodos {
 				gotTodos[index].URL = ""
+				resetTimestamps(&gotTodos[index])
 			}
 
 			assert.Equal(t, tt.wantTodos, gotTodos)
@@ -365,6 +448,87 @@ func TestTodoRecord_withQuerying(t *testing.T) {
 	}
 }
 
+func TestTodoRecord_withCursorPagination(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
+	require.NoError(t, err)
+
+	var createdTodos []models.PresentationTodoRecord
+	for i := 0; i <= 10; i++ {
+		originalTodo := models.PresentationTodoRecord{
+			Date: utilmodels.Date(time.Date(
+				2006, time.January, 2+i/2,
+				0, 0, 0, 0,
+				time.UTC,
+			)),
+			Title:     "test" + strconv.Itoa(i),
+			Completed: true,
+			Order:     i % 2,
+		}
+
+		response, err := sendRequest(http.MethodPost, url, originalTodo)
+		require.NoError(t, err)
+
+		createdTodo, err := unmarshalTodoRecord(response.Body)
+		require.NoError(t, err)
+
+		createdTodos = append(createdTodos, createdTodo)
+	}
+
+	response, err := sendRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var wantTodos []models.PresentationTodoRecord
+	err = httputils.ReadJSONData(response.Body, &wantTodos)
+	require.NoError(t, err)
+	require.Len(t, wantTodos, len(createdTodos))
+
+	var gotTodos []models.PresentationTodoRecord
+	var cursor string
+	for pageCount := 1; ; pageCount++ {
+		// the cursor is URL-safe, so it doesn't require escaping
+		queryURL := url + "?page_size=4"
+		if cursor != "" {
+			queryURL += "&cursor=" + cursor
+		}
+
+		response, err := sendRequest(http.MethodGet, queryURL, nil)
+		require.NoError(t, err)
+
+		var page models.PresentationTodoRecordPage
+		err = httputils.ReadJSONData(response.Body, &page)
+		response.Body.Close()
+		require.NoError(t, err)
+
+		gotTodos = append(gotTodos, page.Todos...)
+		if pageCount == 1 {
+			assert.Equal(t, "11", response.Header.Get("X-Total-Count"))
+		}
+
+		assert.Equal(t, response.Header.Get("X-Next-Cursor"), page.NextCursor)
+		cursor = page.NextCursor
+		if cursor == "" {
+			assert.Equal(t, 3, pageCount)
+			break
+		}
+
+		// the records inserted before the cursor shouldn't affect next pages
+		insertedTodo := models.PresentationTodoRecord{
+			Date: utilmodels.Date(time.Date(
+				2007, time.January, pageCount,
+				0, 0, 0, 0,
+				time.UTC,
+			)),
+			Title: "inserted" + strconv.Itoa(pageCount),
+		}
+		_, err = sendRequest(http.MethodPost, url, insertedTodo)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, wantTodos, gotTodos)
+}
+
 func TestTodoRecord_withModifying(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -394,6 +558,7 @@ func TestTodoRecord_withModifying(t *testing.T) {
 				Title:     "test",
 				Completed: true,
 				Order:     42,
+				Tags:      []string{},
 			},
 		},
 		{
@@ -432,6 +597,7 @@ func TestTodoRecord_withModifying(t *testing.T) {
 				Title:     "test2",
 				Completed: true,
 				Order:     42,
+				Tags:      []string{},
 			},
 		},
 		{
@@ -462,6 +628,7 @@ func TestTodoRecord_withModifying(t *testing.T) {
 				Title:     "test2",
 				Completed: true,
 				Order:     42,
+				Tags:      []string{},
 			},
 		},
 	}
@@ -482,6 +649,8 @@ func TestTodoRecord_withModifying(t *testing.T) {
 			gotTodo, err := unmarshalTodoRecord(response.Body)
 			require.NoError(t, err)
 
+			resetTimestamps(&gotTodo)
+
 			tt.wantTodo.URL = createdTodo.URL
 			assert.Equal(t, tt.wantTodo, gotTodo)
 		})
@@ -514,34 +683,1107 @@ func TestTodoRecord_withDeleting(t *testing.T) {
 	require.NoError(t, err)
 	defer response.Body.Close()
 
-	responseBytes, err := ioutil.ReadAll(response.Body)
+	var problem models.Problem
+	err = httputils.ReadJSONData(response.Body, &problem)
 	require.NoError(t, err)
 
-	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
 	assert.Equal(
 		t,
-		"unable to get the to-do record: sql: no rows in result set",
-		string(responseBytes),
+		"application/problem+json",
+		response.Header.Get("Content-Type"),
 	)
+	instance :=
+		strings.TrimPrefix(createdTodo.URL, "http://"+response.Request.Host)
+	assert.Equal(t, models.Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(http.StatusNotFound),
+		Status:   http.StatusNotFound,
+		Detail:   "unable to get the to-do record: not found",
+		Instance: instance,
+		Code:     "record_not_found",
+	}, problem)
 }
 
-func sendRequest(method string, url string, data interface{}) (
-	*http.Response,
-	error,
-) {
-	var body io.Reader
-	if data != nil {
-		dataAsJSON, err := json.Marshal(data)
-		if err != nil {
-			return nil, err
-		}
+func TestTodoRecord_withBatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          string
+		wantCommitted bool
+		wantStatuses  []int
+		wantTitles    []string
+	}{
+		{
+			name:          "all-or-nothing",
+			mode:          models.BatchModeAllOrNothing,
+			wantCommitted: false,
+			wantStatuses: []int{
+				http.StatusFailedDependency,
+				http.StatusFailedDependency,
+				http.StatusNotFound,
+			},
+			wantTitles: []string{"test"},
+		},
+		{
+			name:          "best-effort",
+			mode:          models.BatchModeBestEffort,
+			wantCommitted: true,
+			wantStatuses:  []int{http.StatusOK, http.StatusOK, http.StatusNotFound},
+			wantTitles:    []string{"test2", "test1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+			_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
+			require.NoError(t, err)
 
-		body = bytes.NewReader(dataAsJSON)
+			response, err := sendRequest(
+				http.MethodPost,
+				url,
+				models.PresentationTodoRecord{
+					Date: utilmodels.Date(time.Date(
+						2006, time.January, 2,
+						0, 0, 0, 0,
+						time.UTC,
+					)),
+					Title: "test",
+				},
+			)
+			require.NoError(t, err)
+
+			createdTodo, err := unmarshalTodoRecord(response.Body)
+			require.NoError(t, err)
+
+			createdTodoID, err := strconv.Atoi(path.Base(createdTodo.URL))
+			require.NoError(t, err)
+
+			todoPatchTitle := "test1"
+			response, err = sendRequest(http.MethodPost, url+"/batch", models.Batch{
+				Mode: tt.mode,
+				Operations: []models.BatchOperation{
+					{
+						Action: models.BatchActionCreate,
+						Todo: &models.PresentationTodoRecord{
+							Date: utilmodels.Date(time.Date(
+								2006, time.January, 3,
+								0, 0, 0, 0,
+								time.UTC,
+							)),
+							Title: "test2",
+						},
+					},
+					{
+						Action:  models.BatchActionPatch,
+						ID:      createdTodoID,
+						Version: createdTodo.Version,
+						Patch:   &models.TodoRecordPatch{Title: &todoPatchTitle},
+					},
+					{Action: models.BatchActionDelete, ID: createdTodoID + 1000},
+				},
+			})
+			require.NoError(t, err)
+			defer response.Body.Close()
+
+			var gotResults models.PresentationBatchResults
+			err = httputils.ReadJSONData(response.Body, &gotResults)
+			require.NoError(t, err)
+
+			var gotStatuses []int
+			for _, result := range gotResults.Results {
+				gotStatuses = append(gotStatuses, result.Status)
+			}
+
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.Equal(t, tt.wantCommitted, gotResults.Committed)
+			assert.Equal(t, tt.wantStatuses, gotStatuses)
+
+			response, err = sendRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			defer response.Body.Close()
+
+			var gotTodos []models.PresentationTodoRecord
+			err = httputils.ReadJSONData(response.Body, &gotTodos)
+			require.NoError(t, err)
+
+			var gotTitles []string
+			for _, todo := range gotTodos {
+				gotTitles = append(gotTitles, todo.Title)
+			}
+
+			assert.Equal(t, tt.wantTitles, gotTitles)
+		})
 	}
+}
 
-	request, err := http.NewRequest(method, url, body)
-	if err != nil {
-		return nil, err
+func TestTodoRecord_withDeletingByQuery(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
+	require.NoError(t, err)
+
+	for i := 0; i <= 3; i++ {
+		_, err := sendRequest(http.MethodPost, url, models.PresentationTodoRecord{
+			Date: utilmodels.Date(time.Date(
+				2006, time.January, 2+i,
+				0, 0, 0, 0,
+				time.UTC,
+			)),
+			Title:     "test" + strconv.Itoa(i),
+			Completed: i%2 == 0,
+		})
+		require.NoError(t, err)
+	}
+
+	response, err := sendRequest(http.MethodDelete, url, nil)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response, err = sendRequest(http.MethodDelete, url+"?completed=true", nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var deletionResult models.DeletionResult
+	err = httputils.ReadJSONData(response.Body, &deletionResult)
+	require.NoError(t, err)
+	assert.Equal(t, models.DeletionResult{DeletedCount: 2}, deletionResult)
+
+	response, err = sendRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var gotTodos []models.PresentationTodoRecord
+	err = httputils.ReadJSONData(response.Body, &gotTodos)
+	require.NoError(t, err)
+
+	var gotTitles []string
+	for _, todo := range gotTodos {
+		gotTitles = append(gotTitles, todo.Title)
+	}
+	assert.Equal(t, []string{"test3", "test1"}, gotTitles)
+}
+
+func TestTodoRecord_withTrash(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
+	require.NoError(t, err)
+
+	trashURL := fmt.Sprintf("http://localhost:%d/api/v1/trash", *port)
+	_, err = sendRequest(http.MethodDelete, trashURL, nil)
+	require.NoError(t, err)
+
+	response, err := sendRequest(http.MethodPost, url, models.PresentationTodoRecord{
+		Date: utilmodels.Date(time.Date(
+			2006, time.January, 2,
+			0, 0, 0, 0,
+			time.UTC,
+		)),
+		Title: "test",
+	})
+	require.NoError(t, err)
+
+	createdTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+
+	_, err = sendRequest(http.MethodDelete, createdTodo.URL, nil)
+	require.NoError(t, err)
+
+	response, err = sendRequest(http.MethodGet, trashURL, nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var gotTodos []models.PresentationTodoRecord
+	err = httputils.ReadJSONData(response.Body, &gotTodos)
+	require.NoError(t, err)
+	require.Len(t, gotTodos, 1)
+	assert.Equal(t, createdTodo.URL, gotTodos[0].URL)
+	assert.NotNil(t, gotTodos[0].DeletedAt)
+
+	id := strings.TrimPrefix(createdTodo.URL, url+"/")
+	response, err =
+		sendRequest(http.MethodPost, trashURL+"/"+id+"/restore", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	restoredTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, createdTodo.URL, restoredTodo.URL)
+	assert.Nil(t, restoredTodo.DeletedAt)
+
+	response, err = sendRequest(http.MethodGet, createdTodo.URL, nil)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	_, err = sendRequest(http.MethodDelete, createdTodo.URL, nil)
+	require.NoError(t, err)
+
+	response, err = sendRequest(http.MethodDelete, trashURL, nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var deletionResult models.DeletionResult
+	err = httputils.ReadJSONData(response.Body, &deletionResult)
+	require.NoError(t, err)
+	assert.Equal(t, models.DeletionResult{DeletedCount: 1}, deletionResult)
+
+	response, err =
+		sendRequest(http.MethodPost, trashURL+"/"+id+"/restore", nil)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestTodoRecord_withHistory(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	startedAt := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	response, err := sendRequest(http.MethodPost, url, models.PresentationTodoRecord{
+		Date: utilmodels.Date(time.Date(
+			2006, time.January, 2,
+			0, 0, 0, 0,
+			time.UTC,
+		)),
+		Title: "test",
+	})
+	require.NoError(t, err)
+
+	createdTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+
+	todoPatchTitle := "test #2"
+	response, err = sendRequestWithHeader(
+		http.MethodPatch,
+		createdTodo.URL,
+		models.TodoRecordPatch{Title: &todoPatchTitle},
+		"X-Actor",
+		"admin",
+	)
+	require.NoError(t, err)
+	response.Body.Close()
+
+	response, err = sendRequest(http.MethodGet, createdTodo.URL+"/history", nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var gotRecords []models.PresentationHistoryRecord
+	err = httputils.ReadJSONData(response.Body, &gotRecords)
+	require.NoError(t, err)
+	require.Len(t, gotRecords, 2)
+	assert.Equal(t, models.HistoryActionCreate, gotRecords[0].Action)
+	assert.Nil(t, gotRecords[0].OldValue)
+	assert.Equal(t, models.HistoryActionPatch, gotRecords[1].Action)
+	assert.Equal(t, "test", gotRecords[1].OldValue.Title)
+	assert.Equal(t, "test #2", gotRecords[1].NewValue.Title)
+	assert.Equal(t, "admin", gotRecords[1].Actor)
+
+	response, err = sendRequest(
+		http.MethodPost,
+		createdTodo.URL+"/revert?revision="+
+			strconv.Itoa(gotRecords[0].Revision),
+		nil,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	revertedTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "test", revertedTodo.Title)
+
+	historyURL := fmt.Sprintf("http://localhost:%d/api/v1/history", *port)
+	response, err = sendRequest(http.MethodGet, historyURL+"?since="+startedAt, nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var gotAllRecords []models.PresentationHistoryRecord
+	err = httputils.ReadJSONData(response.Body, &gotAllRecords)
+	require.NoError(t, err)
+	require.NotEmpty(t, gotAllRecords)
+
+	lastRecord := gotAllRecords[len(gotAllRecords)-1]
+	assert.Equal(t, createdTodo.URL, lastRecord.TodoURL)
+	assert.Equal(t, models.HistoryActionRevert, lastRecord.Action)
+	assert.Equal(t, "test #2", lastRecord.OldValue.Title)
+	assert.Equal(t, "test", lastRecord.NewValue.Title)
+
+	response, err =
+		sendRequest(http.MethodGet, createdTodo.URL+"/history?page_size=2", nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var gotPage models.PresentationHistoryRecordPage
+	err = httputils.ReadJSONData(response.Body, &gotPage)
+	require.NoError(t, err)
+	assert.Equal(t, gotRecords, gotPage.History)
+	require.NotEmpty(t, gotPage.NextCursor)
+	assert.Equal(t, gotPage.NextCursor, response.Header.Get("X-Next-Cursor"))
+
+	response, err = sendRequest(
+		http.MethodGet,
+		createdTodo.URL+"/history?page_size=2&cursor="+gotPage.NextCursor,
+		nil,
+	)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	gotPage = models.PresentationHistoryRecordPage{}
+	err = httputils.ReadJSONData(response.Body, &gotPage)
+	require.NoError(t, err)
+	require.Len(t, gotPage.History, 1)
+	assert.Equal(t, models.HistoryActionRevert, gotPage.History[0].Action)
+	assert.Empty(t, gotPage.NextCursor)
+	assert.Empty(t, response.Header.Get("X-Next-Cursor"))
+}
+
+func TestTodoRecord_withTimestamps(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
+	require.NoError(t, err)
+
+	var createdTodos []models.PresentationTodoRecord
+	for _, title := range []string{"test1", "test2"} {
+		response, err := sendRequest(http.MethodPost, url, models.PresentationTodoRecord{
+			Date: utilmodels.Date(time.Date(
+				2006, time.January, 2,
+				0, 0, 0, 0,
+				time.UTC,
+			)),
+			Title: title,
+		})
+		require.NoError(t, err)
+
+		createdTodo, err := unmarshalTodoRecord(response.Body)
+		require.NoError(t, err)
+		require.NotNil(t, createdTodo.CreatedAt)
+		require.NotNil(t, createdTodo.UpdatedAt)
+		assert.Nil(t, createdTodo.CompletedAt)
+
+		createdTodos = append(createdTodos, createdTodo)
+	}
+
+	completed := true
+	response, err := sendRequest(
+		http.MethodPatch,
+		createdTodos[1].URL,
+		models.TodoRecordPatch{Completed: &completed},
+	)
+	require.NoError(t, err)
+
+	patchedTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, createdTodos[1].CreatedAt, patchedTodo.CreatedAt)
+	require.NotNil(t, patchedTodo.CompletedAt)
+
+	completedAfter := patchedTodo.CompletedAt.UTC().Format(time.RFC3339Nano)
+	response, err = sendRequest(
+		http.MethodGet,
+		url+"?completed_after="+completedAfter+"&sort=-completed_at",
+		nil,
+	)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var gotTodos []models.PresentationTodoRecord
+	err = httputils.ReadJSONData(response.Body, &gotTodos)
+	require.NoError(t, err)
+	require.Len(t, gotTodos, 1)
+	assert.Equal(t, createdTodos[1].URL, gotTodos[0].URL)
+}
+
+func TestTodoRecord_withDueTime(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
+	require.NoError(t, err)
+
+	dueAt := time.Date(2006, time.January, 3, 8, 30, 0, 0, time.FixedZone("", 9*60*60))
+	response, err := sendRequest(http.MethodPost, url, models.PresentationTodoRecord{
+		Date: utilmodels.Date(time.Date(
+			2006, time.January, 2,
+			0, 0, 0, 0,
+			time.UTC,
+		)),
+		DueAt: &dueAt,
+		Title: "test1",
+	})
+	require.NoError(t, err)
+
+	dueTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	require.NotNil(t, dueTodo.DueAt)
+	assert.Equal(t, dueAt.UTC(), *dueTodo.DueAt)
+
+	response, err = sendRequest(http.MethodPost, url, models.PresentationTodoRecord{
+		Date: utilmodels.Date(time.Date(
+			2006, time.January, 3,
+			0, 0, 0, 0,
+			time.UTC,
+		)),
+		Title: "test2",
+	})
+	require.NoError(t, err)
+
+	dateTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+
+	getURLs := func(url string) []string {
+		response, err := sendRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		var gotTodos []models.PresentationTodoRecord
+		err = httputils.ReadJSONData(response.Body, &gotTodos)
+		require.NoError(t, err)
+
+		var gotURLs []string
+		for _, todo := range gotTodos {
+			gotURLs = append(gotURLs, todo.URL)
+		}
+
+		return gotURLs
+	}
+
+	assert.ElementsMatch(
+		t,
+		[]string{dueTodo.URL, dateTodo.URL},
+		getURLs(url+"/2006-01-03?tz=Asia/Tokyo"),
+	)
+	assert.Equal(t, []string{dueTodo.URL}, getURLs(url+"/2006-01-02"))
+	assert.Equal(
+		t,
+		[]string{dueTodo.URL},
+		getURLs(url+"?due_before=2006-01-03T00:00:00Z"),
+	)
+
+	response, err = sendRequest(
+		http.MethodPatch,
+		dueTodo.URL,
+		models.TodoRecordPatch{DueAt: models.NullableTimestamp{IsSet: true}},
+	)
+	require.NoError(t, err)
+
+	patchedTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.Nil(t, patchedTodo.DueAt)
+}
+
+func TestTodoRecord_withRecurrence(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
+	require.NoError(t, err)
+
+	response, err := sendRequest(http.MethodPost, url, models.PresentationTodoRecord{
+		Date: utilmodels.Date(time.Date(
+			2006, time.January, 2,
+			0, 0, 0, 0,
+			time.UTC,
+		)),
+		Title:      "test",
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=3",
+	})
+	require.NoError(t, err)
+
+	createdTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+
+	response, err = sendRequest(
+		http.MethodGet,
+		createdTodo.URL+"/occurrences?minimal_date=2006-01-01&maximal_date=2006-12-31",
+		nil,
+	)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var gotOccurrences []models.PresentationTodoRecord
+	err = httputils.ReadJSONData(response.Body, &gotOccurrences)
+	require.NoError(t, err)
+
+	var gotDates []string
+	for _, occurrence := range gotOccurrences {
+		gotDates = append(gotDates, time.Time(occurrence.Date).Format("2006-01-02"))
+	}
+	assert.Equal(t, []string{"2006-01-02", "2006-01-06", "2006-01-09"}, gotDates)
+
+	completed := true
+	response, err = sendRequest(
+		http.MethodPatch,
+		createdTodo.URL,
+		models.TodoRecordPatch{Completed: &completed},
+	)
+	require.NoError(t, err)
+
+	completedTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.True(t, completedTodo.Completed)
+	assert.Empty(t, completedTodo.Recurrence)
+
+	response, err = sendRequest(http.MethodGet, url+"?completed=false", nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var gotTodos []models.PresentationTodoRecord
+	err = httputils.ReadJSONData(response.Body, &gotTodos)
+	require.NoError(t, err)
+	require.Len(t, gotTodos, 1)
+	assert.Equal(
+		t,
+		utilmodels.Date(time.Date(2006, time.January, 6, 0, 0, 0, 0, time.UTC)),
+		gotTodos[0].Date,
+	)
+	assert.Equal(t, "test", gotTodos[0].Title)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=2", gotTodos[0].Recurrence)
+}
+
+func TestTodoRecord_withChildren(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
+	require.NoError(t, err)
+
+	var createdTodos []models.PresentationTodoRecord
+	var parentID int
+	for _, title := range []string{"parent", "child", "grandchild"} {
+		response, err := sendRequest(http.MethodPost, url, models.PresentationTodoRecord{
+			Date: utilmodels.Date(time.Date(
+				2006, time.January, 2,
+				0, 0, 0, 0,
+				time.UTC,
+			)),
+			Title:    title,
+			ParentID: parentID,
+		})
+		require.NoError(t, err)
+
+		createdTodo, err := unmarshalTodoRecord(response.Body)
+		require.NoError(t, err)
+		createdTodos = append(createdTodos, createdTodo)
+
+		parentID, err = strconv.Atoi(path.Base(createdTodo.URL))
+		require.NoError(t, err)
+	}
+
+	parentTodo, grandchildTodo := createdTodos[0], createdTodos[2]
+	response, err := sendRequest(
+		http.MethodGet,
+		parentTodo.URL+"?include=children",
+		nil,
+	)
+	require.NoError(t, err)
+
+	gotTree, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gotTree.ChildCount)
+	require.Len(t, gotTree.Children, 1)
+	assert.Equal(t, "child", gotTree.Children[0].Title)
+	require.Len(t, gotTree.Children[0].Children, 1)
+	assert.Equal(t, "grandchild", gotTree.Children[0].Children[0].Title)
+
+	response, err = sendRequest(http.MethodGet, parentTodo.URL+"/children", nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var gotChildren []models.PresentationTodoRecord
+	err = httputils.ReadJSONData(response.Body, &gotChildren)
+	require.NoError(t, err)
+	require.Len(t, gotChildren, 1)
+	assert.Equal(t, "child", gotChildren[0].Title)
+
+	// the grandchild can't be the parent of its ancestor
+	response, err = sendRequest(
+		http.MethodPatch,
+		parentTodo.URL,
+		models.TodoRecordPatch{ParentID: &parentID},
+	)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	response, err = sendRequest(http.MethodDelete, parentTodo.URL, nil)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	completed := true
+	response, err = sendRequest(
+		http.MethodPatch,
+		parentTodo.URL+"?cascade=true",
+		models.TodoRecordPatch{Completed: &completed},
+	)
+	require.NoError(t, err)
+
+	completedTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.True(t, completedTodo.Completed)
+	require.NotNil(t, completedTodo.ChildrenCompletion)
+	assert.Equal(t, 1.0, *completedTodo.ChildrenCompletion)
+
+	response, err = sendRequest(http.MethodGet, grandchildTodo.URL, nil)
+	require.NoError(t, err)
+
+	gotTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.True(t, gotTodo.Completed)
+
+	response, err = sendRequest(
+		http.MethodDelete,
+		parentTodo.URL+"?cascade=true",
+		nil,
+	)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+
+	response, err = sendRequest(http.MethodGet, grandchildTodo.URL, nil)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestTodoRecord_withDependencies(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
+	require.NoError(t, err)
+
+	var createdTodos []models.PresentationTodoRecord
+	var ids []int
+	for _, title := range []string{"blocked", "blocker"} {
+		response, err := sendRequest(http.MethodPost, url, models.PresentationTodoRecord{
+			Date: utilmodels.Date(time.Date(
+				2006, time.January, 2,
+				0, 0, 0, 0,
+				time.UTC,
+			)),
+			Title: title,
+		})
+		require.NoError(t, err)
+
+		createdTodo, err := unmarshalTodoRecord(response.Body)
+		require.NoError(t, err)
+		createdTodos = append(createdTodos, createdTodo)
+
+		id, err := strconv.Atoi(path.Base(createdTodo.URL))
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	blockedTodo, blockerTodo := createdTodos[0], createdTodos[1]
+	response, err := sendRequest(
+		http.MethodPost,
+		blockedTodo.URL+"/blockers",
+		models.Dependency{BlockerID: ids[1]},
+	)
+	require.NoError(t, err)
+
+	gotTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, []int{ids[1]}, gotTodo.BlockedBy)
+	assert.True(t, gotTodo.Blocked)
+
+	// the blocker can't be blocked by the to-do record it blocks
+	response, err = sendRequest(
+		http.MethodPost,
+		blockerTodo.URL+"/blockers",
+		models.Dependency{BlockerID: ids[0]},
+	)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	response, err = sendRequest(http.MethodGet, url+"?blocked=true", nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var gotTodos []models.PresentationTodoRecord
+	err = httputils.ReadJSONData(response.Body, &gotTodos)
+	require.NoError(t, err)
+	require.Len(t, gotTodos, 1)
+	assert.Equal(t, "blocked", gotTodos[0].Title)
+
+	completed := true
+	response, err = sendRequest(
+		http.MethodPatch,
+		blockedTodo.URL,
+		models.TodoRecordPatch{Completed: &completed},
+	)
+	require.NoError(t, err)
+
+	var problem models.Problem
+	err = httputils.ReadJSONData(response.Body, &problem)
+	response.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+	assert.Equal(t, "record_blocked", problem.Code)
+
+	response, err = sendRequest(
+		http.MethodPatch,
+		blockedTodo.URL+"?force=true",
+		models.TodoRecordPatch{Completed: &completed},
+	)
+	require.NoError(t, err)
+
+	gotTodo, err = unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.True(t, gotTodo.Completed)
+
+	blockerURL := blockedTodo.URL + "/blockers/" + strconv.Itoa(ids[1])
+	response, err = sendRequest(http.MethodDelete, blockerURL, nil)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+
+	response, err = sendRequest(http.MethodDelete, blockerURL, nil)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestTodoRecord_withNotes(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
+	require.NoError(t, err)
+
+	response, err := sendRequest(http.MethodPost, url, models.PresentationTodoRecord{
+		Date: utilmodels.Date(time.Date(
+			2006, time.January, 2,
+			0, 0, 0, 0,
+			time.UTC,
+		)),
+		Title: "go shopping",
+		Notes: "buy **milk** <script>alert(1)</script>",
+	})
+	require.NoError(t, err)
+
+	createdTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "buy **milk** <script>alert(1)</script>", createdTodo.Notes)
+	assert.Empty(t, createdTodo.NotesHTML)
+
+	for _, data := range []struct {
+		query     string
+		wantCount int
+	}{
+		{query: "?title_fragment=milk", wantCount: 0},
+		{query: "?title_fragment=milk&search_notes=true", wantCount: 1},
+		{query: "?q=milk", wantCount: 0},
+		{query: "?q=milk&search_notes=true", wantCount: 1},
+	} {
+		response, err := sendRequest(http.MethodGet, url+data.query, nil)
+		require.NoError(t, err)
+
+		var gotTodos []models.PresentationTodoRecord
+		err = httputils.ReadJSONData(response.Body, &gotTodos)
+		response.Body.Close()
+		require.NoError(t, err)
+		assert.Len(t, gotTodos, data.wantCount, data.query)
+	}
+
+	response, err = sendRequest(http.MethodGet, createdTodo.URL+"?render=html", nil)
+	require.NoError(t, err)
+
+	gotTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "buy **milk** <script>alert(1)</script>", gotTodo.Notes)
+	assert.Equal(t, "<p>buy <strong>milk</strong> alert(1)</p>\n", gotTodo.NotesHTML)
+}
+
+func TestTodoRecord_withPriority(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	_, err := sendRequest(http.MethodDelete, url+"?confirm=all", nil)
+	require.NoError(t, err)
+
+	var createdTodos []models.PresentationTodoRecord
+	for i, priority := range []int{
+		models.PriorityNone,
+		models.PriorityUrgent,
+		models.PriorityLow,
+	} {
+		response, err :=
+			sendRequest(http.MethodPost, url, models.PresentationTodoRecord{
+				Date: utilmodels.Date(time.Date(
+					2006, time.January, 2+i,
+					0, 0, 0, 0,
+					time.UTC,
+				)),
+				Title:    "test" + strconv.Itoa(i),
+				Priority: priority,
+			})
+		require.NoError(t, err)
+
+		createdTodo, err := unmarshalTodoRecord(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, priority, createdTodo.Priority)
+
+		createdTodos = append(createdTodos, createdTodo)
+	}
+
+	priority := models.PriorityHigh
+	response, err := sendRequest(
+		http.MethodPatch,
+		createdTodos[2].URL,
+		models.TodoRecordPatch{Priority: &priority},
+	)
+	require.NoError(t, err)
+
+	patchedTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, models.PriorityHigh, patchedTodo.Priority)
+
+	for _, data := range []struct {
+		query      string
+		wantTitles []string
+	}{
+		{query: "", wantTitles: []string{"test2", "test1", "test0"}},
+		{query: "?sort=-priority", wantTitles: []string{"test1", "test2", "test0"}},
+		{query: "?min_priority=3", wantTitles: []string{"test2", "test1"}},
+		{query: "?sort=priority", wantTitles: []string{"test0", "test2", "test1"}},
+	} {
+		response, err := sendRequest(http.MethodGet, url+data.query, nil)
+		require.NoError(t, err)
+
+		var gotTodos []models.PresentationTodoRecord
+		err = httputils.ReadJSONData(response.Body, &gotTodos)
+		response.Body.Close()
+		require.NoError(t, err)
+
+		var gotTitles []string
+		for _, gotTodo := range gotTodos {
+			gotTitles = append(gotTitles, gotTodo.Title)
+		}
+		assert.Equal(t, data.wantTitles, gotTitles, data.query)
+	}
+}
+
+func TestTodoRecord_withMissingRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		data   interface{}
+	}{
+		{
+			name:   "getting",
+			method: http.MethodGet,
+			data:   nil,
+		},
+		{
+			name:   "updating",
+			method: http.MethodPut,
+			data: models.PresentationTodoRecord{
+				Date: utilmodels.Date(time.Date(
+					2006, time.January, 2,
+					0, 0, 0, 0,
+					time.UTC,
+				)),
+				Title:     "test",
+				Completed: true,
+				Order:     42,
+			},
+		},
+		{
+			name:   "patching",
+			method: http.MethodPatch,
+			data: func() models.TodoRecordPatch {
+				todoPatchTitle := "test2"
+				return models.TodoRecordPatch{Title: &todoPatchTitle}
+			}(),
+		},
+		{
+			name:   "deleting",
+			method: http.MethodDelete,
+			data:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalTodo := models.PresentationTodoRecord{
+				Date: utilmodels.Date(time.Date(
+					2006, time.January, 2,
+					0, 0, 0, 0,
+					time.UTC,
+				)),
+				Title:     "test",
+				Completed: true,
+				Order:     42,
+			}
+
+			url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+			response, err := sendRequest(http.MethodPost, url, originalTodo)
+			require.NoError(t, err)
+
+			createdTodo, err := unmarshalTodoRecord(response.Body)
+			require.NoError(t, err)
+
+			_, err = sendRequest(http.MethodDelete, createdTodo.URL, nil)
+			require.NoError(t, err)
+
+			response, err = sendRequest(tt.method, createdTodo.URL, tt.data)
+			require.NoError(t, err)
+			defer response.Body.Close()
+
+			assert.Equal(t, http.StatusNotFound, response.StatusCode)
+		})
+	}
+}
+
+func TestTodoRecord_withValidation(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	response, err := sendRequest(http.MethodPost, url, models.PresentationTodoRecord{
+		Title:     "",
+		Completed: true,
+		Order:     -1,
+	})
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var problem models.Problem
+	err = httputils.ReadJSONData(response.Body, &problem)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, response.StatusCode)
+	assert.Equal(t, "validation_failed", problem.Code)
+	assert.Equal(t, []models.FieldError{
+		{Name: "date", Reason: "is required"},
+		{Name: "title", Reason: "is required"},
+		{Name: "order", Reason: "is out of the range [0, 2147483647]"},
+	}, problem.InvalidParams)
+}
+
+func TestTodoRecord_withVersioning(t *testing.T) {
+	originalTodo := models.PresentationTodoRecord{
+		Date: utilmodels.Date(time.Date(
+			2006, time.January, 2,
+			0, 0, 0, 0,
+			time.UTC,
+		)),
+		Title:     "test",
+		Completed: true,
+		Order:     42,
+	}
+
+	url := fmt.Sprintf("http://localhost:%d/api/v1/todos", *port)
+	response, err := sendRequest(http.MethodPost, url, originalTodo)
+	require.NoError(t, err)
+	etag := response.Header.Get("ETag")
+
+	createdTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+
+	response, err = sendRequestWithHeader(
+		http.MethodGet,
+		createdTodo.URL,
+		nil,
+		"If-None-Match",
+		etag,
+	)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNotModified, response.StatusCode)
+
+	todoPatchTitle := "test2"
+	response, err = sendRequestWithHeader(
+		http.MethodPatch,
+		createdTodo.URL,
+		models.TodoRecordPatch{Title: &todoPatchTitle},
+		"If-Match",
+		etag,
+	)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.NotEqual(t, etag, response.Header.Get("ETag"))
+
+	response, err = sendRequestWithHeader(
+		http.MethodDelete,
+		createdTodo.URL,
+		nil,
+		"If-Match",
+		etag,
+	)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusPreconditionFailed, response.StatusCode)
+
+	response, err = sendRequest(http.MethodGet, createdTodo.URL, nil)
+	require.NoError(t, err)
+
+	etag = response.Header.Get("ETag")
+
+	gotTodo, err := unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, todoPatchTitle, gotTodo.Title)
+
+	// the child count changes without the version increment of the parent
+	parentID, err := strconv.Atoi(path.Base(createdTodo.URL))
+	require.NoError(t, err)
+
+	childTodo := originalTodo
+	childTodo.ParentID = parentID
+	_, err = sendRequest(http.MethodPost, url, childTodo)
+	require.NoError(t, err)
+
+	response, err = sendRequestWithHeader(
+		http.MethodGet,
+		createdTodo.URL,
+		nil,
+		"If-None-Match",
+		etag,
+	)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.NotEqual(t, etag, response.Header.Get("ETag"))
+
+	gotTodo, err = unmarshalTodoRecord(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gotTodo.ChildCount)
+
+	response, err = sendRequestWithHeader(
+		http.MethodPatch,
+		createdTodo.URL,
+		models.TodoRecordPatch{Title: &todoPatchTitle},
+		"If-Match",
+		etag,
+	)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusPreconditionFailed, response.StatusCode)
+}
+
+// resetTimestamps clears the timestamps maintained by the storage;
+// they are checked by the TestTodoRecord_withTimestamps() test.
+func resetTimestamps(todo *models.PresentationTodoRecord) {
+	todo.CreatedAt = nil
+	todo.UpdatedAt = nil
+	todo.CompletedAt = nil
+}
+
+func sendRequest(method string, url string, data interface{}) (
+	*http.Response,
+	error,
+) {
+	return sendRequestWithHeader(method, url, data, "", "")
+}
+
+func sendRequestWithHeader(
+	method string,
+	url string,
+	data interface{},
+	headerName string,
+	headerValue string,
+) (*http.Response, error) {
+	var body io.Reader
+	if data != nil {
+		dataAsJSON, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		body = bytes.NewReader(dataAsJSON)
+	}
+
+	request, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if headerName != "" {
+		request.Header.Set(headerName, headerValue)
 	}
 
 	response, err := http.DefaultClient.Do(request)
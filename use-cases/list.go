@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+)
+
+// ListStorage ...
+type ListStorage interface {
+	GetAll() ([]models.List, error)
+	GetSingle(id int) (models.List, error)
+	Create(list models.List) (models.List, error)
+	Update(id int, list models.List) (models.List, error)
+	// DeleteSingle calls the handler in the same transaction before
+	// the deletion, so it can handle the to-do records of the list; the trashed
+	// ones are left to the storage, which moves them to the inbox.
+	DeleteSingle(
+		id int,
+		handler func(todoRecordStorage TodoRecordStorage) error,
+	) error
+}
+
+// List ...
+//
+// The deletion of a list handles its to-do records by the TodoRecord use case,
+// so their changes are recorded to the history.
+type List struct {
+	Storage    ListStorage
+	TodoRecord TodoRecord
+	Limits     models.ValidationLimits
+}
+
+// GetAll ...
+func (useCase List) GetAll(baseURL *url.URL) ([]models.PresentationList, error) {
+	lists, err := useCase.Storage.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the lists: %w", err)
+	}
+
+	// force the empty array instead of the nil one
+	presentationLists := []models.PresentationList{}
+	for _, list := range lists {
+		presentationList := models.NewPresentationList(baseURL, list)
+		presentationLists = append(presentationLists, presentationList)
+	}
+
+	return presentationLists, nil
+}
+
+// GetSingle ...
+func (useCase List) GetSingle(baseURL *url.URL, id int) (
+	models.PresentationList,
+	error,
+) {
+	list, err := useCase.Storage.GetSingle(id)
+	if err != nil {
+		return models.PresentationList{},
+			fmt.Errorf("unable to get the list: %w", err)
+	}
+
+	return models.NewPresentationList(baseURL, list), nil
+}
+
+// Create ...
+func (useCase List) Create(
+	baseURL *url.URL,
+	presentationList models.PresentationList,
+) (
+	models.PresentationList,
+	error,
+) {
+	err := useCase.Limits.ValidatePresentationList(presentationList)
+	if err != nil {
+		return models.PresentationList{},
+			fmt.Errorf("unable to validate the list: %w", err)
+	}
+
+	list, err := useCase.Storage.Create(models.NewList(presentationList))
+	if err != nil {
+		return models.PresentationList{},
+			fmt.Errorf("unable to create a list: %w", err)
+	}
+
+	return models.NewPresentationList(baseURL, list), nil
+}
+
+// Update ...
+func (useCase List) Update(
+	baseURL *url.URL,
+	id int,
+	presentationList models.PresentationList,
+) (
+	models.PresentationList,
+	error,
+) {
+	err := useCase.Limits.ValidatePresentationList(presentationList)
+	if err != nil {
+		return models.PresentationList{},
+			fmt.Errorf("unable to validate the list: %w", err)
+	}
+
+	list, err := useCase.Storage.Update(id, models.NewList(presentationList))
+	if err != nil {
+		return models.PresentationList{},
+			fmt.Errorf("unable to update the list: %w", err)
+	}
+
+	return models.NewPresentationList(baseURL, list), nil
+}
+
+// DeleteSingle moves the to-do records of the list to the trash
+// if the cascade flag is set; otherwise, it moves them to the inbox.
+//
+// The deletion is refused with the models.ErrConflict error
+// if a to-do record of the list has children in another list.
+func (useCase List) DeleteSingle(id int, cascade bool, actor string) error {
+	err := useCase.Storage.DeleteSingle(
+		id,
+		func(todoRecordStorage TodoRecordStorage) error {
+			todoRecordUseCase := useCase.TodoRecord
+			todoRecordUseCase.Storage = todoRecordStorage
+
+			query := models.Query{ListID: id}
+			if cascade {
+				_, err := todoRecordUseCase.DeleteAll(query, actor)
+				return err
+			}
+
+			return todoRecordUseCase.moveToInbox(query, actor)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to delete the list: %w", err)
+	}
+
+	return nil
+}
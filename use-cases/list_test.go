@@ -0,0 +1,515 @@
+package usecases
+
+import (
+	"net/url"
+	"testing"
+	"testing/iotest"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList_GetAll(t *testing.T) {
+	type fields struct {
+		Storage ListStorage
+	}
+	type args struct {
+		baseURL *url.URL
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []models.PresentationList
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success without lists",
+			fields: fields{
+				Storage: func() ListStorage {
+					storage := &MockListStorage{}
+					storage.InnerMock.On("GetAll").Return([]models.List(nil), nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+			},
+			want:    []models.PresentationList{},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with lists",
+			fields: fields{
+				Storage: func() ListStorage {
+					lists := []models.List{
+						{ID: 5, Title: "test"},
+						{ID: 23, Title: "test2"},
+					}
+
+					storage := &MockListStorage{}
+					storage.InnerMock.On("GetAll").Return(lists, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+			},
+			want: []models.PresentationList{
+				{
+					URL:      "https://example.com/api/v1/lists/5",
+					Title:    "test",
+					TodosURL: "https://example.com/api/v1/lists/5/todos",
+				},
+				{
+					URL:      "https://example.com/api/v1/lists/23",
+					Title:    "test2",
+					TodosURL: "https://example.com/api/v1/lists/23/todos",
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				Storage: func() ListStorage {
+					storage := &MockListStorage{}
+					storage.InnerMock.
+						On("GetAll").
+						Return([]models.List(nil), iotest.ErrTimeout)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := List{
+				Storage: tt.fields.Storage,
+			}
+			got, err := useCase.GetAll(tt.args.baseURL)
+
+			tt.fields.Storage.(*MockListStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestList_GetSingle(t *testing.T) {
+	type fields struct {
+		Storage ListStorage
+	}
+	type args struct {
+		baseURL *url.URL
+		id      int
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    models.PresentationList
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Storage: func() ListStorage {
+					list := models.List{ID: 23, Title: "test"}
+
+					storage := &MockListStorage{}
+					storage.InnerMock.On("GetSingle", 23).Return(list, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      23,
+			},
+			want: models.PresentationList{
+				URL:      "https://example.com/api/v1/lists/23",
+				Title:    "test",
+				TodosURL: "https://example.com/api/v1/lists/23/todos",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				Storage: func() ListStorage {
+					storage := &MockListStorage{}
+					storage.InnerMock.
+						On("GetSingle", 23).
+						Return(models.List{}, models.ErrNotFound)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      23,
+			},
+			want: models.PresentationList{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrNotFound, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := List{
+				Storage: tt.fields.Storage,
+			}
+			got, err := useCase.GetSingle(tt.args.baseURL, tt.args.id)
+
+			tt.fields.Storage.(*MockListStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestList_Create(t *testing.T) {
+	type fields struct {
+		Storage ListStorage
+		Limits  models.ValidationLimits
+	}
+	type args struct {
+		baseURL          *url.URL
+		presentationList models.PresentationList
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    models.PresentationList
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Storage: func() ListStorage {
+					storage := &MockListStorage{}
+					storage.InnerMock.
+						On("Create", models.List{Title: "test"}).
+						Return(models.List{ID: 23, Title: "test"}, nil)
+
+					return storage
+				}(),
+				Limits: models.DefaultValidationLimits,
+			},
+			args: args{
+				baseURL:          &url.URL{Scheme: "https", Host: "example.com"},
+				presentationList: models.PresentationList{Title: "test"},
+			},
+			want: models.PresentationList{
+				URL:      "https://example.com/api/v1/lists/23",
+				Title:    "test",
+				TodosURL: "https://example.com/api/v1/lists/23/todos",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with validation",
+			fields: fields{
+				Storage: &MockListStorage{},
+				Limits:  models.DefaultValidationLimits,
+			},
+			args: args{
+				baseURL:          &url.URL{Scheme: "https", Host: "example.com"},
+				presentationList: models.PresentationList{Title: ""},
+			},
+			want: models.PresentationList{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrInvalid, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with the storage",
+			fields: fields{
+				Storage: func() ListStorage {
+					storage := &MockListStorage{}
+					storage.InnerMock.
+						On("Create", models.List{Title: "test"}).
+						Return(models.List{}, iotest.ErrTimeout)
+
+					return storage
+				}(),
+				Limits: models.DefaultValidationLimits,
+			},
+			args: args{
+				baseURL:          &url.URL{Scheme: "https", Host: "example.com"},
+				presentationList: models.PresentationList{Title: "test"},
+			},
+			want:    models.PresentationList{},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := List{
+				Storage: tt.fields.Storage,
+				Limits:  tt.fields.Limits,
+			}
+			got, err := useCase.Create(tt.args.baseURL, tt.args.presentationList)
+
+			tt.fields.Storage.(*MockListStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestList_Update(t *testing.T) {
+	type fields struct {
+		Storage ListStorage
+		Limits  models.ValidationLimits
+	}
+	type args struct {
+		baseURL          *url.URL
+		id               int
+		presentationList models.PresentationList
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    models.PresentationList
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Storage: func() ListStorage {
+					storage := &MockListStorage{}
+					storage.InnerMock.
+						On("Update", 23, models.List{Title: "test"}).
+						Return(models.List{ID: 23, Title: "test"}, nil)
+
+					return storage
+				}(),
+				Limits: models.DefaultValidationLimits,
+			},
+			args: args{
+				baseURL:          &url.URL{Scheme: "https", Host: "example.com"},
+				id:               23,
+				presentationList: models.PresentationList{Title: "test"},
+			},
+			want: models.PresentationList{
+				URL:      "https://example.com/api/v1/lists/23",
+				Title:    "test",
+				TodosURL: "https://example.com/api/v1/lists/23/todos",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with validation",
+			fields: fields{
+				Storage: &MockListStorage{},
+				Limits:  models.DefaultValidationLimits,
+			},
+			args: args{
+				baseURL:          &url.URL{Scheme: "https", Host: "example.com"},
+				id:               23,
+				presentationList: models.PresentationList{Title: ""},
+			},
+			want: models.PresentationList{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrInvalid, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a not found list",
+			fields: fields{
+				Storage: func() ListStorage {
+					storage := &MockListStorage{}
+					storage.InnerMock.
+						On("Update", 23, models.List{Title: "test"}).
+						Return(models.List{}, models.ErrNotFound)
+
+					return storage
+				}(),
+				Limits: models.DefaultValidationLimits,
+			},
+			args: args{
+				baseURL:          &url.URL{Scheme: "https", Host: "example.com"},
+				id:               23,
+				presentationList: models.PresentationList{Title: "test"},
+			},
+			want: models.PresentationList{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrNotFound, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := List{
+				Storage: tt.fields.Storage,
+				Limits:  tt.fields.Limits,
+			}
+			got, err :=
+				useCase.Update(tt.args.baseURL, tt.args.id, tt.args.presentationList)
+
+			tt.fields.Storage.(*MockListStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestList_DeleteSingle(t *testing.T) {
+	type fields struct {
+		Storage ListStorage
+	}
+	type args struct {
+		id      int
+		cascade bool
+		actor   string
+	}
+
+	inboxListID := 0
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the cascade",
+			fields: fields{
+				Storage: func() ListStorage {
+					todos := []models.TodoRecord{{ID: 12, Title: "test", ListID: 23}}
+
+					todoRecordStorage := &MockStorage{}
+					todoRecordStorage.InnerMock.On("InTransaction").Return(nil)
+					todoRecordStorage.InnerMock.
+						On("GetAll", models.Query{ListID: 23}).
+						Return(todos, nil)
+					todoRecordStorage.InnerMock.
+						On("DeleteAll", models.Query{IDs: []int{12}}).
+						Return(1, nil)
+					todoRecordStorage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 12,
+							Action:       models.HistoryActionDelete,
+							OldValue:     &todos[0],
+							Actor:        "admin",
+						}).
+						Return(nil)
+
+					storage := &MockListStorage{TodoRecordStorage: todoRecordStorage}
+					storage.InnerMock.On("DeleteSingle", 23).Return(nil)
+
+					return storage
+				}(),
+			},
+			args:    args{id: 23, cascade: true, actor: "admin"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with moving to the inbox",
+			fields: fields{
+				Storage: func() ListStorage {
+					oldTodo := models.TodoRecord{ID: 12, Title: "test", ListID: 23}
+					newTodo := models.TodoRecord{ID: 12, Title: "test", Version: 1}
+
+					todoRecordStorage := &MockStorage{}
+					todoRecordStorage.InnerMock.
+						On("GetAll", models.Query{ListID: 23}).
+						Return([]models.TodoRecord{oldTodo}, nil)
+					todoRecordStorage.InnerMock.On("InTransaction").Return(nil)
+					todoRecordStorage.InnerMock.On("GetSingle", 12).Return(oldTodo, nil)
+					todoRecordStorage.InnerMock.
+						On("Patch", 12, models.TodoRecordPatch{ListID: &inboxListID}, 0).
+						Return(newTodo, nil)
+					todoRecordStorage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 12,
+							Action:       models.HistoryActionPatch,
+							OldValue:     &oldTodo,
+							NewValue:     &newTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
+
+					storage := &MockListStorage{TodoRecordStorage: todoRecordStorage}
+					storage.InnerMock.On("DeleteSingle", 23).Return(nil)
+
+					return storage
+				}(),
+			},
+			args:    args{id: 23, cascade: false, actor: "admin"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with children in another list",
+			fields: fields{
+				Storage: func() ListStorage {
+					todos := []models.TodoRecord{
+						{ID: 12, Title: "test", ListID: 23, ChildCount: 1},
+					}
+
+					todoRecordStorage := &MockStorage{}
+					todoRecordStorage.InnerMock.On("InTransaction").Return(nil)
+					todoRecordStorage.InnerMock.
+						On("GetAll", models.Query{ListID: 23}).
+						Return(todos, nil)
+
+					storage := &MockListStorage{TodoRecordStorage: todoRecordStorage}
+					storage.InnerMock.On("DeleteSingle", 23).Return(nil)
+
+					return storage
+				}(),
+			},
+			args: args{id: 23, cascade: true, actor: "admin"},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrConflict, msgAndArgs...)
+			},
+		},
+		{
+			name: "error",
+			fields: fields{
+				Storage: func() ListStorage {
+					storage := &MockListStorage{TodoRecordStorage: &MockStorage{}}
+					storage.InnerMock.
+						On("DeleteSingle", 23).
+						Return(models.ErrNotFound)
+
+					return storage
+				}(),
+			},
+			args: args{id: 23, cascade: false, actor: "admin"},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrNotFound, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := List{
+				Storage: tt.fields.Storage,
+			}
+			err := useCase.DeleteSingle(tt.args.id, tt.args.cascade, tt.args.actor)
+
+			storage := tt.fields.Storage.(*MockListStorage)
+			storage.InnerMock.AssertExpectations(t)
+			storage.TodoRecordStorage.InnerMock.AssertExpectations(t)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockListStorage struct {
+	InnerMock         mock.Mock
+	TodoRecordStorage *MockStorage
+}
+
+func (mock *MockListStorage) GetAll() ([]models.List, error) {
+	results := mock.InnerMock.Called()
+	return results.Get(0).([]models.List), results.Error(1)
+}
+
+func (mock *MockListStorage) GetSingle(id int) (models.List, error) {
+	results := mock.InnerMock.Called(id)
+	return results.Get(0).(models.List), results.Error(1)
+}
+
+func (mock *MockListStorage) Create(list models.List) (models.List, error) {
+	results := mock.InnerMock.Called(list)
+	return results.Get(0).(models.List), results.Error(1)
+}
+
+func (mock *MockListStorage) Update(id int, list models.List) (
+	models.List,
+	error,
+) {
+	results := mock.InnerMock.Called(id, list)
+	return results.Get(0).(models.List), results.Error(1)
+}
+
+// DeleteSingle calls the handler with the TodoRecordStorage mock,
+// unless the mocked call returns an error.
+func (mock *MockListStorage) DeleteSingle(
+	id int,
+	handler func(todoRecordStorage TodoRecordStorage) error,
+) error {
+	results := mock.InnerMock.Called(id)
+	if err := results.Error(0); err != nil {
+		return err
+	}
+
+	return handler(mock.TodoRecordStorage)
+}
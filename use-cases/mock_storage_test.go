@@ -1,6 +1,8 @@
 package usecases
 
 import (
+	"time"
+
 	"github.com/irenicaa/go-todo-backend/v2/models"
 	"github.com/stretchr/testify/mock"
 )
@@ -17,27 +19,107 @@ func (mock *MockStorage) GetAll(query models.Query) (
 	return results.Get(0).([]models.TodoRecord), results.Error(1)
 }
 
+func (mock *MockStorage) Count(query models.Query) (int, error) {
+	results := mock.InnerMock.Called(query)
+	return results.Int(0), results.Error(1)
+}
+
 func (mock *MockStorage) GetSingle(id int) (models.TodoRecord, error) {
 	results := mock.InnerMock.Called(id)
 	return results.Get(0).(models.TodoRecord), results.Error(1)
 }
 
-func (mock *MockStorage) Create(todo models.TodoRecord) (id int, err error) {
+func (mock *MockStorage) Create(todo models.TodoRecord) (
+	models.TodoRecord,
+	error,
+) {
 	results := mock.InnerMock.Called(todo)
+	return results.Get(0).(models.TodoRecord), results.Error(1)
+}
+
+func (mock *MockStorage) Update(
+	id int,
+	todo models.TodoRecord,
+	version int,
+) (models.TodoRecord, error) {
+	results := mock.InnerMock.Called(id, todo, version)
+	return results.Get(0).(models.TodoRecord), results.Error(1)
+}
+
+func (mock *MockStorage) Patch(
+	id int,
+	todoPatch models.TodoRecordPatch,
+	version int,
+) (models.TodoRecord, error) {
+	results := mock.InnerMock.Called(id, todoPatch, version)
+	return results.Get(0).(models.TodoRecord), results.Error(1)
+}
+
+func (mock *MockStorage) DeleteAll(query models.Query) (int, error) {
+	results := mock.InnerMock.Called(query)
 	return results.Int(0), results.Error(1)
 }
 
-func (mock *MockStorage) Update(id int, todo models.TodoRecord) error {
-	results := mock.InnerMock.Called(id, todo)
+func (mock *MockStorage) DeleteSingle(id int, version int) error {
+	results := mock.InnerMock.Called(id, version)
 	return results.Error(0)
 }
 
-func (mock *MockStorage) DeleteAll() error {
-	results := mock.InnerMock.Called()
+func (mock *MockStorage) Restore(id int) (models.TodoRecord, error) {
+	results := mock.InnerMock.Called(id)
+	return results.Get(0).(models.TodoRecord), results.Error(1)
+}
+
+func (mock *MockStorage) Purge(retention time.Duration) (int, error) {
+	results := mock.InnerMock.Called(retention)
+	return results.Int(0), results.Error(1)
+}
+
+func (mock *MockStorage) AddDependency(id int, blockerID int) error {
+	results := mock.InnerMock.Called(id, blockerID)
+	return results.Error(0)
+}
+
+func (mock *MockStorage) DeleteDependency(id int, blockerID int) error {
+	results := mock.InnerMock.Called(id, blockerID)
 	return results.Error(0)
 }
 
-func (mock *MockStorage) DeleteSingle(id int) error {
+func (mock *MockStorage) GetBlockerIDs(id int) ([]int, error) {
 	results := mock.InnerMock.Called(id)
+	return results.Get(0).([]int), results.Error(1)
+}
+
+// InTransaction calls the handler with the same mock,
+// unless the mocked call returns an error.
+func (mock *MockStorage) InTransaction(
+	handler func(storage TodoRecordStorage) error,
+) error {
+	results := mock.InnerMock.Called()
+	if err := results.Error(0); err != nil {
+		return err
+	}
+
+	return handler(mock)
+}
+
+func (mock *MockStorage) AddHistoryRecord(record models.HistoryRecord) error {
+	results := mock.InnerMock.Called(record)
 	return results.Error(0)
 }
+
+func (mock *MockStorage) GetHistory(query models.HistoryQuery) (
+	[]models.HistoryRecord,
+	error,
+) {
+	results := mock.InnerMock.Called(query)
+	return results.Get(0).([]models.HistoryRecord), results.Error(1)
+}
+
+func (mock *MockStorage) GetHistoryRecord(revision int) (
+	models.HistoryRecord,
+	error,
+) {
+	results := mock.InnerMock.Called(revision)
+	return results.Get(0).(models.HistoryRecord), results.Error(1)
+}
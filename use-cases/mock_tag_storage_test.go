@@ -0,0 +1,15 @@
+package usecases
+
+import (
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagStorage struct {
+	InnerMock mock.Mock
+}
+
+func (mock *MockTagStorage) GetAll() ([]models.Tag, error) {
+	results := mock.InnerMock.Called()
+	return results.Get(0).([]models.Tag), results.Error(1)
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+)
+
+// TagStorage ...
+type TagStorage interface {
+	GetAll() ([]models.Tag, error)
+}
+
+// Tag ...
+type Tag struct {
+	Storage TagStorage
+}
+
+// GetAll ...
+func (useCase Tag) GetAll() ([]models.Tag, error) {
+	tags, err := useCase.Storage.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the tags: %w", err)
+	}
+
+	// force the empty array instead of the nil one
+	if tags == nil {
+		tags = []models.Tag{}
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"testing"
+	"testing/iotest"
+
+	"github.com/irenicaa/go-todo-backend/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTag_GetAll(t *testing.T) {
+	type fields struct {
+		Storage TagStorage
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []models.Tag
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success without tags",
+			fields: fields{
+				Storage: func() TagStorage {
+					storage := &MockTagStorage{}
+					storage.InnerMock.On("GetAll").Return([]models.Tag(nil), nil)
+
+					return storage
+				}(),
+			},
+			want:    []models.Tag{},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with tags",
+			fields: fields{
+				Storage: func() TagStorage {
+					tags := []models.Tag{
+						{Name: "work", Count: 23},
+						{Name: "home", Count: 5},
+					}
+
+					storage := &MockTagStorage{}
+					storage.InnerMock.On("GetAll").Return(tags, nil)
+
+					return storage
+				}(),
+			},
+			want: []models.Tag{
+				{Name: "work", Count: 23},
+				{Name: "home", Count: 5},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				Storage: func() TagStorage {
+					storage := &MockTagStorage{}
+					storage.InnerMock.
+						On("GetAll").
+						Return([]models.Tag(nil), iotest.ErrTimeout)
+
+					return storage
+				}(),
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := Tag{
+				Storage: tt.fields.Storage,
+			}
+			got, err := useCase.GetAll()
+
+			tt.fields.Storage.(*MockTagStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -1,35 +1,124 @@
 package usecases
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/irenicaa/go-todo-backend/v2/models"
 )
 
+var errBatchFailed = errors.New("batch failed")
+
 // TodoRecordStorage ...
+//
+// The version parameters hold the expected version of the to-do record;
+// the zero version disables the check.
+//
+// The deletion methods move the to-do records to the trash,
+// which is ignored by the other methods, unless the Deleted flag is set
+// in the query; the Purge() method empties it permanently.
 type TodoRecordStorage interface {
 	GetAll(query models.Query) ([]models.TodoRecord, error)
+	Count(query models.Query) (int, error)
 	GetSingle(id int) (models.TodoRecord, error)
-	Create(todo models.TodoRecord) (id int, err error)
-	Update(id int, todo models.TodoRecord) error
-	DeleteAll() error
-	DeleteSingle(id int) error
+	Create(todo models.TodoRecord) (models.TodoRecord, error)
+	Update(id int, todo models.TodoRecord, version int) (
+		models.TodoRecord,
+		error,
+	)
+	Patch(id int, todoPatch models.TodoRecordPatch, version int) (
+		models.TodoRecord,
+		error,
+	)
+	DeleteAll(query models.Query) (int, error)
+	DeleteSingle(id int, version int) error
+	Restore(id int) (models.TodoRecord, error)
+	Purge(retention time.Duration) (int, error)
+
+	// AddDependency ignores the existing dependency.
+	AddDependency(id int, blockerID int) error
+	DeleteDependency(id int, blockerID int) error
+	// GetBlockerIDs includes the trashed blockers, unlike the GetSingle()
+	// method, which ignores the trashed to-do records themselves.
+	GetBlockerIDs(id int) ([]int, error)
+
+	// AddHistoryRecord sets the revision and the change time of the record.
+	AddHistoryRecord(record models.HistoryRecord) error
+	// GetHistory returns the history records in the chronological order.
+	GetHistory(query models.HistoryQuery) ([]models.HistoryRecord, error)
+	GetHistoryRecord(revision int) (models.HistoryRecord, error)
+
+	// InTransaction commits the changes of the handler only if it succeeds;
+	// the nested calls roll back only their own changes on failure.
+	InTransaction(handler func(storage TodoRecordStorage) error) error
 }
 
 // TodoRecord ...
+//
+// The modifying methods record the changes to the history
+// in the same transaction; the actor parameter identifies the changer.
+//
+// Completing of a recurring to-do record by the Update() or Patch() method
+// moves its recurrence to the next occurrence, which is created
+// as a new to-do record.
+//
+// The parent of a to-do record should exist and can't be the to-do record
+// itself or its descendant, otherwise the models.ErrConflict error
+// is returned. The cascade parameters complete or delete the descendants
+// along with the to-do record; without it, the deletion of a to-do record
+// with children is refused with the same error.
+//
+// Likewise, the blockers of a to-do record should exist and can't be blocked
+// by it, even indirectly. Completing of a to-do record with open blockers
+// is refused with the models.ErrBlocked error, unless the force parameter
+// is set; with the cascade parameter, it's checked for each descendant.
+//
+// The DefaultSort field completes the sort of the queries, see the
+// models.ParseDefaultSort() function; the storages use the models.DefaultSort
+// variable if it's nil.
 type TodoRecord struct {
-	Storage TodoRecordStorage
+	Storage     TodoRecordStorage
+	Limits      models.ValidationLimits
+	DefaultSort []models.SortField
 }
 
 // GetAll ...
+//
+// In the cursor mode, one extra record is requested from the storage
+// to find out whether the next page exists.
 func (useCase TodoRecord) GetAll(baseURL *url.URL, query models.Query) (
 	[]models.PresentationTodoRecord,
+	models.PageInfo,
 	error,
 ) {
-	todos, err := useCase.Storage.GetAll(query)
+	storageQuery := query
+	storageQuery.Sort = models.CompleteSort(query.Sort, useCase.DefaultSort)
+	if query.Pagination.IsCursorMode() {
+		storageQuery.Pagination.PageSize++
+	}
+
+	todos, err := useCase.Storage.GetAll(storageQuery)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get the to-do records: %v", err)
+		return nil, models.PageInfo{},
+			fmt.Errorf("unable to get the to-do records: %w", err)
+	}
+
+	totalCount, err := useCase.Storage.Count(query)
+	if err != nil {
+		return nil, models.PageInfo{},
+			fmt.Errorf("unable to count the to-do records: %w", err)
+	}
+
+	pageInfo := models.PageInfo{TotalCount: totalCount}
+	if query.Pagination.IsCursorMode() &&
+		len(todos) > query.Pagination.PageSize {
+		todos = todos[:query.Pagination.PageSize]
+
+		lastTodo := todos[len(todos)-1]
+		pageInfo.NextCursor =
+			models.NewCursor(lastTodo, storageQuery.Sort).String()
 	}
 
 	// force the empty array instead of the nil one
@@ -39,7 +128,7 @@ func (useCase TodoRecord) GetAll(baseURL *url.URL, query models.Query) (
 		presentationTodos = append(presentationTodos, presentationTodo)
 	}
 
-	return presentationTodos, nil
+	return presentationTodos, pageInfo, nil
 }
 
 // GetSingle ...
@@ -50,29 +139,66 @@ func (useCase TodoRecord) GetSingle(baseURL *url.URL, id int) (
 	todo, err := useCase.Storage.GetSingle(id)
 	if err != nil {
 		return models.PresentationTodoRecord{},
-			fmt.Errorf("unable to get the to-do record: %v", err)
+			fmt.Errorf("unable to get the to-do record: %w", err)
 	}
 
 	presentationTodo := models.NewPresentationTodoRecord(baseURL, todo)
 	return presentationTodo, nil
 }
 
+// GetTree returns the to-do record with all its descendants
+// in the Children fields.
+func (useCase TodoRecord) GetTree(baseURL *url.URL, id int) (
+	models.PresentationTodoRecord,
+	error,
+) {
+	todo, err := useCase.Storage.GetSingle(id)
+	if err != nil {
+		return models.PresentationTodoRecord{},
+			fmt.Errorf("unable to get the to-do record: %w", err)
+	}
+
+	return useCase.makeTree(baseURL, todo)
+}
+
 // Create ...
 func (useCase TodoRecord) Create(
 	baseURL *url.URL,
 	presentationTodo models.PresentationTodoRecord,
+	actor string,
 ) (
 	models.PresentationTodoRecord,
 	error,
 ) {
-	todo := models.NewTodoRecord(presentationTodo)
-	id, err := useCase.Storage.Create(todo)
+	err := useCase.Limits.ValidatePresentationTodoRecord(presentationTodo)
 	if err != nil {
 		return models.PresentationTodoRecord{},
-			fmt.Errorf("unable to create a to-do record: %v", err)
+			fmt.Errorf("unable to validate the to-do record: %w", err)
 	}
 
-	todo.ID = id
+	var todo models.TodoRecord
+	err = useCase.Storage.InTransaction(func(storage TodoRecordStorage) error {
+		if err := checkParent(storage, 0, presentationTodo.ParentID); err != nil {
+			return err
+		}
+
+		var err error
+		todo, err = storage.Create(models.NewTodoRecord(presentationTodo))
+		if err != nil {
+			return err
+		}
+
+		return storage.AddHistoryRecord(models.HistoryRecord{
+			TodoRecordID: todo.ID,
+			Action:       models.HistoryActionCreate,
+			NewValue:     &todo,
+			Actor:        actor,
+		})
+	})
+	if err != nil {
+		return models.PresentationTodoRecord{},
+			fmt.Errorf("unable to create a to-do record: %w", err)
+	}
 
 	presentationTodo = models.NewPresentationTodoRecord(baseURL, todo)
 	return presentationTodo, nil
@@ -83,17 +209,53 @@ func (useCase TodoRecord) Update(
 	baseURL *url.URL,
 	id int,
 	presentationTodo models.PresentationTodoRecord,
+	version int,
+	cascade bool,
+	force bool,
+	actor string,
 ) (
 	models.PresentationTodoRecord,
 	error,
 ) {
-	todo := models.NewTodoRecord(presentationTodo)
-	if err := useCase.Storage.Update(id, todo); err != nil {
+	err := useCase.Limits.ValidatePresentationTodoRecord(presentationTodo)
+	if err != nil {
 		return models.PresentationTodoRecord{},
-			fmt.Errorf("unable to update the to-do record: %v", err)
+			fmt.Errorf("unable to validate the to-do record: %w", err)
 	}
 
-	todo.ID = id
+	todo, err := useCase.modify(
+		id,
+		models.HistoryActionUpdate,
+		actor,
+		func(
+			storage TodoRecordStorage,
+			oldTodo models.TodoRecord,
+		) (models.TodoRecord, error) {
+			err := checkParent(storage, id, presentationTodo.ParentID)
+			if err != nil {
+				return models.TodoRecord{}, err
+			}
+
+			todo, err :=
+				storage.Update(id, models.NewTodoRecord(presentationTodo), version)
+			if err != nil {
+				return models.TodoRecord{}, err
+			}
+
+			return useCase.complete(
+				storage,
+				oldTodo,
+				todo,
+				cascade,
+				force,
+				actor,
+			)
+		},
+	)
+	if err != nil {
+		return models.PresentationTodoRecord{},
+			fmt.Errorf("unable to update the to-do record: %w", err)
+	}
 
 	presentationTodo = models.NewPresentationTodoRecord(baseURL, todo)
 	return presentationTodo, nil
@@ -104,36 +266,879 @@ func (useCase TodoRecord) Patch(
 	baseURL *url.URL,
 	id int,
 	todoPatch models.TodoRecordPatch,
+	version int,
+	cascade bool,
+	force bool,
+	actor string,
 ) (
 	models.PresentationTodoRecord,
 	error,
 ) {
-	todo, err := useCase.Storage.GetSingle(id)
+	if err := useCase.Limits.ValidateTodoRecordPatch(todoPatch); err != nil {
+		return models.PresentationTodoRecord{},
+			fmt.Errorf("unable to validate the to-do record patch: %w", err)
+	}
+
+	todo, err := useCase.modify(
+		id,
+		models.HistoryActionPatch,
+		actor,
+		func(
+			storage TodoRecordStorage,
+			oldTodo models.TodoRecord,
+		) (models.TodoRecord, error) {
+			if todoPatch.ParentID != nil {
+				if err := checkParent(storage, id, *todoPatch.ParentID); err != nil {
+					return models.TodoRecord{}, err
+				}
+			}
+
+			todo, err := storage.Patch(id, todoPatch, version)
+			if err != nil {
+				return models.TodoRecord{}, err
+			}
+
+			return useCase.complete(
+				storage,
+				oldTodo,
+				todo,
+				cascade,
+				force,
+				actor,
+			)
+		},
+	)
 	if err != nil {
 		return models.PresentationTodoRecord{},
-			fmt.Errorf("unable to get the to-do record: %v", err)
+			fmt.Errorf("unable to patch the to-do record: %w", err)
+	}
+
+	presentationTodo := models.NewPresentationTodoRecord(baseURL, todo)
+	return presentationTodo, nil
+}
+
+// DeleteAll returns the count of the deleted to-do records.
+//
+// The matched to-do records are read beforehand for the history,
+// and then exactly they are deleted. They should include all the children
+// of the deleted to-do records.
+func (useCase TodoRecord) DeleteAll(query models.Query, actor string) (
+	int,
+	error,
+) {
+	var count int
+	err := useCase.Storage.InTransaction(func(storage TodoRecordStorage) error {
+		todos, err := storage.GetAll(query)
+		if err != nil || len(todos) == 0 {
+			return err
+		}
+
+		var ids []int
+		matchedChildCounts := map[int]int{}
+		for _, todo := range todos {
+			ids = append(ids, todo.ID)
+			matchedChildCounts[todo.ParentID]++
+		}
+		for _, todo := range todos {
+			if todo.ChildCount > matchedChildCounts[todo.ID] {
+				return fmt.Errorf(
+					"%w: the to-do record #%d has unmatched children",
+					models.ErrConflict,
+					todo.ID,
+				)
+			}
+		}
+
+		count, err = storage.DeleteAll(models.Query{IDs: ids})
+		if err != nil {
+			return err
+		}
+
+		for index := range todos {
+			err := storage.AddHistoryRecord(models.HistoryRecord{
+				TodoRecordID: todos[index].ID,
+				Action:       models.HistoryActionDelete,
+				OldValue:     &todos[index],
+				Actor:        actor,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to delete the to-do records: %w", err)
+	}
+
+	return count, nil
+}
+
+// DeleteSingle ...
+func (useCase TodoRecord) DeleteSingle(
+	id int,
+	version int,
+	cascade bool,
+	actor string,
+) error {
+	err := useCase.Storage.InTransaction(func(storage TodoRecordStorage) error {
+		oldTodo, err := storage.GetSingle(id)
+		if err != nil {
+			return err
+		}
+
+		return useCase.deleteWithChildren(
+			storage,
+			oldTodo,
+			version,
+			cascade,
+			actor,
+		)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to delete the to-do record: %w", err)
 	}
 
-	todo.Patch(todoPatch)
+	return nil
+}
+
+// Restore ...
+func (useCase TodoRecord) Restore(baseURL *url.URL, id int, actor string) (
+	models.PresentationTodoRecord,
+	error,
+) {
+	var todo models.TodoRecord
+	err := useCase.Storage.InTransaction(func(storage TodoRecordStorage) error {
+		var err error
+		todo, err = storage.Restore(id)
+		if err != nil {
+			return err
+		}
+		if err := checkParent(storage, id, todo.ParentID); err != nil {
+			return err
+		}
+
+		return storage.AddHistoryRecord(models.HistoryRecord{
+			TodoRecordID: id,
+			Action:       models.HistoryActionRestore,
+			NewValue:     &todo,
+			Actor:        actor,
+		})
+	})
+	if err != nil {
+		return models.PresentationTodoRecord{},
+			fmt.Errorf("unable to restore the to-do record: %w", err)
+	}
+
+	presentationTodo := models.NewPresentationTodoRecord(baseURL, todo)
+	return presentationTodo, nil
+}
+
+// AddDependency makes the to-do record blocked by the blocker one.
+func (useCase TodoRecord) AddDependency(
+	baseURL *url.URL,
+	id int,
+	dependency models.Dependency,
+) (
+	models.PresentationTodoRecord,
+	error,
+) {
+	if err := useCase.Limits.ValidateDependency(dependency); err != nil {
+		return models.PresentationTodoRecord{},
+			fmt.Errorf("unable to validate the dependency: %w", err)
+	}
+
+	var todo models.TodoRecord
+	err := useCase.Storage.InTransaction(func(storage TodoRecordStorage) error {
+		if _, err := storage.GetSingle(id); err != nil {
+			return err
+		}
+		if err := checkBlocker(storage, id, dependency.BlockerID); err != nil {
+			return err
+		}
+
+		err := storage.AddDependency(id, dependency.BlockerID)
+		if err != nil {
+			return err
+		}
+
+		// reread the to-do record to update its blockers
+		todo, err = storage.GetSingle(id)
+		return err
+	})
+	if err != nil {
+		return models.PresentationTodoRecord{},
+			fmt.Errorf("unable to add the dependency: %w", err)
+	}
 
 	presentationTodo := models.NewPresentationTodoRecord(baseURL, todo)
-	return useCase.Update(baseURL, id, presentationTodo)
+	return presentationTodo, nil
 }
 
-// DeleteAll ...
-func (useCase TodoRecord) DeleteAll() error {
-	if err := useCase.Storage.DeleteAll(); err != nil {
-		return fmt.Errorf("unable to delete the to-do records: %v", err)
+// DeleteDependency ...
+func (useCase TodoRecord) DeleteDependency(id int, blockerID int) error {
+	if err := useCase.Storage.DeleteDependency(id, blockerID); err != nil {
+		return fmt.Errorf("unable to delete the dependency: %w", err)
 	}
 
 	return nil
 }
 
-// DeleteSingle ...
-func (useCase TodoRecord) DeleteSingle(id int) error {
-	if err := useCase.Storage.DeleteSingle(id); err != nil {
-		return fmt.Errorf("unable to delete the to-do record: %v", err)
+// GetHistory ...
+//
+// If the page size is set, one extra record is requested from the storage
+// to find out whether the next page exists; the next cursor is empty
+// on the last page.
+func (useCase TodoRecord) GetHistory(
+	baseURL *url.URL,
+	query models.HistoryQuery,
+) ([]models.PresentationHistoryRecord, string, error) {
+	storageQuery := query
+	if query.PageSize != 0 {
+		storageQuery.PageSize++
+	}
+
+	records, err := useCase.Storage.GetHistory(storageQuery)
+	if err != nil {
+		return nil, "",
+			fmt.Errorf("unable to get the history records: %w", err)
+	}
+
+	var nextCursor string
+	if query.PageSize != 0 && len(records) > query.PageSize {
+		records = records[:query.PageSize]
+
+		lastRecord := records[len(records)-1]
+		nextCursor =
+			models.HistoryCursor{Revision: lastRecord.Revision}.String()
+	}
+
+	// force the empty array instead of the nil one
+	presentationRecords := []models.PresentationHistoryRecord{}
+	for _, record := range records {
+		presentationRecord := models.NewPresentationHistoryRecord(baseURL, record)
+		presentationRecords = append(presentationRecords, presentationRecord)
+	}
+
+	return presentationRecords, nextCursor, nil
+}
+
+// Revert sets the to-do record to its state after the given revision.
+//
+// The revisions of the deletion can't be reverted to,
+// and the trashed to-do records should be restored beforehand.
+// The parent of the revision is checked as on the update,
+// and its completion is handled as on the update without the cascade
+// and force parameters.
+func (useCase TodoRecord) Revert(
+	baseURL *url.URL,
+	id int,
+	revision int,
+	version int,
+	actor string,
+) (
+	models.PresentationTodoRecord,
+	error,
+) {
+	todo, err := useCase.modify(
+		id,
+		models.HistoryActionRevert,
+		actor,
+		func(
+			storage TodoRecordStorage,
+			oldTodo models.TodoRecord,
+		) (models.TodoRecord, error) {
+			record, err := storage.GetHistoryRecord(revision)
+			if err != nil {
+				return models.TodoRecord{},
+					fmt.Errorf("unable to get the revision: %w", err)
+			}
+			if record.TodoRecordID != id {
+				return models.TodoRecord{}, fmt.Errorf(
+					"unable to get the revision: %w",
+					models.ErrNotFound,
+				)
+			}
+			if record.NewValue == nil {
+				return models.TodoRecord{}, fmt.Errorf(
+					"%w: the revision %d deletes the to-do record",
+					models.ErrInvalid,
+					revision,
+				)
+			}
+
+			// the fields missed in the revision keep their current values
+			if parentID := record.NewValuePatch.ParentID; parentID != nil {
+				if err := checkParent(storage, id, *parentID); err != nil {
+					return models.TodoRecord{}, err
+				}
+			}
+
+			todo, err := storage.Patch(id, *record.NewValuePatch, version)
+			if err != nil {
+				return models.TodoRecord{}, err
+			}
+
+			return useCase.complete(storage, oldTodo, todo, false, false, actor)
+		},
+	)
+	if err != nil {
+		return models.PresentationTodoRecord{},
+			fmt.Errorf("unable to revert the to-do record: %w", err)
+	}
+
+	presentationTodo := models.NewPresentationTodoRecord(baseURL, todo)
+	return presentationTodo, nil
+}
+
+// GetOccurrences expands the recurrence of the to-do record
+// in the inclusive date range without saving the occurrences;
+// the non-recurring to-do record is its only occurrence.
+//
+// The occurrences keep the URL of the to-do record,
+// and their due time is shifted along with the date.
+func (useCase TodoRecord) GetOccurrences(
+	baseURL *url.URL,
+	id int,
+	minimalDate time.Time,
+	maximalDate time.Time,
+) ([]models.PresentationTodoRecord, error) {
+	todo, err := useCase.Storage.GetSingle(id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the to-do record: %w", err)
+	}
+
+	recurrence := models.Recurrence{Frequency: models.FrequencyDaily, Count: 1}
+	if todo.Recurrence != "" {
+		recurrence, err = models.ParseRecurrence(todo.Recurrence)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: unable to parse the recurrence: %v",
+				models.ErrInvalid,
+				err,
+			)
+		}
+	}
+
+	// force the empty array instead of the nil one
+	presentationTodos := []models.PresentationTodoRecord{}
+	for _, date := range recurrence.Expand(todo.Date, minimalDate, maximalDate) {
+		occurrence := moveToDate(todo, date)
+		presentationTodo := models.NewPresentationTodoRecord(baseURL, occurrence)
+		presentationTodos = append(presentationTodos, presentationTodo)
+	}
+
+	return presentationTodos, nil
+}
+
+// PurgeTrash deletes permanently the to-do records trashed at least
+// the retention ago; the zero retention empties the whole trash.
+func (useCase TodoRecord) PurgeTrash(retention time.Duration) (int, error) {
+	count, err := useCase.Storage.Purge(retention)
+	if err != nil {
+		return 0, fmt.Errorf("unable to purge the trash: %w", err)
+	}
+
+	return count, nil
+}
+
+// Batch executes the operations in a single transaction.
+//
+// In the all-or-nothing mode, the first failed operation rolls back
+// the whole batch; the other operations get the models.ErrRolledBack error.
+// In the best-effort mode, the failed operations are just skipped.
+func (useCase TodoRecord) Batch(
+	baseURL *url.URL,
+	batch models.Batch,
+	actor string,
+) (
+	[]models.BatchResult,
+	error,
+) {
+	if err := useCase.Limits.ValidateBatch(batch); err != nil {
+		return nil, fmt.Errorf("unable to validate the batch: %w", err)
+	}
+
+	results := make([]models.BatchResult, len(batch.Operations))
+	var failedIndex int
+	err := useCase.Storage.InTransaction(func(storage TodoRecordStorage) error {
+		for index, operation := range batch.Operations {
+			err := storage.InTransaction(func(storage TodoRecordStorage) error {
+				operationUseCase := useCase
+				operationUseCase.Storage = storage
+				todo, err :=
+					operationUseCase.executeOperation(baseURL, operation, actor)
+				results[index] = models.BatchResult{Todo: todo, Err: err}
+
+				return err
+			})
+			if err != nil && batch.Mode != models.BatchModeBestEffort {
+				failedIndex = index
+				return errBatchFailed
+			}
+		}
+
+		return nil
+	})
+	if errors.Is(err, errBatchFailed) {
+		for index := range results {
+			if index != failedIndex {
+				results[index] = models.BatchResult{Err: models.ErrRolledBack}
+			}
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to execute the batch: %w", err)
+	}
+
+	return results, nil
+}
+
+func (useCase TodoRecord) executeOperation(
+	baseURL *url.URL,
+	operation models.BatchOperation,
+	actor string,
+) (*models.PresentationTodoRecord, error) {
+	var presentationTodo models.PresentationTodoRecord
+	var err error
+	switch operation.Action {
+	case models.BatchActionCreate:
+		presentationTodo, err = useCase.Create(baseURL, *operation.Todo, actor)
+	case models.BatchActionUpdate:
+		presentationTodo, err = useCase.Update(
+			baseURL,
+			operation.ID,
+			*operation.Todo,
+			operation.Version,
+			operation.Cascade,
+			operation.Force,
+			actor,
+		)
+	case models.BatchActionPatch:
+		presentationTodo, err = useCase.Patch(
+			baseURL,
+			operation.ID,
+			*operation.Patch,
+			operation.Version,
+			operation.Cascade,
+			operation.Force,
+			actor,
+		)
+	case models.BatchActionDelete:
+		return nil, useCase.DeleteSingle(
+			operation.ID,
+			operation.Version,
+			operation.Cascade,
+			actor,
+		)
+	default:
+		return nil,
+			fmt.Errorf("%w: unknown action %q", models.ErrInvalid, operation.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &presentationTodo, nil
+}
+
+// makeTree reads the descendants of the to-do record recursively.
+func (useCase TodoRecord) makeTree(baseURL *url.URL, todo models.TodoRecord) (
+	models.PresentationTodoRecord,
+	error,
+) {
+	presentationTodo := models.NewPresentationTodoRecord(baseURL, todo)
+	if todo.ChildCount == 0 {
+		return presentationTodo, nil
+	}
+
+	children, err := useCase.Storage.GetAll(models.Query{
+		ParentID: todo.ID,
+		Sort:     useCase.DefaultSort,
+	})
+	if err != nil {
+		return models.PresentationTodoRecord{},
+			fmt.Errorf("unable to get the children: %w", err)
+	}
+
+	for _, child := range children {
+		presentationChild, err := useCase.makeTree(baseURL, child)
+		if err != nil {
+			return models.PresentationTodoRecord{}, err
+		}
+
+		presentationTodo.Children =
+			append(presentationTodo.Children, presentationChild)
+	}
+
+	return presentationTodo, nil
+}
+
+// moveToInbox patches each matched to-do record separately,
+// so each of them gets its own history record.
+func (useCase TodoRecord) moveToInbox(query models.Query, actor string) error {
+	todos, err := useCase.Storage.GetAll(query)
+	if err != nil {
+		return fmt.Errorf("unable to get the to-do records: %w", err)
+	}
+
+	inboxListID := 0
+	for _, todo := range todos {
+		_, err := useCase.modify(
+			todo.ID,
+			models.HistoryActionPatch,
+			actor,
+			func(
+				storage TodoRecordStorage,
+				oldTodo models.TodoRecord,
+			) (models.TodoRecord, error) {
+				todoPatch := models.TodoRecordPatch{ListID: &inboxListID}
+				return storage.Patch(oldTodo.ID, todoPatch, 0)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"unable to move the to-do record #%d to the inbox: %w",
+				todo.ID,
+				err,
+			)
+		}
 	}
 
 	return nil
 }
+
+// modify records the change of the existing to-do record to the history.
+func (useCase TodoRecord) modify(
+	id int,
+	action string,
+	actor string,
+	handler func(
+		storage TodoRecordStorage,
+		oldTodo models.TodoRecord,
+	) (models.TodoRecord, error),
+) (models.TodoRecord, error) {
+	var todo models.TodoRecord
+	err := useCase.Storage.InTransaction(func(storage TodoRecordStorage) error {
+		oldTodo, err := storage.GetSingle(id)
+		if err != nil {
+			return err
+		}
+
+		todo, err = handler(storage, oldTodo)
+		if err != nil {
+			return err
+		}
+
+		return storage.AddHistoryRecord(models.HistoryRecord{
+			TodoRecordID: id,
+			Action:       action,
+			OldValue:     &oldTodo,
+			NewValue:     &todo,
+			Actor:        actor,
+		})
+	})
+	if err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	return todo, nil
+}
+
+// checkParent walks up the ancestors of the to-do record starting
+// from its parent; the zero ID means a new to-do record.
+func checkParent(storage TodoRecordStorage, id int, parentID int) error {
+	for ancestorID := parentID; ancestorID != 0; {
+		if ancestorID == id {
+			return fmt.Errorf(
+				"%w: the parent #%d is the to-do record itself or its descendant",
+				models.ErrConflict,
+				parentID,
+			)
+		}
+
+		ancestor, err := storage.GetSingle(ancestorID)
+		if err != nil {
+			if errors.Is(err, models.ErrNotFound) {
+				return fmt.Errorf(
+					"%w: the parent #%d or its ancestor is missed or trashed",
+					models.ErrConflict,
+					parentID,
+				)
+			}
+
+			return fmt.Errorf("unable to get the ancestor: %w", err)
+		}
+
+		ancestorID = ancestor.ParentID
+	}
+
+	return nil
+}
+
+// checkBlocker walks the blockers of the blocker to-do record
+// including the trashed ones, so their restoring can't make a cycle.
+func checkBlocker(storage TodoRecordStorage, id int, blockerID int) error {
+	if _, err := storage.GetSingle(blockerID); err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return fmt.Errorf(
+				"%w: the blocker #%d is missed or trashed",
+				models.ErrConflict,
+				blockerID,
+			)
+		}
+
+		return fmt.Errorf("unable to get the blocker: %w", err)
+	}
+
+	visitedIDs := map[int]struct{}{}
+	pendingIDs := []int{blockerID}
+	for len(pendingIDs) != 0 {
+		currentID := pendingIDs[len(pendingIDs)-1]
+		pendingIDs = pendingIDs[:len(pendingIDs)-1]
+		if currentID == id {
+			return fmt.Errorf(
+				"%w: the blocker #%d is the to-do record itself or blocked by it",
+				models.ErrConflict,
+				blockerID,
+			)
+		}
+		if _, ok := visitedIDs[currentID]; ok {
+			continue
+		}
+		visitedIDs[currentID] = struct{}{}
+
+		blockerIDs, err := storage.GetBlockerIDs(currentID)
+		if err != nil {
+			return fmt.Errorf("unable to get the blockers: %w", err)
+		}
+
+		pendingIDs = append(pendingIDs, blockerIDs...)
+	}
+
+	return nil
+}
+
+// checkCompletion refuses the completion of the to-do record
+// with open blockers unless it's forced.
+func checkCompletion(
+	oldTodo models.TodoRecord,
+	todo models.TodoRecord,
+	force bool,
+) error {
+	if force || oldTodo.Completed || !todo.Completed || !todo.Blocked {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: the to-do record #%d has open blockers among %v;"+
+			" complete them first or use the force parameter",
+		models.ErrBlocked,
+		todo.ID,
+		todo.BlockerIDs,
+	)
+}
+
+// complete handles the completion of the to-do record:
+// it moves the recurrence and, if requested, completes the descendants.
+func (useCase TodoRecord) complete(
+	storage TodoRecordStorage,
+	oldTodo models.TodoRecord,
+	todo models.TodoRecord,
+	cascade bool,
+	force bool,
+	actor string,
+) (models.TodoRecord, error) {
+	if err := checkCompletion(oldTodo, todo, force); err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	todo, err := completeOccurrence(storage, oldTodo, todo, actor)
+	if err != nil || !cascade || !todo.Completed || todo.ChildCount == 0 {
+		return todo, err
+	}
+
+	err = useCase.completeChildren(storage, todo.ID, force, actor)
+	if err != nil {
+		return models.TodoRecord{}, err
+	}
+
+	// reread the to-do record to update its children counts
+	return storage.GetSingle(todo.ID)
+}
+
+// completeChildren records the completion of each descendant
+// to the history.
+func (useCase TodoRecord) completeChildren(
+	storage TodoRecordStorage,
+	id int,
+	force bool,
+	actor string,
+) error {
+	children, err := storage.GetAll(
+		models.Query{ParentID: id, Sort: useCase.DefaultSort},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to get the children: %w", err)
+	}
+
+	completed := true
+	for _, child := range children {
+		if !child.Completed {
+			oldChild := child
+			child, err = storage.Patch(
+				child.ID,
+				models.TodoRecordPatch{Completed: &completed},
+				0,
+			)
+			if err != nil {
+				return fmt.Errorf("unable to complete the child: %w", err)
+			}
+
+			if err := checkCompletion(oldChild, child, force); err != nil {
+				return err
+			}
+
+			child, err = completeOccurrence(storage, oldChild, child, actor)
+			if err != nil {
+				return err
+			}
+
+			err = storage.AddHistoryRecord(models.HistoryRecord{
+				TodoRecordID: child.ID,
+				Action:       models.HistoryActionPatch,
+				OldValue:     &oldChild,
+				NewValue:     &child,
+				Actor:        actor,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if child.ChildCount != 0 {
+			err := useCase.completeChildren(storage, child.ID, force, actor)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// deleteWithChildren deletes the descendants before the to-do record
+// and records each deletion to the history.
+func (useCase TodoRecord) deleteWithChildren(
+	storage TodoRecordStorage,
+	todo models.TodoRecord,
+	version int,
+	cascade bool,
+	actor string,
+) error {
+	if todo.ChildCount != 0 {
+		if !cascade {
+			return fmt.Errorf(
+				"%w: the to-do record #%d has children",
+				models.ErrConflict,
+				todo.ID,
+			)
+		}
+
+		children, err := storage.GetAll(
+			models.Query{ParentID: todo.ID, Sort: useCase.DefaultSort},
+		)
+		if err != nil {
+			return fmt.Errorf("unable to get the children: %w", err)
+		}
+
+		for _, child := range children {
+			err := useCase.deleteWithChildren(storage, child, 0, true, actor)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := storage.DeleteSingle(todo.ID, version); err != nil {
+		return err
+	}
+
+	return storage.AddHistoryRecord(models.HistoryRecord{
+		TodoRecordID: todo.ID,
+		Action:       models.HistoryActionDelete,
+		OldValue:     &todo,
+		Actor:        actor,
+	})
+}
+
+// completeOccurrence creates the next occurrence of the just completed
+// recurring to-do record and makes the completed one non-recurring,
+// so its repeated completion doesn't create the occurrence again.
+func completeOccurrence(
+	storage TodoRecordStorage,
+	oldTodo models.TodoRecord,
+	todo models.TodoRecord,
+	actor string,
+) (models.TodoRecord, error) {
+	if oldTodo.Completed || !todo.Completed || todo.Recurrence == "" {
+		return todo, nil
+	}
+
+	recurrence, err := models.ParseRecurrence(todo.Recurrence)
+	if err != nil {
+		return models.TodoRecord{}, fmt.Errorf(
+			"%w: unable to parse the recurrence: %v",
+			models.ErrInvalid,
+			err,
+		)
+	}
+
+	if date, nextRecurrence, ok := recurrence.Next(todo.Date); ok {
+		nextTodo := moveToDate(models.TodoRecord{
+			Date:       todo.Date,
+			DueAt:      todo.DueAt,
+			Title:      todo.Title,
+			Notes:      todo.Notes,
+			Order:      todo.Order,
+			Priority:   todo.Priority,
+			Tags:       todo.Tags,
+			ListID:     todo.ListID,
+			ParentID:   todo.ParentID,
+			Recurrence: nextRecurrence.String(),
+		}, date)
+		nextTodo, err = storage.Create(nextTodo)
+		if err != nil {
+			return models.TodoRecord{},
+				fmt.Errorf("unable to create the next occurrence: %w", err)
+		}
+
+		err = storage.AddHistoryRecord(models.HistoryRecord{
+			TodoRecordID: nextTodo.ID,
+			Action:       models.HistoryActionCreate,
+			NewValue:     &nextTodo,
+			Actor:        actor,
+		})
+		if err != nil {
+			return models.TodoRecord{}, err
+		}
+	}
+
+	noRecurrence := ""
+	return storage.Patch(
+		todo.ID,
+		models.TodoRecordPatch{Recurrence: &noRecurrence},
+		0,
+	)
+}
+
+// moveToDate shifts the due time of the to-do record along with its date.
+func moveToDate(todo models.TodoRecord, date time.Time) models.TodoRecord {
+	if todo.DueAt != nil {
+		dueAt := todo.DueAt.Add(date.Sub(todo.Date))
+		todo.DueAt = &dueAt
+	}
+
+	todo.Date = date
+	return todo
+}
@@ -4,14 +4,17 @@ import (
 	"net/url"
 	"testing"
 	"testing/iotest"
+	"time"
 
+	utilmodels "github.com/irenicaa/go-http-utils/models"
 	"github.com/irenicaa/go-todo-backend/v2/models"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestTodoRecord_GetAll(t *testing.T) {
 	type fields struct {
-		Storage TodoRecordStorage
+		Storage     TodoRecordStorage
+		DefaultSort []models.SortField
 	}
 	type args struct {
 		baseURL *url.URL
@@ -19,11 +22,12 @@ func TestTodoRecord_GetAll(t *testing.T) {
 	}
 
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    []models.PresentationTodoRecord
-		wantErr assert.ErrorAssertionFunc
+		name         string
+		fields       fields
+		args         args
+		want         []models.PresentationTodoRecord
+		wantPageInfo models.PageInfo
+		wantErr      assert.ErrorAssertionFunc
 	}{
 		{
 			name: "success without to-do records",
@@ -33,6 +37,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 					storage.InnerMock.
 						On("GetAll", models.Query{}).
 						Return([]models.TodoRecord(nil), nil)
+					storage.InnerMock.On("Count", models.Query{}).Return(0, nil)
 
 					return storage
 				}(),
@@ -40,8 +45,9 @@ func TestTodoRecord_GetAll(t *testing.T) {
 			args: args{
 				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
 			},
-			want:    []models.PresentationTodoRecord{},
-			wantErr: assert.NoError,
+			want:         []models.PresentationTodoRecord{},
+			wantPageInfo: models.PageInfo{TotalCount: 0},
+			wantErr:      assert.NoError,
 		},
 		{
 			name: "success with to-do records",
@@ -64,6 +70,7 @@ func TestTodoRecord_GetAll(t *testing.T) {
 
 					storage := &MockStorage{}
 					storage.InnerMock.On("GetAll", models.Query{}).Return(todos, nil)
+					storage.InnerMock.On("Count", models.Query{}).Return(2, nil)
 
 					return storage
 				}(),
@@ -77,18 +84,219 @@ func TestTodoRecord_GetAll(t *testing.T) {
 					Title:     "test",
 					Completed: true,
 					Order:     12,
+					Tags:      []string{},
 				},
 				{
 					URL:       "https://example.com/api/v1/todos/23",
 					Title:     "test",
 					Completed: true,
 					Order:     42,
+					Tags:      []string{},
+				},
+			},
+			wantPageInfo: models.PageInfo{TotalCount: 2},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "success in the cursor mode with the next page",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					todos := []models.TodoRecord{
+						{
+							ID:    5,
+							Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+							Title: "test",
+							Order: 12,
+						},
+						{
+							ID:    23,
+							Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+							Title: "test",
+							Order: 42,
+						},
+					}
+
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("GetAll", models.Query{
+							Pagination: models.Pagination{
+								PageSize: 2,
+								Cursor:   models.Cursor{Order: 5, ID: 2},
+							},
+						}).
+						Return(todos, nil)
+					storage.InnerMock.
+						On("Count", models.Query{
+							Pagination: models.Pagination{
+								PageSize: 1,
+								Cursor:   models.Cursor{Order: 5, ID: 2},
+							},
+						}).
+						Return(5, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				query: models.Query{
+					Pagination: models.Pagination{
+						PageSize: 1,
+						Cursor:   models.Cursor{Order: 5, ID: 2},
+					},
+				},
+			},
+			want: []models.PresentationTodoRecord{
+				{
+					URL: "https://example.com/api/v1/todos/5",
+					Date: utilmodels.Date(
+						time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					),
+					Title: "test",
+					Order: 12,
+					Tags:  []string{},
 				},
 			},
+			wantPageInfo: models.PageInfo{
+				TotalCount: 5,
+				NextCursor: models.Cursor{
+					Date: utilmodels.Date(
+						time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					),
+					Order: 12,
+					ID:    5,
+				}.String(),
+			},
 			wantErr: assert.NoError,
 		},
 		{
-			name: "error",
+			name: "success in the cursor mode without the next page",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					todos := []models.TodoRecord{
+						{
+							ID:    5,
+							Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+							Title: "test",
+							Order: 12,
+						},
+					}
+
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("GetAll", models.Query{
+							Pagination: models.Pagination{PageSize: 3},
+						}).
+						Return(todos, nil)
+					storage.InnerMock.
+						On("Count", models.Query{
+							Pagination: models.Pagination{PageSize: 2},
+						}).
+						Return(1, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				query: models.Query{
+					Pagination: models.Pagination{PageSize: 2},
+				},
+			},
+			want: []models.PresentationTodoRecord{
+				{
+					URL: "https://example.com/api/v1/todos/5",
+					Date: utilmodels.Date(
+						time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					),
+					Title: "test",
+					Order: 12,
+					Tags:  []string{},
+				},
+			},
+			wantPageInfo: models.PageInfo{TotalCount: 1},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "success in the cursor mode with the configured default sort",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					todos := []models.TodoRecord{
+						{
+							ID:       5,
+							Date:     time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+							Title:    "test",
+							Order:    12,
+							Priority: models.PriorityHigh,
+						},
+						{
+							ID:    23,
+							Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+							Title: "test",
+							Order: 42,
+						},
+					}
+
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("GetAll", models.Query{
+							Sort: []models.SortField{
+								{Name: models.SortByPriority, Descending: true},
+								{Name: models.SortByDate, Descending: true},
+								{Name: models.SortByOrder},
+								{Name: models.SortByID},
+							},
+							Pagination: models.Pagination{PageSize: 2},
+						}).
+						Return(todos, nil)
+					storage.InnerMock.
+						On("Count", models.Query{
+							Pagination: models.Pagination{PageSize: 1},
+						}).
+						Return(5, nil)
+
+					return storage
+				}(),
+				DefaultSort: []models.SortField{
+					{Name: models.SortByPriority, Descending: true},
+					{Name: models.SortByDate, Descending: true},
+					{Name: models.SortByOrder},
+					{Name: models.SortByID},
+				},
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				query: models.Query{
+					Pagination: models.Pagination{PageSize: 1},
+				},
+			},
+			want: []models.PresentationTodoRecord{
+				{
+					URL: "https://example.com/api/v1/todos/5",
+					Date: utilmodels.Date(
+						time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					),
+					Title:    "test",
+					Order:    12,
+					Priority: models.PriorityHigh,
+					Tags:     []string{},
+				},
+			},
+			wantPageInfo: models.PageInfo{
+				TotalCount: 5,
+				NextCursor: models.Cursor{
+					Date: utilmodels.Date(
+						time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					),
+					Order:    12,
+					ID:       5,
+					Priority: models.PriorityHigh,
+				}.String(),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error on getting",
 			fields: fields{
 				Storage: func() TodoRecordStorage {
 					storage := &MockStorage{}
@@ -105,16 +313,40 @@ func TestTodoRecord_GetAll(t *testing.T) {
 			want:    nil,
 			wantErr: assert.Error,
 		},
+		{
+			name: "error on counting",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("GetAll", models.Query{}).
+						Return([]models.TodoRecord(nil), nil)
+					storage.InnerMock.
+						On("Count", models.Query{}).
+						Return(0, iotest.ErrTimeout)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			useCase := TodoRecord{
-				Storage: tt.fields.Storage,
+				Storage:     tt.fields.Storage,
+				DefaultSort: tt.fields.DefaultSort,
 			}
-			got, err := useCase.GetAll(tt.args.baseURL, tt.args.query)
+			got, gotPageInfo, err :=
+				useCase.GetAll(tt.args.baseURL, tt.args.query)
 
 			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
 			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPageInfo, gotPageInfo)
 			tt.wantErr(t, err)
 		})
 	}
@@ -162,6 +394,7 @@ func TestTodoRecord_GetSingle(t *testing.T) {
 				Title:     "test",
 				Completed: true,
 				Order:     42,
+				Tags:      []string{},
 			},
 			wantErr: assert.NoError,
 		},
@@ -184,6 +417,27 @@ func TestTodoRecord_GetSingle(t *testing.T) {
 			want:    models.PresentationTodoRecord{},
 			wantErr: assert.Error,
 		},
+		{
+			name: "error with a not found to-do record",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("GetSingle", 23).
+						Return(models.TodoRecord{}, models.ErrNotFound)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      23,
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrNotFound, msgAndArgs...)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -199,6 +453,156 @@ func TestTodoRecord_GetSingle(t *testing.T) {
 	}
 }
 
+func TestTodoRecord_GetTree(t *testing.T) {
+	type fields struct {
+		Storage TodoRecordStorage
+	}
+	type args struct {
+		baseURL *url.URL
+		id      int
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    models.PresentationTodoRecord
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					todo := models.TodoRecord{ID: 23, Title: "test", ChildCount: 2}
+					children := []models.TodoRecord{
+						{
+							ID:                  24,
+							Title:               "child",
+							ParentID:            23,
+							ChildCount:          1,
+							CompletedChildCount: 1,
+						},
+						{ID: 26, Title: "child2", Completed: true, ParentID: 23},
+					}
+					grandchildren := []models.TodoRecord{
+						{ID: 25, Title: "grandchild", Completed: true, ParentID: 24},
+					}
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("GetSingle", 23).Return(todo, nil)
+					storage.InnerMock.
+						On("GetAll", models.Query{ParentID: 23}).
+						Return(children, nil)
+					storage.InnerMock.
+						On("GetAll", models.Query{ParentID: 24}).
+						Return(grandchildren, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      23,
+			},
+			want: models.PresentationTodoRecord{
+				URL:        "https://example.com/api/v1/todos/23",
+				Title:      "test",
+				Tags:       []string{},
+				ChildCount: 2,
+				ChildrenCompletion: func() *float64 {
+					childrenCompletion := 0.0
+					return &childrenCompletion
+				}(),
+				Children: []models.PresentationTodoRecord{
+					{
+						URL:        "https://example.com/api/v1/todos/24",
+						Title:      "child",
+						Tags:       []string{},
+						ParentID:   23,
+						ChildCount: 1,
+						ChildrenCompletion: func() *float64 {
+							childrenCompletion := 1.0
+							return &childrenCompletion
+						}(),
+						Children: []models.PresentationTodoRecord{
+							{
+								URL:       "https://example.com/api/v1/todos/25",
+								Title:     "grandchild",
+								Completed: true,
+								Tags:      []string{},
+								ParentID:  24,
+							},
+						},
+					},
+					{
+						URL:       "https://example.com/api/v1/todos/26",
+						Title:     "child2",
+						Completed: true,
+						Tags:      []string{},
+						ParentID:  23,
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with the children",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					todo := models.TodoRecord{ID: 23, Title: "test", ChildCount: 2}
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("GetSingle", 23).Return(todo, nil)
+					storage.InnerMock.
+						On("GetAll", models.Query{ParentID: 23}).
+						Return([]models.TodoRecord(nil), iotest.ErrTimeout)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      23,
+			},
+			want:    models.PresentationTodoRecord{},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with a not found to-do record",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("GetSingle", 23).
+						Return(models.TodoRecord{}, models.ErrNotFound)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      23,
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrNotFound, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := TodoRecord{
+				Storage: tt.fields.Storage,
+			}
+			got, err := useCase.GetTree(tt.args.baseURL, tt.args.id)
+
+			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
 func TestTodoRecord_Create(t *testing.T) {
 	type fields struct {
 		Storage TodoRecordStorage
@@ -206,6 +610,7 @@ func TestTodoRecord_Create(t *testing.T) {
 	type args struct {
 		baseURL          *url.URL
 		presentationTodo models.PresentationTodoRecord
+		actor            string
 	}
 
 	tests := []struct {
@@ -220,13 +625,27 @@ func TestTodoRecord_Create(t *testing.T) {
 			fields: fields{
 				Storage: func() TodoRecordStorage {
 					todo := models.TodoRecord{
+						Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
 						Title:     "test",
 						Completed: true,
 						Order:     23,
 					}
 
+					createdTodo := todo
+					createdTodo.ID = 42
+					createdTodo.Version = 1
+
 					storage := &MockStorage{}
-					storage.InnerMock.On("Create", todo).Return(42, nil)
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("Create", todo).Return(createdTodo, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 42,
+							Action:       models.HistoryActionCreate,
+							NewValue:     &createdTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
 
 					return storage
 				}(),
@@ -234,16 +653,21 @@ func TestTodoRecord_Create(t *testing.T) {
 			args: args{
 				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
 				presentationTodo: models.PresentationTodoRecord{
+					Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
 					Title:     "test",
 					Completed: true,
 					Order:     23,
 				},
+				actor: "admin",
 			},
 			want: models.PresentationTodoRecord{
 				URL:       "https://example.com/api/v1/todos/42",
+				Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
 				Title:     "test",
 				Completed: true,
 				Order:     23,
+				Tags:      []string{},
+				Version:   1,
 			},
 			wantErr: assert.NoError,
 		},
@@ -252,13 +676,17 @@ func TestTodoRecord_Create(t *testing.T) {
 			fields: fields{
 				Storage: func() TodoRecordStorage {
 					todo := models.TodoRecord{
+						Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
 						Title:     "test",
 						Completed: true,
 						Order:     23,
 					}
 
 					storage := &MockStorage{}
-					storage.InnerMock.On("Create", todo).Return(0, iotest.ErrTimeout)
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("Create", todo).
+						Return(models.TodoRecord{}, iotest.ErrTimeout)
 
 					return storage
 				}(),
@@ -266,21 +694,74 @@ func TestTodoRecord_Create(t *testing.T) {
 			args: args{
 				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
 				presentationTodo: models.PresentationTodoRecord{
+					Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
 					Title:     "test",
 					Completed: true,
 					Order:     23,
 				},
+				actor: "admin",
 			},
 			want:    models.PresentationTodoRecord{},
 			wantErr: assert.Error,
 		},
+		{
+			name: "error with a trashed parent",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetSingle", 12).
+						Return(models.TodoRecord{}, models.ErrNotFound)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				presentationTodo: models.PresentationTodoRecord{
+					Date:     utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					Title:    "test",
+					ParentID: 12,
+				},
+				actor: "admin",
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrConflict, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with validation",
+			fields: fields{
+				Storage: &MockStorage{},
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				presentationTodo: models.PresentationTodoRecord{
+					Title:     " ",
+					Completed: true,
+					Order:     -1,
+				},
+				actor: "admin",
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrInvalid, msgAndArgs...)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			useCase := TodoRecord{
 				Storage: tt.fields.Storage,
+				Limits:  models.DefaultValidationLimits,
 			}
-			got, err := useCase.Create(tt.args.baseURL, tt.args.presentationTodo)
+			got, err := useCase.Create(
+				tt.args.baseURL,
+				tt.args.presentationTodo,
+				tt.args.actor,
+			)
 
 			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
 			assert.Equal(t, tt.want, got)
@@ -297,6 +778,10 @@ func TestTodoRecord_Update(t *testing.T) {
 		baseURL          *url.URL
 		id               int
 		presentationTodo models.PresentationTodoRecord
+		version          int
+		cascade          bool
+		force            bool
+		actor            string
 	}
 
 	tests := []struct {
@@ -311,13 +796,35 @@ func TestTodoRecord_Update(t *testing.T) {
 			fields: fields{
 				Storage: func() TodoRecordStorage {
 					todo := models.TodoRecord{
+						Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
 						Title:     "test",
 						Completed: true,
 						Order:     23,
 					}
+					updatedTodo := todo
+					updatedTodo.ID = 42
+					updatedTodo.Version = 6
+
+					oldTodo := models.TodoRecord{
+						ID:      42,
+						Date:    time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title:   "test",
+						Version: 5,
+					}
 
 					storage := &MockStorage{}
-					storage.InnerMock.On("Update", 42, todo).Return(nil)
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 42).Return(oldTodo, nil)
+					storage.InnerMock.On("Update", 42, todo, 5).Return(updatedTodo, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 42,
+							Action:       models.HistoryActionUpdate,
+							OldValue:     &oldTodo,
+							NewValue:     &updatedTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
 
 					return storage
 				}(),
@@ -326,16 +833,22 @@ func TestTodoRecord_Update(t *testing.T) {
 				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
 				id:      42,
 				presentationTodo: models.PresentationTodoRecord{
+					Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
 					Title:     "test",
 					Completed: true,
 					Order:     23,
 				},
+				version: 5,
+				actor:   "admin",
 			},
 			want: models.PresentationTodoRecord{
 				URL:       "https://example.com/api/v1/todos/42",
+				Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
 				Title:     "test",
 				Completed: true,
 				Order:     23,
+				Tags:      []string{},
+				Version:   6,
 			},
 			wantErr: assert.NoError,
 		},
@@ -344,13 +857,20 @@ func TestTodoRecord_Update(t *testing.T) {
 			fields: fields{
 				Storage: func() TodoRecordStorage {
 					todo := models.TodoRecord{
+						Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
 						Title:     "test",
 						Completed: true,
 						Order:     23,
 					}
 
 					storage := &MockStorage{}
-					storage.InnerMock.On("Update", 42, todo).Return(iotest.ErrTimeout)
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetSingle", 42).
+						Return(models.TodoRecord{ID: 42, Version: 6}, nil)
+					storage.InnerMock.
+						On("Update", 42, todo, 5).
+						Return(models.TodoRecord{}, models.ErrVersionMismatch)
 
 					return storage
 				}(),
@@ -359,22 +879,35 @@ func TestTodoRecord_Update(t *testing.T) {
 				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
 				id:      42,
 				presentationTodo: models.PresentationTodoRecord{
+					Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
 					Title:     "test",
 					Completed: true,
 					Order:     23,
 				},
+				version: 5,
+				actor:   "admin",
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrVersionMismatch, msgAndArgs...)
 			},
-			want:    models.PresentationTodoRecord{},
-			wantErr: assert.Error,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			useCase := TodoRecord{
 				Storage: tt.fields.Storage,
+				Limits:  models.DefaultValidationLimits,
 			}
-			got, err :=
-				useCase.Update(tt.args.baseURL, tt.args.id, tt.args.presentationTodo)
+			got, err := useCase.Update(
+				tt.args.baseURL,
+				tt.args.id,
+				tt.args.presentationTodo,
+				tt.args.version,
+				tt.args.cascade,
+				tt.args.force,
+				tt.args.actor,
+			)
 
 			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
 			assert.Equal(t, tt.want, got)
@@ -391,6 +924,10 @@ func TestTodoRecord_Patch(t *testing.T) {
 		baseURL   *url.URL
 		id        int
 		todoPatch models.TodoRecordPatch
+		version   int
+		cascade   bool
+		force     bool
+		actor     string
 	}
 
 	tests := []struct {
@@ -404,21 +941,36 @@ func TestTodoRecord_Patch(t *testing.T) {
 			name: "success",
 			fields: fields{
 				Storage: func() TodoRecordStorage {
-					todo := models.TodoRecord{
-						ID:        23,
-						Title:     "test",
-						Completed: true,
-						Order:     42,
-					}
+					title := "test2"
+					todoPatch := models.TodoRecordPatch{Title: &title}
 					patchedTodo := models.TodoRecord{
+						ID:        23,
+						Date:      time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
 						Title:     "test2",
 						Completed: true,
 						Order:     42,
+						Version:   6,
 					}
 
+					oldTodo := patchedTodo
+					oldTodo.Title = "test"
+					oldTodo.Version = 5
+
 					storage := &MockStorage{}
-					storage.InnerMock.On("GetSingle", 23).Return(todo, nil)
-					storage.InnerMock.On("Update", 23, patchedTodo).Return(nil)
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 23).Return(oldTodo, nil)
+					storage.InnerMock.
+						On("Patch", 23, todoPatch, 5).
+						Return(patchedTodo, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 23,
+							Action:       models.HistoryActionPatch,
+							OldValue:     &oldTodo,
+							NewValue:     &patchedTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
 
 					return storage
 				}(),
@@ -432,23 +984,98 @@ func TestTodoRecord_Patch(t *testing.T) {
 						return &title
 					}(),
 				},
+				version: 5,
+				actor:   "admin",
 			},
 			want: models.PresentationTodoRecord{
 				URL:       "https://example.com/api/v1/todos/23",
+				Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
 				Title:     "test2",
 				Completed: true,
 				Order:     42,
+				Tags:      []string{},
+				Version:   6,
 			},
 			wantErr: assert.NoError,
 		},
 		{
-			name: "error with getting",
+			name: "success with completing of a recurring to-do record",
 			fields: fields{
 				Storage: func() TodoRecordStorage {
+					completed := true
+					todoPatch := models.TodoRecordPatch{Completed: &completed}
+					oldTodo := models.TodoRecord{
+						ID:    23,
+						Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title: "test",
+						Notes: "*notes*",
+						Order: 42,
+						Tags:  []string{"one"},
+						DueAt: func() *time.Time {
+							dueAt := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+							return &dueAt
+						}(),
+						Recurrence: "FREQ=WEEKLY;COUNT=3",
+						Version:    5,
+					}
+
+					patchedTodo := oldTodo
+					patchedTodo.Completed = true
+					patchedTodo.Version = 6
+
+					nextTodo := models.TodoRecord{
+						Date:  time.Date(2006, time.January, 9, 0, 0, 0, 0, time.UTC),
+						Title: "test",
+						Notes: "*notes*",
+						Order: 42,
+						Tags:  []string{"one"},
+						DueAt: func() *time.Time {
+							dueAt := time.Date(2006, time.January, 9, 15, 4, 5, 0, time.UTC)
+							return &dueAt
+						}(),
+						Recurrence: "FREQ=WEEKLY;COUNT=2",
+					}
+					createdNextTodo := nextTodo
+					createdNextTodo.ID = 24
+					createdNextTodo.Version = 1
+
+					noRecurrence := ""
+					finalTodo := patchedTodo
+					finalTodo.Recurrence = ""
+					finalTodo.Version = 7
+
 					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 23).Return(oldTodo, nil)
 					storage.InnerMock.
-						On("GetSingle", 23).
-						Return(models.TodoRecord{}, iotest.ErrTimeout)
+						On("Patch", 23, todoPatch, 5).
+						Return(patchedTodo, nil)
+					storage.InnerMock.On("Create", nextTodo).Return(createdNextTodo, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 24,
+							Action:       models.HistoryActionCreate,
+							NewValue:     &createdNextTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
+					storage.InnerMock.
+						On(
+							"Patch",
+							23,
+							models.TodoRecordPatch{Recurrence: &noRecurrence},
+							0,
+						).
+						Return(finalTodo, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 23,
+							Action:       models.HistoryActionPatch,
+							OldValue:     &oldTodo,
+							NewValue:     &finalTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
 
 					return storage
 				}(),
@@ -457,34 +1084,281 @@ func TestTodoRecord_Patch(t *testing.T) {
 				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
 				id:      23,
 				todoPatch: models.TodoRecordPatch{
-					Title: func() *string {
-						title := "test2"
-						return &title
+					Completed: func() *bool {
+						completed := true
+						return &completed
 					}(),
 				},
+				version: 5,
+				actor:   "admin",
 			},
-			want:    models.PresentationTodoRecord{},
-			wantErr: assert.Error,
+			want: models.PresentationTodoRecord{
+				URL:  "https://example.com/api/v1/todos/23",
+				Date: utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				DueAt: func() *time.Time {
+					dueAt := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+					return &dueAt
+				}(),
+				Title:     "test",
+				Notes:     "*notes*",
+				Completed: true,
+				Order:     42,
+				Tags:      []string{"one"},
+				Version:   7,
+			},
+			wantErr: assert.NoError,
 		},
 		{
-			name: "error with updating",
+			name: "success with the cascade completion",
 			fields: fields{
 				Storage: func() TodoRecordStorage {
-					todo := models.TodoRecord{
-						ID:        23,
-						Title:     "test",
-						Completed: true,
-						Order:     42,
+					completed := true
+					todoPatch := models.TodoRecordPatch{Completed: &completed}
+					oldTodo := models.TodoRecord{
+						ID:         23,
+						Date:       time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title:      "test",
+						Version:    5,
+						ChildCount: 1,
 					}
-					patchedTodo := models.TodoRecord{
-						Title:     "test2",
-						Completed: true,
-						Order:     42,
+
+					patchedTodo := oldTodo
+					patchedTodo.Completed = true
+					patchedTodo.Version = 6
+
+					rereadTodo := patchedTodo
+					rereadTodo.CompletedChildCount = 1
+
+					oldChild := models.TodoRecord{
+						ID:       24,
+						Date:     time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title:    "child",
+						ParentID: 23,
+						Version:  1,
 					}
 
+					completedChild := oldChild
+					completedChild.Completed = true
+					completedChild.Version = 2
+
 					storage := &MockStorage{}
-					storage.InnerMock.On("GetSingle", 23).Return(todo, nil)
-					storage.InnerMock.On("Update", 23, patchedTodo).Return(iotest.ErrTimeout)
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 23).Return(oldTodo, nil).Once()
+					storage.InnerMock.
+						On("Patch", 23, todoPatch, 5).
+						Return(patchedTodo, nil)
+					storage.InnerMock.
+						On("GetAll", models.Query{ParentID: 23}).
+						Return([]models.TodoRecord{oldChild}, nil)
+					storage.InnerMock.
+						On("Patch", 24, todoPatch, 0).
+						Return(completedChild, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 24,
+							Action:       models.HistoryActionPatch,
+							OldValue:     &oldChild,
+							NewValue:     &completedChild,
+							Actor:        "admin",
+						}).
+						Return(nil)
+					storage.InnerMock.On("GetSingle", 23).Return(rereadTodo, nil).Once()
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 23,
+							Action:       models.HistoryActionPatch,
+							OldValue:     &oldTodo,
+							NewValue:     &rereadTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      23,
+				todoPatch: models.TodoRecordPatch{
+					Completed: func() *bool {
+						completed := true
+						return &completed
+					}(),
+				},
+				version: 5,
+				cascade: true,
+				actor:   "admin",
+			},
+			want: models.PresentationTodoRecord{
+				URL:        "https://example.com/api/v1/todos/23",
+				Date:       utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				Title:      "test",
+				Completed:  true,
+				Tags:       []string{},
+				Version:    6,
+				ChildCount: 1,
+				ChildrenCompletion: func() *float64 {
+					childrenCompletion := 1.0
+					return &childrenCompletion
+				}(),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the forced completion",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					completed := true
+					todoPatch := models.TodoRecordPatch{Completed: &completed}
+					oldTodo := models.TodoRecord{
+						ID:         23,
+						Date:       time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title:      "test",
+						Version:    5,
+						BlockerIDs: []int{12},
+						Blocked:    true,
+					}
+
+					patchedTodo := oldTodo
+					patchedTodo.Completed = true
+					patchedTodo.Version = 6
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 23).Return(oldTodo, nil)
+					storage.InnerMock.
+						On("Patch", 23, todoPatch, 5).
+						Return(patchedTodo, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 23,
+							Action:       models.HistoryActionPatch,
+							OldValue:     &oldTodo,
+							NewValue:     &patchedTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      23,
+				todoPatch: models.TodoRecordPatch{
+					Completed: func() *bool {
+						completed := true
+						return &completed
+					}(),
+				},
+				version: 5,
+				force:   true,
+				actor:   "admin",
+			},
+			want: models.PresentationTodoRecord{
+				URL:       "https://example.com/api/v1/todos/23",
+				Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				Title:     "test",
+				Completed: true,
+				Tags:      []string{},
+				BlockedBy: []int{12},
+				Blocked:   true,
+				Version:   6,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with the open blockers",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					completed := true
+					todoPatch := models.TodoRecordPatch{Completed: &completed}
+					oldTodo := models.TodoRecord{
+						ID:         23,
+						Version:    5,
+						BlockerIDs: []int{12},
+						Blocked:    true,
+					}
+
+					patchedTodo := oldTodo
+					patchedTodo.Completed = true
+					patchedTodo.Version = 6
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 23).Return(oldTodo, nil)
+					storage.InnerMock.
+						On("Patch", 23, todoPatch, 5).
+						Return(patchedTodo, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      23,
+				todoPatch: models.TodoRecordPatch{
+					Completed: func() *bool {
+						completed := true
+						return &completed
+					}(),
+				},
+				version: 5,
+				actor:   "admin",
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrBlocked, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a cycle",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetSingle", 23).
+						Return(models.TodoRecord{ID: 23, Version: 5}, nil)
+					storage.InnerMock.
+						On("GetSingle", 24).
+						Return(models.TodoRecord{ID: 24, ParentID: 23, Version: 1}, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      23,
+				todoPatch: models.TodoRecordPatch{
+					ParentID: func() *int {
+						parentID := 24
+						return &parentID
+					}(),
+				},
+				version: 5,
+				actor:   "admin",
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrConflict, msgAndArgs...)
+			},
+		},
+		{
+			name: "error",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					title := "test2"
+					todoPatch := models.TodoRecordPatch{Title: &title}
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetSingle", 23).
+						Return(models.TodoRecord{ID: 23, Version: 5}, nil)
+					storage.InnerMock.
+						On("Patch", 23, todoPatch, 5).
+						Return(models.TodoRecord{}, iotest.ErrTimeout)
 
 					return storage
 				}(),
@@ -498,17 +1372,50 @@ func TestTodoRecord_Patch(t *testing.T) {
 						return &title
 					}(),
 				},
+				version: 5,
+				actor:   "admin",
 			},
 			want:    models.PresentationTodoRecord{},
 			wantErr: assert.Error,
 		},
+		{
+			name: "error with validation",
+			fields: fields{
+				Storage: &MockStorage{},
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      23,
+				todoPatch: models.TodoRecordPatch{
+					Title: func() *string {
+						title := ""
+						return &title
+					}(),
+				},
+				version: 5,
+				actor:   "admin",
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrInvalid, msgAndArgs...)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			useCase := TodoRecord{
 				Storage: tt.fields.Storage,
+				Limits:  models.DefaultValidationLimits,
 			}
-			got, err := useCase.Patch(tt.args.baseURL, tt.args.id, tt.args.todoPatch)
+			got, err := useCase.Patch(
+				tt.args.baseURL,
+				tt.args.id,
+				tt.args.todoPatch,
+				tt.args.version,
+				tt.args.cascade,
+				tt.args.force,
+				tt.args.actor,
+			)
 
 			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
 			assert.Equal(t, tt.want, got)
@@ -521,34 +1428,126 @@ func TestTodoRecord_DeleteAll(t *testing.T) {
 	type fields struct {
 		Storage TodoRecordStorage
 	}
+	type args struct {
+		query models.Query
+		actor string
+	}
+
+	completed := true
 
 	tests := []struct {
 		name    string
 		fields  fields
+		args    args
+		want    int
 		wantErr assert.ErrorAssertionFunc
 	}{
 		{
 			name: "success",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					todos := []models.TodoRecord{
+						{ID: 12, Title: "test", Completed: true},
+						{ID: 23, Title: "test2", Completed: true},
+					}
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetAll", models.Query{Completed: &completed}).
+						Return(todos, nil)
+					storage.InnerMock.
+						On("DeleteAll", models.Query{IDs: []int{12, 23}}).
+						Return(2, nil)
+					for index := range todos {
+						storage.InnerMock.
+							On("AddHistoryRecord", models.HistoryRecord{
+								TodoRecordID: todos[index].ID,
+								Action:       models.HistoryActionDelete,
+								OldValue:     &todos[index],
+								Actor:        "admin",
+							}).
+							Return(nil)
+					}
+
+					return storage
+				}(),
+			},
+			args: args{
+				query: models.Query{Completed: &completed},
+				actor: "admin",
+			},
+			want:    2,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success without matched to-do records",
 			fields: fields{
 				Storage: func() TodoRecordStorage {
 					storage := &MockStorage{}
-					storage.InnerMock.On("DeleteAll").Return(nil)
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetAll", models.Query{Completed: &completed}).
+						Return([]models.TodoRecord(nil), nil)
 
 					return storage
 				}(),
 			},
+			args: args{
+				query: models.Query{Completed: &completed},
+				actor: "admin",
+			},
+			want:    0,
 			wantErr: assert.NoError,
 		},
+		{
+			name: "error with unmatched children",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					todos := []models.TodoRecord{
+						{ID: 12, Title: "test", Completed: true, ChildCount: 2},
+						{ID: 23, Title: "test2", Completed: true, ParentID: 12},
+					}
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetAll", models.Query{Completed: &completed}).
+						Return(todos, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				query: models.Query{Completed: &completed},
+				actor: "admin",
+			},
+			want: 0,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrConflict, msgAndArgs...)
+			},
+		},
 		{
 			name: "error",
 			fields: fields{
 				Storage: func() TodoRecordStorage {
 					storage := &MockStorage{}
-					storage.InnerMock.On("DeleteAll").Return(iotest.ErrTimeout)
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetAll", models.Query{Completed: &completed}).
+						Return([]models.TodoRecord{{ID: 12}}, nil)
+					storage.InnerMock.
+						On("DeleteAll", models.Query{IDs: []int{12}}).
+						Return(0, iotest.ErrTimeout)
 
 					return storage
 				}(),
 			},
+			args: args{
+				query: models.Query{Completed: &completed},
+				actor: "admin",
+			},
+			want:    0,
 			wantErr: assert.Error,
 		},
 	}
@@ -557,9 +1556,10 @@ func TestTodoRecord_DeleteAll(t *testing.T) {
 			useCase := TodoRecord{
 				Storage: tt.fields.Storage,
 			}
-			err := useCase.DeleteAll()
+			got, err := useCase.DeleteAll(tt.args.query, tt.args.actor)
 
 			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
 			tt.wantErr(t, err)
 		})
 	}
@@ -570,7 +1570,10 @@ func TestTodoRecord_DeleteSingle(t *testing.T) {
 		Storage TodoRecordStorage
 	}
 	type args struct {
-		id int
+		id      int
+		version int
+		cascade bool
+		actor   string
 	}
 
 	tests := []struct {
@@ -583,13 +1586,73 @@ func TestTodoRecord_DeleteSingle(t *testing.T) {
 			name: "success",
 			fields: fields{
 				Storage: func() TodoRecordStorage {
+					oldTodo := models.TodoRecord{ID: 42, Title: "test", Version: 5}
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 42).Return(oldTodo, nil)
+					storage.InnerMock.On("DeleteSingle", 42, 5).Return(nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 42,
+							Action:       models.HistoryActionDelete,
+							OldValue:     &oldTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
+
+					return storage
+				}(),
+			},
+			args:    args{id: 42, version: 5, actor: "admin"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the cascade deletion",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					oldTodo := models.TodoRecord{
+						ID:         42,
+						Title:      "test",
+						Version:    5,
+						ChildCount: 1,
+					}
+					oldChild := models.TodoRecord{
+						ID:       43,
+						Title:    "child",
+						ParentID: 42,
+						Version:  2,
+					}
+
 					storage := &MockStorage{}
-					storage.InnerMock.On("DeleteSingle", 42).Return(nil)
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 42).Return(oldTodo, nil)
+					storage.InnerMock.
+						On("GetAll", models.Query{ParentID: 42}).
+						Return([]models.TodoRecord{oldChild}, nil)
+					storage.InnerMock.On("DeleteSingle", 43, 0).Return(nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 43,
+							Action:       models.HistoryActionDelete,
+							OldValue:     &oldChild,
+							Actor:        "admin",
+						}).
+						Return(nil)
+					storage.InnerMock.On("DeleteSingle", 42, 5).Return(nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 42,
+							Action:       models.HistoryActionDelete,
+							OldValue:     &oldTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
 
 					return storage
 				}(),
 			},
-			args:    args{id: 42},
+			args:    args{id: 42, version: 5, cascade: true, actor: "admin"},
 			wantErr: assert.NoError,
 		},
 		{
@@ -597,23 +1660,1431 @@ func TestTodoRecord_DeleteSingle(t *testing.T) {
 			fields: fields{
 				Storage: func() TodoRecordStorage {
 					storage := &MockStorage{}
-					storage.InnerMock.On("DeleteSingle", 42).Return(iotest.ErrTimeout)
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetSingle", 42).
+						Return(models.TodoRecord{ID: 42, Version: 5}, nil)
+					storage.InnerMock.On("DeleteSingle", 42, 5).Return(iotest.ErrTimeout)
 
 					return storage
 				}(),
 			},
-			args:    args{id: 42},
+			args:    args{id: 42, version: 5, actor: "admin"},
 			wantErr: assert.Error,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			useCase := TodoRecord{
-				Storage: tt.fields.Storage,
-			}
-			err := useCase.DeleteSingle(tt.args.id)
+		{
+			name: "error with a version mismatch",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetSingle", 42).
+						Return(models.TodoRecord{ID: 42, Version: 6}, nil)
+					storage.InnerMock.
+						On("DeleteSingle", 42, 5).
+						Return(models.ErrVersionMismatch)
 
-			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
+					return storage
+				}(),
+			},
+			args: args{id: 42, version: 5, actor: "admin"},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrVersionMismatch, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with children",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetSingle", 42).
+						Return(models.TodoRecord{ID: 42, Version: 5, ChildCount: 1}, nil)
+
+					return storage
+				}(),
+			},
+			args: args{id: 42, version: 5, actor: "admin"},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrConflict, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a not found to-do record",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetSingle", 42).
+						Return(models.TodoRecord{}, models.ErrNotFound)
+
+					return storage
+				}(),
+			},
+			args: args{id: 42, version: 5, actor: "admin"},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrNotFound, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := TodoRecord{
+				Storage: tt.fields.Storage,
+			}
+			err := useCase.DeleteSingle(
+				tt.args.id,
+				tt.args.version,
+				tt.args.cascade,
+				tt.args.actor,
+			)
+
+			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestTodoRecord_Restore(t *testing.T) {
+	type fields struct {
+		Storage TodoRecordStorage
+	}
+	type args struct {
+		baseURL *url.URL
+		id      int
+		actor   string
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    models.PresentationTodoRecord
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					todo := models.TodoRecord{
+						ID:      42,
+						Date:    time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title:   "test",
+						Version: 5,
+					}
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("Restore", 42).Return(todo, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 42,
+							Action:       models.HistoryActionRestore,
+							NewValue:     &todo,
+							Actor:        "admin",
+						}).
+						Return(nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      42,
+				actor:   "admin",
+			},
+			want: models.PresentationTodoRecord{
+				URL:     "https://example.com/api/v1/todos/42",
+				Date:    utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				Title:   "test",
+				Tags:    []string{},
+				Version: 5,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a not found to-do record",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("Restore", 42).
+						Return(models.TodoRecord{}, models.ErrNotFound)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				id:      42,
+				actor:   "admin",
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrNotFound, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := TodoRecord{
+				Storage: tt.fields.Storage,
+			}
+			got, err := useCase.Restore(tt.args.baseURL, tt.args.id, tt.args.actor)
+
+			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestTodoRecord_AddDependency(t *testing.T) {
+	type fields struct {
+		Storage TodoRecordStorage
+	}
+	type args struct {
+		baseURL    *url.URL
+		id         int
+		dependency models.Dependency
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    models.PresentationTodoRecord
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					todo := models.TodoRecord{
+						ID:      23,
+						Date:    time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title:   "test",
+						Version: 5,
+					}
+
+					blockedTodo := todo
+					blockedTodo.BlockerIDs = []int{12}
+					blockedTodo.Blocked = true
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 23).Return(todo, nil).Once()
+					storage.InnerMock.
+						On("GetSingle", 12).
+						Return(models.TodoRecord{ID: 12, Version: 1}, nil)
+					storage.InnerMock.On("GetBlockerIDs", 12).Return([]int{5}, nil)
+					storage.InnerMock.On("GetBlockerIDs", 5).Return([]int(nil), nil)
+					storage.InnerMock.On("AddDependency", 23, 12).Return(nil)
+					storage.InnerMock.On("GetSingle", 23).Return(blockedTodo, nil).Once()
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL:    &url.URL{Scheme: "https", Host: "example.com"},
+				id:         23,
+				dependency: models.Dependency{BlockerID: 12},
+			},
+			want: models.PresentationTodoRecord{
+				URL:       "https://example.com/api/v1/todos/23",
+				Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				Title:     "test",
+				Tags:      []string{},
+				BlockedBy: []int{12},
+				Blocked:   true,
+				Version:   5,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a cycle",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetSingle", 23).
+						Return(models.TodoRecord{ID: 23, Version: 5}, nil)
+					storage.InnerMock.
+						On("GetSingle", 12).
+						Return(models.TodoRecord{ID: 12, Version: 1}, nil)
+					storage.InnerMock.On("GetBlockerIDs", 12).Return([]int{5}, nil)
+					storage.InnerMock.On("GetBlockerIDs", 5).Return([]int{23}, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL:    &url.URL{Scheme: "https", Host: "example.com"},
+				id:         23,
+				dependency: models.Dependency{BlockerID: 12},
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrConflict, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a not found blocker",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetSingle", 23).
+						Return(models.TodoRecord{ID: 23, Version: 5}, nil)
+					storage.InnerMock.
+						On("GetSingle", 12).
+						Return(models.TodoRecord{}, models.ErrNotFound)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL:    &url.URL{Scheme: "https", Host: "example.com"},
+				id:         23,
+				dependency: models.Dependency{BlockerID: 12},
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrConflict, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with validation",
+			fields: fields{
+				Storage: &MockStorage{},
+			},
+			args: args{
+				baseURL:    &url.URL{Scheme: "https", Host: "example.com"},
+				id:         23,
+				dependency: models.Dependency{BlockerID: 0},
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrInvalid, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := TodoRecord{
+				Storage: tt.fields.Storage,
+				Limits:  models.DefaultValidationLimits,
+			}
+			got, err := useCase.AddDependency(
+				tt.args.baseURL,
+				tt.args.id,
+				tt.args.dependency,
+			)
+
+			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestTodoRecord_DeleteDependency(t *testing.T) {
+	type fields struct {
+		Storage TodoRecordStorage
+	}
+	type args struct {
+		id        int
+		blockerID int
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("DeleteDependency", 23, 12).Return(nil)
+
+					return storage
+				}(),
+			},
+			args:    args{id: 23, blockerID: 12},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("DeleteDependency", 23, 12).
+						Return(models.ErrNotFound)
+
+					return storage
+				}(),
+			},
+			args: args{id: 23, blockerID: 12},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrNotFound, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := TodoRecord{
+				Storage: tt.fields.Storage,
+			}
+			err := useCase.DeleteDependency(tt.args.id, tt.args.blockerID)
+
+			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestTodoRecord_GetHistory(t *testing.T) {
+	type fields struct {
+		Storage TodoRecordStorage
+	}
+	type args struct {
+		baseURL *url.URL
+		query   models.HistoryQuery
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		want           []models.PresentationHistoryRecord
+		wantNextCursor string
+		wantErr        assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success without history records",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("GetHistory", models.HistoryQuery{TodoRecordID: 42}).
+						Return([]models.HistoryRecord(nil), nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				query:   models.HistoryQuery{TodoRecordID: 42},
+			},
+			want:    []models.PresentationHistoryRecord{},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with history records",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					records := []models.HistoryRecord{
+						{
+							Revision:     12,
+							TodoRecordID: 42,
+							Action:       models.HistoryActionDelete,
+							OldValue: &models.TodoRecord{
+								ID:      42,
+								Date:    time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+								Title:   "test",
+								Version: 5,
+							},
+							Actor:     "admin",
+							ChangedAt: time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC),
+						},
+					}
+
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("GetHistory", models.HistoryQuery{TodoRecordID: 42}).
+						Return(records, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				query:   models.HistoryQuery{TodoRecordID: 42},
+			},
+			want: []models.PresentationHistoryRecord{
+				{
+					Revision: 12,
+					TodoURL:  "https://example.com/api/v1/todos/42",
+					Action:   models.HistoryActionDelete,
+					OldValue: &models.PresentationTodoRecord{
+						URL:     "https://example.com/api/v1/todos/42",
+						Date:    utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+						Title:   "test",
+						Tags:    []string{},
+						Version: 5,
+					},
+					Actor:     "admin",
+					ChangedAt: time.Date(2006, time.January, 3, 15, 4, 5, 0, time.UTC),
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the next page",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					records := []models.HistoryRecord{
+						{Revision: 12, TodoRecordID: 42, Action: models.HistoryActionCreate},
+						{Revision: 15, TodoRecordID: 42, Action: models.HistoryActionDelete},
+					}
+
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("GetHistory", models.HistoryQuery{
+							TodoRecordID: 42,
+							PageSize:     2,
+							Cursor:       models.HistoryCursor{Revision: 5},
+						}).
+						Return(records, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				query: models.HistoryQuery{
+					TodoRecordID: 42,
+					PageSize:     1,
+					Cursor:       models.HistoryCursor{Revision: 5},
+				},
+			},
+			want: []models.PresentationHistoryRecord{
+				{
+					Revision: 12,
+					TodoURL:  "https://example.com/api/v1/todos/42",
+					Action:   models.HistoryActionCreate,
+				},
+			},
+			wantNextCursor: models.HistoryCursor{Revision: 12}.String(),
+			wantErr:        assert.NoError,
+		},
+		{
+			name: "success on the last page",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					records := []models.HistoryRecord{
+						{Revision: 12, TodoRecordID: 42, Action: models.HistoryActionCreate},
+					}
+
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("GetHistory", models.HistoryQuery{
+							TodoRecordID: 42,
+							PageSize:     3,
+						}).
+						Return(records, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				query:   models.HistoryQuery{TodoRecordID: 42, PageSize: 2},
+			},
+			want: []models.PresentationHistoryRecord{
+				{
+					Revision: 12,
+					TodoURL:  "https://example.com/api/v1/todos/42",
+					Action:   models.HistoryActionCreate,
+				},
+			},
+			wantNextCursor: "",
+			wantErr:        assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("GetHistory", models.HistoryQuery{TodoRecordID: 42}).
+						Return([]models.HistoryRecord(nil), iotest.ErrTimeout)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				query:   models.HistoryQuery{TodoRecordID: 42},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := TodoRecord{
+				Storage: tt.fields.Storage,
+			}
+			got, gotNextCursor, err :=
+				useCase.GetHistory(tt.args.baseURL, tt.args.query)
+
+			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantNextCursor, gotNextCursor)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestTodoRecord_Revert(t *testing.T) {
+	type fields struct {
+		Storage TodoRecordStorage
+	}
+	type args struct {
+		baseURL  *url.URL
+		id       int
+		revision int
+		version  int
+		actor    string
+	}
+
+	oldTodo := models.TodoRecord{
+		ID:      42,
+		Date:    time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title:   "test2",
+		Version: 5,
+	}
+	revisionTodo := models.TodoRecord{
+		ID:      42,
+		Date:    time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title:   "test",
+		Version: 3,
+	}
+	revisionDate := utilmodels.Date(revisionTodo.Date)
+	revisionTitle := revisionTodo.Title
+	// the revision misses the fields introduced after its storing
+	revisionPatch := models.TodoRecordPatch{
+		Date:  &revisionDate,
+		Title: &revisionTitle,
+	}
+	revertedTodo := revisionTodo
+	revertedTodo.Version = 6
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    models.PresentationTodoRecord
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 42).Return(oldTodo, nil)
+					storage.InnerMock.
+						On("GetHistoryRecord", 12).
+						Return(models.HistoryRecord{
+							Revision:      12,
+							TodoRecordID:  42,
+							Action:        models.HistoryActionUpdate,
+							NewValue:      &revisionTodo,
+							NewValuePatch: &revisionPatch,
+						}, nil)
+					storage.InnerMock.
+						On("Patch", 42, revisionPatch, 5).
+						Return(revertedTodo, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 42,
+							Action:       models.HistoryActionRevert,
+							OldValue:     &oldTodo,
+							NewValue:     &revertedTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL:  &url.URL{Scheme: "https", Host: "example.com"},
+				id:       42,
+				revision: 12,
+				version:  5,
+				actor:    "admin",
+			},
+			want: models.PresentationTodoRecord{
+				URL:     "https://example.com/api/v1/todos/42",
+				Date:    utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				Title:   "test",
+				Tags:    []string{},
+				Version: 6,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with completing of a recurring to-do record",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					oldTodo := models.TodoRecord{
+						ID:         42,
+						Date:       time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title:      "test",
+						Recurrence: "FREQ=WEEKLY;COUNT=3",
+						Version:    5,
+					}
+					revisionTodo := oldTodo
+					revisionTodo.Completed = true
+					revisionTodo.Version = 3
+
+					revisionDate := utilmodels.Date(revisionTodo.Date)
+					revisionTitle := revisionTodo.Title
+					completed := true
+					revisionPatch := models.TodoRecordPatch{
+						Date:      &revisionDate,
+						Title:     &revisionTitle,
+						Completed: &completed,
+					}
+
+					revertedTodo := oldTodo
+					revertedTodo.Completed = true
+					revertedTodo.Version = 6
+
+					nextTodo := models.TodoRecord{
+						Date:       time.Date(2006, time.January, 9, 0, 0, 0, 0, time.UTC),
+						Title:      "test",
+						Recurrence: "FREQ=WEEKLY;COUNT=2",
+					}
+					createdNextTodo := nextTodo
+					createdNextTodo.ID = 43
+					createdNextTodo.Version = 1
+
+					noRecurrence := ""
+					finalTodo := revertedTodo
+					finalTodo.Recurrence = ""
+					finalTodo.Version = 7
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 42).Return(oldTodo, nil)
+					storage.InnerMock.
+						On("GetHistoryRecord", 12).
+						Return(models.HistoryRecord{
+							Revision:      12,
+							TodoRecordID:  42,
+							Action:        models.HistoryActionPatch,
+							NewValue:      &revisionTodo,
+							NewValuePatch: &revisionPatch,
+						}, nil)
+					storage.InnerMock.
+						On("Patch", 42, revisionPatch, 5).
+						Return(revertedTodo, nil)
+					storage.InnerMock.On("Create", nextTodo).Return(createdNextTodo, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 43,
+							Action:       models.HistoryActionCreate,
+							NewValue:     &createdNextTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
+					storage.InnerMock.
+						On(
+							"Patch",
+							42,
+							models.TodoRecordPatch{Recurrence: &noRecurrence},
+							0,
+						).
+						Return(finalTodo, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 42,
+							Action:       models.HistoryActionRevert,
+							OldValue:     &oldTodo,
+							NewValue:     &finalTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL:  &url.URL{Scheme: "https", Host: "example.com"},
+				id:       42,
+				revision: 12,
+				version:  5,
+				actor:    "admin",
+			},
+			want: models.PresentationTodoRecord{
+				URL:       "https://example.com/api/v1/todos/42",
+				Date:      utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				Title:     "test",
+				Completed: true,
+				Tags:      []string{},
+				Version:   7,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with the open blockers",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					oldTodo := models.TodoRecord{
+						ID:         42,
+						Title:      "test",
+						Version:    5,
+						BlockerIDs: []int{12},
+						Blocked:    true,
+					}
+					revisionTodo := oldTodo
+					revisionTodo.Completed = true
+					revisionTodo.Version = 3
+
+					revisionTitle := revisionTodo.Title
+					completed := true
+					revisionPatch := models.TodoRecordPatch{
+						Title:     &revisionTitle,
+						Completed: &completed,
+					}
+
+					revertedTodo := oldTodo
+					revertedTodo.Completed = true
+					revertedTodo.Version = 6
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 42).Return(oldTodo, nil)
+					storage.InnerMock.
+						On("GetHistoryRecord", 12).
+						Return(models.HistoryRecord{
+							Revision:      12,
+							TodoRecordID:  42,
+							Action:        models.HistoryActionPatch,
+							NewValue:      &revisionTodo,
+							NewValuePatch: &revisionPatch,
+						}, nil)
+					storage.InnerMock.
+						On("Patch", 42, revisionPatch, 5).
+						Return(revertedTodo, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL:  &url.URL{Scheme: "https", Host: "example.com"},
+				id:       42,
+				revision: 12,
+				version:  5,
+				actor:    "admin",
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrBlocked, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a revision of another to-do record",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 42).Return(oldTodo, nil)
+					storage.InnerMock.
+						On("GetHistoryRecord", 12).
+						Return(models.HistoryRecord{
+							Revision:     12,
+							TodoRecordID: 23,
+							Action:       models.HistoryActionUpdate,
+							NewValue:     &revisionTodo,
+						}, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL:  &url.URL{Scheme: "https", Host: "example.com"},
+				id:       42,
+				revision: 12,
+				actor:    "admin",
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrNotFound, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a revision of the deletion",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.On("GetSingle", 42).Return(oldTodo, nil)
+					storage.InnerMock.
+						On("GetHistoryRecord", 12).
+						Return(models.HistoryRecord{
+							Revision:     12,
+							TodoRecordID: 42,
+							Action:       models.HistoryActionDelete,
+							OldValue:     &revisionTodo,
+						}, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL:  &url.URL{Scheme: "https", Host: "example.com"},
+				id:       42,
+				revision: 12,
+				actor:    "admin",
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrInvalid, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a not found to-do record",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil)
+					storage.InnerMock.
+						On("GetSingle", 42).
+						Return(models.TodoRecord{}, models.ErrNotFound)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL:  &url.URL{Scheme: "https", Host: "example.com"},
+				id:       42,
+				revision: 12,
+				actor:    "admin",
+			},
+			want: models.PresentationTodoRecord{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrNotFound, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := TodoRecord{
+				Storage: tt.fields.Storage,
+			}
+			got, err := useCase.Revert(
+				tt.args.baseURL,
+				tt.args.id,
+				tt.args.revision,
+				tt.args.version,
+				tt.args.actor,
+			)
+
+			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestTodoRecord_GetOccurrences(t *testing.T) {
+	type fields struct {
+		Storage TodoRecordStorage
+	}
+	type args struct {
+		baseURL     *url.URL
+		id          int
+		minimalDate time.Time
+		maximalDate time.Time
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []models.PresentationTodoRecord
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the recurring to-do record",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					todo := models.TodoRecord{
+						ID:    23,
+						Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title: "test",
+						DueAt: func() *time.Time {
+							dueAt := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+							return &dueAt
+						}(),
+						Recurrence: "FREQ=DAILY;INTERVAL=2",
+					}
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("GetSingle", 23).Return(todo, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL:     &url.URL{Scheme: "https", Host: "example.com"},
+				id:          23,
+				minimalDate: time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+				maximalDate: time.Date(2006, time.January, 6, 0, 0, 0, 0, time.UTC),
+			},
+			want: []models.PresentationTodoRecord{
+				{
+					URL:  "https://example.com/api/v1/todos/23",
+					Date: utilmodels.Date(time.Date(2006, time.January, 4, 0, 0, 0, 0, time.UTC)),
+					DueAt: func() *time.Time {
+						dueAt := time.Date(2006, time.January, 4, 15, 4, 5, 0, time.UTC)
+						return &dueAt
+					}(),
+					Title:      "test",
+					Tags:       []string{},
+					Recurrence: "FREQ=DAILY;INTERVAL=2",
+				},
+				{
+					URL:  "https://example.com/api/v1/todos/23",
+					Date: utilmodels.Date(time.Date(2006, time.January, 6, 0, 0, 0, 0, time.UTC)),
+					DueAt: func() *time.Time {
+						dueAt := time.Date(2006, time.January, 6, 15, 4, 5, 0, time.UTC)
+						return &dueAt
+					}(),
+					Title:      "test",
+					Tags:       []string{},
+					Recurrence: "FREQ=DAILY;INTERVAL=2",
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the non-recurring to-do record",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					todo := models.TodoRecord{
+						ID:    23,
+						Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						Title: "test",
+					}
+
+					storage := &MockStorage{}
+					storage.InnerMock.On("GetSingle", 23).Return(todo, nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL:     &url.URL{Scheme: "https", Host: "example.com"},
+				id:          23,
+				minimalDate: time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+				maximalDate: time.Date(2006, time.January, 31, 0, 0, 0, 0, time.UTC),
+			},
+			want: []models.PresentationTodoRecord{
+				{
+					URL:   "https://example.com/api/v1/todos/23",
+					Date:  utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					Title: "test",
+					Tags:  []string{},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.
+						On("GetSingle", 23).
+						Return(models.TodoRecord{}, iotest.ErrTimeout)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL:     &url.URL{Scheme: "https", Host: "example.com"},
+				id:          23,
+				minimalDate: time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+				maximalDate: time.Date(2006, time.January, 31, 0, 0, 0, 0, time.UTC),
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := TodoRecord{
+				Storage: tt.fields.Storage,
+			}
+			got, err := useCase.GetOccurrences(
+				tt.args.baseURL,
+				tt.args.id,
+				tt.args.minimalDate,
+				tt.args.maximalDate,
+			)
+
+			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestTodoRecord_PurgeTrash(t *testing.T) {
+	type fields struct {
+		Storage TodoRecordStorage
+	}
+	type args struct {
+		retention time.Duration
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("Purge", time.Hour).Return(5, nil)
+
+					return storage
+				}(),
+			},
+			args:    args{retention: time.Hour},
+			want:    5,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("Purge", time.Hour).Return(0, iotest.ErrTimeout)
+
+					return storage
+				}(),
+			},
+			args:    args{retention: time.Hour},
+			want:    0,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := TodoRecord{
+				Storage: tt.fields.Storage,
+			}
+			got, err := useCase.PurgeTrash(tt.args.retention)
+
+			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestTodoRecord_Batch(t *testing.T) {
+	type fields struct {
+		Storage     TodoRecordStorage
+		DefaultSort []models.SortField
+	}
+	type args struct {
+		baseURL *url.URL
+		batch   models.Batch
+		actor   string
+	}
+
+	todo := models.TodoRecord{
+		Date:  time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+		Title: "test",
+		Order: 23,
+	}
+	createdTodo := todo
+	createdTodo.ID = 42
+	createdTodo.Version = 1
+	creationHistoryRecord := models.HistoryRecord{
+		TodoRecordID: 42,
+		Action:       models.HistoryActionCreate,
+		NewValue:     &createdTodo,
+		Actor:        "admin",
+	}
+
+	deletedTodo := models.TodoRecord{ID: 12, Title: "test2", Version: 5}
+	deletedParentTodo := deletedTodo
+	deletedParentTodo.ChildCount = 1
+	deletedChildTodo := models.TodoRecord{ID: 13, Title: "test3", ParentID: 12}
+	defaultSort := []models.SortField{
+		{Name: models.SortByPriority, Descending: true},
+		{Name: models.SortByID},
+	}
+
+	presentationTodo := models.PresentationTodoRecord{
+		Date:  utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+		Title: "test",
+		Order: 23,
+	}
+	createdPresentationTodo := models.PresentationTodoRecord{
+		URL:     "https://example.com/api/v1/todos/42",
+		Date:    utilmodels.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+		Title:   "test",
+		Order:   23,
+		Tags:    []string{},
+		Version: 1,
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []models.BatchResult
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil).Times(5)
+					storage.InnerMock.On("Create", todo).Return(createdTodo, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", creationHistoryRecord).
+						Return(nil)
+					storage.InnerMock.On("GetSingle", 12).Return(deletedTodo, nil)
+					storage.InnerMock.On("DeleteSingle", 12, 5).Return(nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 12,
+							Action:       models.HistoryActionDelete,
+							OldValue:     &deletedTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				batch: models.Batch{
+					Operations: []models.BatchOperation{
+						{Action: models.BatchActionCreate, Todo: &presentationTodo},
+						{Action: models.BatchActionDelete, ID: 12, Version: 5},
+					},
+				},
+				actor: "admin",
+			},
+			want: []models.BatchResult{
+				{Todo: &createdPresentationTodo},
+				{},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the default sort",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil).Times(3)
+					storage.InnerMock.On("GetSingle", 12).Return(deletedParentTodo, nil)
+					storage.InnerMock.
+						On("GetAll", models.Query{ParentID: 12, Sort: defaultSort}).
+						Return([]models.TodoRecord{deletedChildTodo}, nil)
+					storage.InnerMock.On("DeleteSingle", 13, 0).Return(nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 13,
+							Action:       models.HistoryActionDelete,
+							OldValue:     &deletedChildTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
+					storage.InnerMock.On("DeleteSingle", 12, 5).Return(nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", models.HistoryRecord{
+							TodoRecordID: 12,
+							Action:       models.HistoryActionDelete,
+							OldValue:     &deletedParentTodo,
+							Actor:        "admin",
+						}).
+						Return(nil)
+
+					return storage
+				}(),
+				DefaultSort: defaultSort,
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				batch: models.Batch{
+					Operations: []models.BatchOperation{
+						{
+							Action:  models.BatchActionDelete,
+							ID:      12,
+							Version: 5,
+							Cascade: true,
+						},
+					},
+				},
+				actor: "admin",
+			},
+			want:    []models.BatchResult{{}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a failed operation in the all-or-nothing mode",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil).Times(5)
+					storage.InnerMock.On("Create", todo).Return(createdTodo, nil)
+					storage.InnerMock.
+						On("AddHistoryRecord", creationHistoryRecord).
+						Return(nil)
+					storage.InnerMock.
+						On("GetSingle", 12).
+						Return(models.TodoRecord{}, models.ErrNotFound)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				batch: models.Batch{
+					Mode: models.BatchModeAllOrNothing,
+					Operations: []models.BatchOperation{
+						{Action: models.BatchActionCreate, Todo: &presentationTodo},
+						{Action: models.BatchActionDelete, ID: 12, Version: 5},
+						{Action: models.BatchActionCreate, Todo: &presentationTodo},
+					},
+				},
+				actor: "admin",
+			},
+			want: []models.BatchResult{
+				{Err: models.ErrRolledBack},
+				{Err: models.ErrNotFound},
+				{Err: models.ErrRolledBack},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a failed operation in the best-effort mode",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(nil).Times(7)
+					storage.InnerMock.On("Create", todo).Return(createdTodo, nil).Twice()
+					storage.InnerMock.
+						On("AddHistoryRecord", creationHistoryRecord).
+						Return(nil).
+						Twice()
+					storage.InnerMock.
+						On("GetSingle", 12).
+						Return(models.TodoRecord{}, models.ErrNotFound)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				batch: models.Batch{
+					Mode: models.BatchModeBestEffort,
+					Operations: []models.BatchOperation{
+						{Action: models.BatchActionCreate, Todo: &presentationTodo},
+						{Action: models.BatchActionDelete, ID: 12, Version: 5},
+						{Action: models.BatchActionCreate, Todo: &presentationTodo},
+					},
+				},
+				actor: "admin",
+			},
+			want: []models.BatchResult{
+				{Todo: &createdPresentationTodo},
+				{Err: models.ErrNotFound},
+				{Todo: &createdPresentationTodo},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with validation",
+			fields: fields{
+				Storage: &MockStorage{},
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				batch: models.Batch{
+					Operations: []models.BatchOperation{
+						{Action: models.BatchActionDelete},
+					},
+				},
+				actor: "admin",
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrInvalid, msgAndArgs...)
+			},
+		},
+		{
+			name: "error on the transaction",
+			fields: fields{
+				Storage: func() TodoRecordStorage {
+					storage := &MockStorage{}
+					storage.InnerMock.On("InTransaction").Return(iotest.ErrTimeout)
+
+					return storage
+				}(),
+			},
+			args: args{
+				baseURL: &url.URL{Scheme: "https", Host: "example.com"},
+				batch: models.Batch{
+					Operations: []models.BatchOperation{
+						{Action: models.BatchActionDelete, ID: 12, Version: 5},
+					},
+				},
+				actor: "admin",
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := TodoRecord{
+				Storage:     tt.fields.Storage,
+				Limits:      models.DefaultValidationLimits,
+				DefaultSort: tt.fields.DefaultSort,
+			}
+			got, err := useCase.Batch(tt.args.baseURL, tt.args.batch, tt.args.actor)
+
+			tt.fields.Storage.(*MockStorage).InnerMock.AssertExpectations(t)
+			if assert.Len(t, got, len(tt.want)) {
+				for index, result := range got {
+					assert.Equal(t, tt.want[index].Todo, result.Todo)
+					if tt.want[index].Err != nil {
+						assert.ErrorIs(t, result.Err, tt.want[index].Err)
+					} else {
+						assert.NoError(t, result.Err)
+					}
+				}
+			}
 			tt.wantErr(t, err)
 		})
 	}